@@ -0,0 +1,53 @@
+package alpaca
+
+// IsTrade returns true for activity types that represent executions.
+// All other activity types are non-trade activities.
+func (t ActivityType) IsTrade() bool {
+	return t == ActivityFill
+}
+
+// AsTradeActivity returns the activity as a TradeActivity.
+// The second return value is false if the activity is not an execution.
+func (a AccountActivity) AsTradeActivity() (TradeActivity, bool) {
+	if !a.ActivityType.IsTrade() {
+		return TradeActivity{}, false
+	}
+	return TradeActivity{
+		ID:              a.ID,
+		ActivityType:    a.ActivityType,
+		TransactionTime: a.TransactionTime,
+		Type:            a.Type,
+		Price:           a.Price,
+		Qty:             a.Qty,
+		Side:            a.Side,
+		Symbol:          a.Symbol,
+		LeavesQty:       a.LeavesQty,
+		CumQty:          a.CumQty,
+		OrderID:         a.OrderID,
+		OrderStatus:     a.OrderStatus,
+		SwapRate:        a.SwapRate,
+	}, true
+}
+
+// AsNonTradeActivity returns the activity as a NonTradeActivity.
+// The second return value is false if the activity is an execution.
+func (a AccountActivity) AsNonTradeActivity() (NonTradeActivity, bool) {
+	if a.ActivityType.IsTrade() {
+		return NonTradeActivity{}, false
+	}
+	return NonTradeActivity{
+		ID:              a.ID,
+		ActivityType:    a.ActivityType,
+		ActivitySubType: a.ActivitySubType,
+		Date:            a.Date,
+		NetAmount:       a.NetAmount,
+		Description:     a.Description,
+		Symbol:          a.Symbol,
+		CUSIP:           a.CUSIP,
+		Qty:             a.Qty,
+		PerShareAmount:  a.PerShareAmount,
+		Status:          a.Status,
+		GroupID:         a.GroupID,
+		CreatedAt:       a.CreatedAt,
+	}, true
+}
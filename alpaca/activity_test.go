@@ -0,0 +1,189 @@
+package alpaca
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountActivityAccessors(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "FILL,JNLC", req.URL.Query().Get("activity_types"))
+		return &http.Response{
+			Body: io.NopCloser(strings.NewReader(`[
+				{
+					"activity_type": "FILL",
+					"id": "20240624093004214::18a82342-245e-4e8a-9703-87ae38d9b629",
+					"transaction_time": "2024-06-24T13:30:04.214535Z",
+					"type": "fill",
+					"price": "3.8",
+					"qty": "10",
+					"side": "buy",
+					"symbol": "AAPL",
+					"leaves_qty": "0",
+					"cum_qty": "10",
+					"order_id": "c0e497c2-a547-48cd-85dc-0f1f0ed1b26c",
+					"order_status": "filled"
+				},
+				{
+					"activity_type": "JNLC",
+					"activity_sub_type": "GIFT",
+					"id": "20240625000000000::8ed9e0c2-7d0d-4b8f-a1b5-6fd3a7f6d0e3",
+					"date": "2024-06-25",
+					"net_amount": "100",
+					"description": "cash journal",
+					"status": "executed",
+					"group_id": "group",
+					"created_at": "2024-06-25T10:00:00Z"
+				}
+			]`)),
+		}, nil
+	}
+
+	activities, err := c.GetAccountActivities(GetAccountActivitiesRequest{
+		ActivityTypes: []ActivityType{ActivityFill, ActivityJournalCash},
+	})
+	require.NoError(t, err)
+	require.Len(t, activities, 2)
+
+	fill, ok := activities[0].AsTradeActivity()
+	require.True(t, ok)
+	assert.Equal(t, FillTypeFill, fill.Type)
+	assert.Equal(t, Buy, fill.Side)
+	assert.Equal(t, OrderStatusFilled, fill.OrderStatus)
+	assert.True(t, decimal.NewFromInt(10).Equal(fill.Qty))
+	_, ok = activities[0].AsNonTradeActivity()
+	assert.False(t, ok)
+
+	journal, ok := activities[1].AsNonTradeActivity()
+	require.True(t, ok)
+	assert.Equal(t, ActivityJournalCash, journal.ActivityType)
+	assert.Equal(t, "GIFT", journal.ActivitySubType)
+	assert.Equal(t, civil.Date{Year: 2024, Month: 6, Day: 25}, journal.Date)
+	assert.True(t, decimal.NewFromInt(100).Equal(journal.NetAmount))
+	assert.Equal(t, "group", journal.GroupID)
+	require.NotNil(t, journal.CreatedAt)
+	assert.True(t, time.Date(2024, 6, 25, 10, 0, 0, 0, time.UTC).Equal(*journal.CreatedAt))
+	_, ok = activities[1].AsTradeActivity()
+	assert.False(t, ok)
+}
+
+func TestForEachAccountActivity(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	var all []AccountActivity
+	for i := 0; i < 5; i++ {
+		all = append(all, AccountActivity{
+			ID: fmt.Sprintf("id%d", i), ActivityType: ActivityFee, Date: civil.Date{Year: 2024, Month: 1, Day: 2},
+		})
+	}
+	var tokens []string
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		assert.Equal(t, "asc", q.Get("direction"))
+		assert.Equal(t, "2024-01-01T00:00:00Z", q.Get("after"))
+		token := q.Get("page_token")
+		tokens = append(tokens, token)
+		pageSize, err := strconv.Atoi(q.Get("page_size"))
+		require.NoError(t, err)
+		start := 0
+		for i, a := range all {
+			if a.ID == token {
+				start = i + 1
+			}
+		}
+		end := start + pageSize
+		if end > len(all) {
+			end = len(all)
+		}
+		return &http.Response{Body: genBody(all[start:end])}, nil
+	}
+
+	req := GetAccountActivitiesRequest{
+		After:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Direction: "asc",
+		PageSize:  2,
+	}
+	var ids []string
+	err := c.ForEachAccountActivity(req, func(activity AccountActivity, err error) bool {
+		require.NoError(t, err)
+		ids = append(ids, activity.ID)
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id0", "id1", "id2", "id3", "id4"}, ids)
+	assert.Equal(t, []string{"", "id1", "id3"}, tokens)
+
+	// total limit
+	ids, tokens = nil, nil
+	req.TotalLimit = 3
+	err = c.ForEachAccountActivity(req, func(activity AccountActivity, err error) bool {
+		ids = append(ids, activity.ID)
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id0", "id1", "id2"}, ids)
+	assert.Equal(t, []string{"", "id1"}, tokens)
+
+	// stopped by the callback
+	ids, tokens = nil, nil
+	req.TotalLimit = 0
+	err = c.ForEachAccountActivity(req, func(activity AccountActivity, err error) bool {
+		ids = append(ids, activity.ID)
+		return activity.ID != "id2"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id0", "id1", "id2"}, ids)
+
+	// error
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		return nil, &APIError{StatusCode: http.StatusInternalServerError, Message: "internal server error"}
+	}
+	var cbErr error
+	err = c.ForEachAccountActivity(req, func(activity AccountActivity, err error) bool {
+		cbErr = err
+		return true
+	})
+	require.Error(t, err)
+	assert.Equal(t, err, cbErr)
+}
+
+func TestForEachAccountActivityPageSizeCap(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	var pageSizes []string
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		pageSizes = append(pageSizes, q.Get("page_size"))
+		// The server returns at most 100 activities per page, 150 in total.
+		n := 100
+		if q.Get("page_token") != "" {
+			n = 50
+		}
+		activities := make([]AccountActivity, n)
+		for i := range activities {
+			activities[i] = AccountActivity{
+				ID: fmt.Sprintf("%s-%d", q.Get("page_token"), i), Date: civil.Date{Year: 2024, Month: 1, Day: 2},
+			}
+		}
+		return &http.Response{Body: genBody(activities)}, nil
+	}
+
+	count := 0
+	err := c.ForEachAccountActivity(GetAccountActivitiesRequest{PageSize: 500}, func(activity AccountActivity, err error) bool {
+		require.NoError(t, err)
+		count++
+		return true
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 150, count)
+	assert.Equal(t, []string{"100", "100"}, pageSizes)
+}
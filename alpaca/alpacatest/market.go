@@ -0,0 +1,106 @@
+package alpacatest
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+)
+
+// newYork is the time zone of the market calendar. The fixed EST offset is only
+// used if the time zone database is not available.
+var newYork = func() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return time.FixedZone("EST", -5*60*60)
+	}
+	return loc
+}()
+
+const calendarDateLayout = "2006-01-02"
+
+func (s *Server) handleClock(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) != 0 || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, 40410000, "endpoint not found")
+		return
+	}
+	if s.clock != nil {
+		writeJSON(w, http.StatusOK, s.clock)
+		return
+	}
+
+	now := s.opts.Now().In(newYork)
+	clock := alpaca.Clock{Timestamp: now}
+	// Sessions are looked up in the two weeks around now, which covers the longest market holidays.
+	for _, day := range s.calendar(now.AddDate(0, 0, -7), now.AddDate(0, 0, 14)) {
+		openAt, closeAt, ok := sessionTimes(day)
+		if !ok {
+			continue
+		}
+		if !now.Before(openAt) && now.Before(closeAt) {
+			clock.IsOpen = true
+		}
+		if clock.NextOpen.IsZero() && openAt.After(now) {
+			clock.NextOpen = openAt
+		}
+		if clock.NextClose.IsZero() && closeAt.After(now) {
+			clock.NextClose = closeAt
+		}
+	}
+	writeJSON(w, http.StatusOK, clock)
+}
+
+func (s *Server) handleCalendar(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) != 0 || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, 40410000, "endpoint not found")
+		return
+	}
+	now := s.opts.Now().In(newYork)
+	start, end := now, now.AddDate(0, 0, 30)
+	if t, err := time.ParseInLocation(calendarDateLayout, r.URL.Query().Get("start"), newYork); err == nil {
+		start = t
+	}
+	if t, err := time.ParseInLocation(calendarDateLayout, r.URL.Query().Get("end"), newYork); err == nil {
+		end = t
+	}
+	writeJSON(w, http.StatusOK, s.calendar(start, end))
+}
+
+// calendar returns the trading days between start and end, inclusive.
+func (s *Server) calendar(start, end time.Time) []alpaca.CalendarDay {
+	first, last := start.Format(calendarDateLayout), end.Format(calendarDateLayout)
+	days := []alpaca.CalendarDay{}
+	if len(s.opts.Calendar) > 0 {
+		for _, day := range s.opts.Calendar {
+			if day.Date >= first && day.Date <= last {
+				days = append(days, day)
+			}
+		}
+		return days
+	}
+	for d := start; d.Format(calendarDateLayout) <= last; d = d.AddDate(0, 0, 1) {
+		if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			continue
+		}
+		days = append(days, alpaca.CalendarDay{
+			Date: d.Format(calendarDateLayout), Open: "09:30", Close: "16:00", SessionOpen: "0400", SessionClose: "2000",
+		})
+	}
+	return days
+}
+
+// sessionTimes returns the open and close time of day.
+func sessionTimes(day alpaca.CalendarDay) (openAt, closeAt time.Time, ok bool) {
+	var err error
+	openAt, err = time.ParseInLocation(calendarDateLayout+" 15:04", day.Date+" "+day.Open, newYork)
+	if err != nil {
+		return openAt, closeAt, false
+	}
+	closeAt, err = time.ParseInLocation(calendarDateLayout+" 15:04", day.Date+" "+day.Close, newYork)
+	return openAt, closeAt, err == nil
+}
+
+func sortAssets(assets []alpaca.Asset) {
+	sort.Slice(assets, func(i, j int) bool { return assets[i].Symbol < assets[j].Symbol })
+}
@@ -0,0 +1,586 @@
+package alpacatest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+)
+
+// errResponse is an error that is sent to the client as an API error.
+type errResponse struct {
+	status  int
+	code    int
+	message string
+}
+
+func (e *errResponse) Error() string {
+	return e.message
+}
+
+func unprocessable(format string, args ...interface{}) *errResponse {
+	return &errResponse{status: http.StatusUnprocessableEntity, code: 42210000, message: fmt.Sprintf(format, args...)}
+}
+
+func writeErrResponse(w http.ResponseWriter, err *errResponse) {
+	writeError(w, err.status, err.code, err.message)
+}
+
+func (s *Server) handleOrders(w http.ResponseWriter, r *http.Request, parts []string) {
+	if parts[0] == "orders:by_client_order_id" {
+		if len(parts) != 1 || r.Method != http.MethodGet {
+			writeError(w, http.StatusNotFound, 40410000, "endpoint not found")
+			return
+		}
+		for _, o := range s.orders {
+			if o.ClientOrderID == r.URL.Query().Get("client_order_id") {
+				writeJSON(w, http.StatusOK, o)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, 40410000, "order not found")
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			s.listOrders(w, r)
+		case http.MethodPost:
+			var req alpaca.PlaceOrderRequest
+			if !readJSON(w, r, &req) {
+				return
+			}
+			o, err := s.placeOrder(req)
+			if err != nil {
+				writeErrResponse(w, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, o)
+		case http.MethodDelete:
+			results := []map[string]interface{}{}
+			for _, o := range s.orders {
+				if o.Status.IsOpen() {
+					s.cancelOrder(o)
+					results = append(results, map[string]interface{}{"id": o.ID, "status": http.StatusOK, "body": o})
+				}
+			}
+			writeJSON(w, http.StatusMultiStatus, results)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, 40510000, "method not allowed")
+		}
+		return
+	}
+
+	o := s.findOrder(parts[1])
+	if o == nil || len(parts) > 2 {
+		writeError(w, http.StatusNotFound, 40410000, "order not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, o)
+	case http.MethodPatch:
+		var req alpaca.ReplaceOrderRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		replacement, err := s.replaceOrder(o, req)
+		if err != nil {
+			writeErrResponse(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, replacement)
+	case http.MethodDelete:
+		if !o.Status.CanCancel() {
+			writeError(w, http.StatusUnprocessableEntity, 42210000, fmt.Sprintf("order is %s", o.Status))
+			return
+		}
+		s.cancelOrder(o)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, 40510000, "method not allowed")
+	}
+}
+
+func (s *Server) findOrder(orderID string) *alpaca.Order {
+	for _, o := range s.orders {
+		if o.ID == orderID {
+			return o
+		}
+	}
+	return nil
+}
+
+func (s *Server) listOrders(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	status := q.Get("status")
+	if status == "" {
+		status = "open"
+	}
+	limit := 50
+	if l, err := strconv.Atoi(q.Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+	symbols := map[string]bool{}
+	for _, symbol := range strings.Split(q.Get("symbols"), ",") {
+		if symbol != "" {
+			symbols[symbol] = true
+		}
+	}
+	after, _ := time.Parse(time.RFC3339, q.Get("after"))
+	until, _ := time.Parse(time.RFC3339, q.Get("until"))
+
+	orders := []*alpaca.Order{}
+	for _, o := range s.orders {
+		switch {
+		case status == "open" && !o.Status.IsOpen(),
+			status == "closed" && o.Status.IsOpen(),
+			len(symbols) > 0 && !symbols[o.Symbol],
+			q.Get("side") != "" && string(o.Side) != q.Get("side"),
+			!after.IsZero() && !o.SubmittedAt.After(after),
+			!until.IsZero() && !o.SubmittedAt.Before(until):
+			continue
+		}
+		orders = append(orders, o)
+	}
+	if q.Get("direction") != "asc" {
+		for i, j := 0, len(orders)-1; i < j; i, j = i+1, j-1 {
+			orders[i], orders[j] = orders[j], orders[i]
+		}
+	}
+	if len(orders) > limit {
+		orders = orders[:limit]
+	}
+	writeJSON(w, http.StatusOK, orders)
+}
+
+func (s *Server) placeOrder(req alpaca.PlaceOrderRequest) (*alpaca.Order, *errResponse) {
+	asset, ok := s.assets[req.Symbol]
+	if !ok {
+		return nil, unprocessable("asset %q not found", req.Symbol)
+	}
+	if !asset.Tradable {
+		return nil, unprocessable("asset %s is not tradable", req.Symbol)
+	}
+	if err := validateOrder(req); err != nil {
+		return nil, err
+	}
+	if req.ClientOrderID != "" {
+		for _, o := range s.orders {
+			if o.ClientOrderID == req.ClientOrderID {
+				return nil, unprocessable("client_order_id must be unique")
+			}
+		}
+	}
+	if req.Side == alpaca.Buy {
+		if err := s.checkBuyingPower(req); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.opts.Now()
+	o := &alpaca.Order{
+		ID:             s.newID(),
+		ClientOrderID:  req.ClientOrderID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		SubmittedAt:    now,
+		AssetID:        asset.ID,
+		Symbol:         asset.Symbol,
+		AssetClass:     asset.Class,
+		OrderClass:     alpaca.Simple,
+		Type:           req.Type,
+		Side:           req.Side,
+		TimeInForce:    req.TimeInForce,
+		Status:         alpaca.OrderStatusNew,
+		Notional:       req.Notional,
+		Qty:            req.Qty,
+		LimitPrice:     req.LimitPrice,
+		StopPrice:      req.StopPrice,
+		TrailPrice:     req.TrailPrice,
+		TrailPercent:   req.TrailPercent,
+		ExtendedHours:  req.ExtendedHours,
+		PositionIntent: req.PositionIntent,
+	}
+	if o.ClientOrderID == "" {
+		o.ClientOrderID = s.newID()
+	}
+	s.orders = append(s.orders, o)
+	s.publish(alpaca.TradeEventNew, o, nil)
+	s.tryFill(o)
+	if o.Status.IsOpen() && (o.TimeInForce == alpaca.IOC || o.TimeInForce == alpaca.FOK) {
+		s.cancelOrder(o)
+	}
+	return o, nil
+}
+
+func validateOrder(req alpaca.PlaceOrderRequest) *errResponse {
+	if req.OrderClass != "" && req.OrderClass != alpaca.Simple {
+		return unprocessable("order class %s is not supported by the fake server", req.OrderClass)
+	}
+	if (req.Qty == nil) == (req.Notional == nil) {
+		return unprocessable("qty or notional is required")
+	}
+	if req.Qty != nil && !req.Qty.IsPositive() {
+		return unprocessable("qty must be > 0")
+	}
+	if req.Notional != nil && (!req.Notional.IsPositive() || req.Type != alpaca.Market) {
+		return unprocessable("notional must be > 0 and is only allowed for market orders")
+	}
+	if req.Side != alpaca.Buy && req.Side != alpaca.Sell {
+		return unprocessable("invalid side")
+	}
+	if req.TimeInForce == "" {
+		return unprocessable("time_in_force is required")
+	}
+	hasLimit, hasStop := req.LimitPrice != nil, req.StopPrice != nil
+	hasTrail := (req.TrailPrice != nil) != (req.TrailPercent != nil)
+	switch req.Type {
+	case alpaca.Market:
+		if hasLimit || hasStop {
+			return unprocessable("market orders must not have a limit or stop price")
+		}
+	case alpaca.Limit:
+		if !hasLimit || hasStop {
+			return unprocessable("limit orders require a limit price")
+		}
+	case alpaca.Stop:
+		if hasLimit || !hasStop {
+			return unprocessable("stop orders require a stop price")
+		}
+	case alpaca.StopLimit:
+		if !hasLimit || !hasStop {
+			return unprocessable("stop limit orders require a limit and a stop price")
+		}
+	case alpaca.TrailingStop:
+		if !hasTrail {
+			return unprocessable("trailing stop orders require either a trail price or a trail percent")
+		}
+	default:
+		return unprocessable("invalid order type")
+	}
+	return nil
+}
+
+// checkBuyingPower rejects buy orders whose estimated cost exceeds the buying power.
+// The cost is unknown, and not checked, if there is no price for the symbol yet.
+func (s *Server) checkBuyingPower(req alpaca.PlaceOrderRequest) *errResponse {
+	var cost decimal.Decimal
+	switch {
+	case req.Notional != nil:
+		cost = *req.Notional
+	case req.LimitPrice != nil:
+		cost = req.Qty.Mul(*req.LimitPrice)
+	default:
+		price, ok := s.prices[req.Symbol]
+		if !ok {
+			return nil
+		}
+		cost = req.Qty.Mul(price)
+	}
+	if cost.GreaterThan(s.buyingPower()) {
+		return &errResponse{status: http.StatusForbidden, code: 40310000, message: "insufficient buying power"}
+	}
+	return nil
+}
+
+func (s *Server) replaceOrder(o *alpaca.Order, req alpaca.ReplaceOrderRequest) (*alpaca.Order, *errResponse) {
+	if !o.Status.CanReplace() {
+		return nil, unprocessable("order is %s", o.Status)
+	}
+	now := s.opts.Now()
+	replacement := *o
+	replacement.ID = s.newID()
+	replacement.ClientOrderID = req.ClientOrderID
+	if replacement.ClientOrderID == "" {
+		replacement.ClientOrderID = s.newID()
+	}
+	replacement.CreatedAt, replacement.UpdatedAt, replacement.SubmittedAt = now, now, now
+	replacement.Replaces = &o.ID
+	if req.Qty != nil {
+		replacement.Qty = req.Qty
+	}
+	if req.LimitPrice != nil {
+		replacement.LimitPrice = req.LimitPrice
+	}
+	if req.StopPrice != nil {
+		replacement.StopPrice = req.StopPrice
+	}
+	if req.Trail != nil {
+		if replacement.TrailPercent != nil {
+			replacement.TrailPercent = req.Trail
+		} else {
+			replacement.TrailPrice = req.Trail
+		}
+		replacement.HWM = nil
+	}
+	if req.TimeInForce != "" {
+		replacement.TimeInForce = req.TimeInForce
+	}
+
+	o.Status = alpaca.OrderStatusReplaced
+	o.ReplacedBy = &replacement.ID
+	o.ReplacedAt, o.UpdatedAt = &now, now
+	s.publish(alpaca.TradeEventReplaced, o, nil)
+
+	s.orders = append(s.orders, &replacement)
+	s.publish(alpaca.TradeEventNew, &replacement, nil)
+	s.tryFill(&replacement)
+	return &replacement, nil
+}
+
+func (s *Server) cancelOrder(o *alpaca.Order) {
+	now := s.opts.Now()
+	o.Status = alpaca.OrderStatusCanceled
+	o.CanceledAt, o.UpdatedAt = &now, now
+	s.publish(alpaca.TradeEventCanceled, o, nil)
+}
+
+// tryFill fills o completely at the current price if its conditions are met.
+func (s *Server) tryFill(o *alpaca.Order) {
+	price, ok := s.prices[o.Symbol]
+	if !ok || !s.canFill(o, price) {
+		return
+	}
+	qty := o.Qty
+	if qty == nil {
+		q := o.Notional.DivRound(price, 9)
+		qty = &q
+	}
+
+	now := s.opts.Now()
+	o.Status = alpaca.OrderStatusFilled
+	o.FilledQty = *qty
+	o.FilledAvgPrice = &price
+	o.FilledAt, o.UpdatedAt = &now, now
+
+	signed := *qty
+	if o.Side == alpaca.Sell {
+		signed = qty.Neg()
+	}
+	s.cash = s.cash.Sub(signed.Mul(price))
+	pos, ok := s.positions[o.Symbol]
+	if !ok {
+		asset := s.assets[o.Symbol]
+		pos = &alpaca.Position{
+			AssetID:         asset.ID,
+			Symbol:          asset.Symbol,
+			Exchange:        asset.Exchange,
+			AssetClass:      asset.Class,
+			AssetMarginable: asset.Marginable,
+		}
+		s.positions[o.Symbol] = pos
+	}
+	switch {
+	case pos.Qty.IsZero() || pos.Qty.Sign() == signed.Sign():
+		cost := pos.Qty.Abs().Mul(pos.AvgEntryPrice).Add(qty.Mul(price))
+		pos.Qty = pos.Qty.Add(signed)
+		pos.AvgEntryPrice = cost.Div(pos.Qty.Abs())
+	default:
+		pos.Qty = pos.Qty.Add(signed)
+		if pos.Qty.Sign() == signed.Sign() {
+			// the fill flipped the position
+			pos.AvgEntryPrice = price
+		}
+	}
+	positionQty := pos.Qty
+	if pos.Qty.IsZero() {
+		delete(s.positions, o.Symbol)
+	}
+
+	s.publish(alpaca.TradeEventFill, o, &fill{qty: *qty, price: price, positionQty: positionQty})
+}
+
+// canFill returns whether o can be filled at price. It keeps track of the
+// triggered stop limit orders and the high water mark of trailing stop orders.
+func (s *Server) canFill(o *alpaca.Order, price decimal.Decimal) bool {
+	buy := o.Side == alpaca.Buy
+	limitOK := func() bool {
+		if buy {
+			return price.LessThanOrEqual(*o.LimitPrice)
+		}
+		return price.GreaterThanOrEqual(*o.LimitPrice)
+	}
+	stopOK := func(stop decimal.Decimal) bool {
+		if buy {
+			return price.GreaterThanOrEqual(stop)
+		}
+		return price.LessThanOrEqual(stop)
+	}
+
+	switch o.Type {
+	case alpaca.Market:
+		return true
+	case alpaca.Limit:
+		return limitOK()
+	case alpaca.Stop:
+		return stopOK(*o.StopPrice)
+	case alpaca.StopLimit:
+		if !s.triggered[o.ID] && stopOK(*o.StopPrice) {
+			s.triggered[o.ID] = true
+		}
+		return s.triggered[o.ID] && limitOK()
+	case alpaca.TrailingStop:
+		if o.HWM == nil || (buy && price.LessThan(*o.HWM)) || (!buy && price.GreaterThan(*o.HWM)) {
+			hwm := price
+			o.HWM = &hwm
+		}
+		var offset decimal.Decimal
+		if o.TrailPrice != nil {
+			offset = *o.TrailPrice
+		} else {
+			offset = o.HWM.Mul(*o.TrailPercent).Div(decimal.NewFromInt(100))
+		}
+		stop := o.HWM.Sub(offset)
+		if buy {
+			stop = o.HWM.Add(offset)
+		}
+		o.StopPrice = &stop
+		return stopOK(stop)
+	}
+	return false
+}
+
+func (s *Server) handlePositions(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			positions := []alpaca.Position{}
+			for _, symbol := range s.positionSymbols() {
+				positions = append(positions, s.valuePosition(s.positions[symbol]))
+			}
+			writeJSON(w, http.StatusOK, positions)
+		case http.MethodDelete:
+			if r.URL.Query().Get("cancel_orders") == "true" {
+				for _, o := range s.orders {
+					if o.Status.IsOpen() {
+						s.cancelOrder(o)
+					}
+				}
+			}
+			results := []map[string]interface{}{}
+			for _, symbol := range s.positionSymbols() {
+				o, err := s.closePosition(symbol, decimal.Zero, decimal.Zero)
+				if err != nil {
+					results = append(results, map[string]interface{}{
+						"symbol": symbol,
+						"status": err.status,
+						"body":   map[string]interface{}{"code": err.code, "message": err.message},
+					})
+					continue
+				}
+				results = append(results, map[string]interface{}{"symbol": symbol, "status": http.StatusOK, "body": o})
+			}
+			writeJSON(w, http.StatusMultiStatus, results)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, 40510000, "method not allowed")
+		}
+		return
+	}
+
+	if len(parts) > 2 || (len(parts) == 2 && (parts[1] != "exercise" || r.Method != http.MethodPost)) {
+		writeError(w, http.StatusNotFound, 40410000, "endpoint not found")
+		return
+	}
+	symbol := parts[0]
+	if _, ok := s.positions[symbol]; !ok {
+		writeError(w, http.StatusNotFound, 40410000, "position does not exist")
+		return
+	}
+	if len(parts) == 2 {
+		writeError(w, http.StatusUnprocessableEntity, 42210000, "exercise is not supported by the fake server")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, s.valuePosition(s.positions[symbol]))
+	case http.MethodDelete:
+		q := r.URL.Query()
+		qty, _ := decimal.NewFromString(q.Get("qty"))
+		percentage, _ := decimal.NewFromString(q.Get("percentage"))
+		o, err := s.closePosition(symbol, qty, percentage)
+		if err != nil {
+			writeErrResponse(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, o)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, 40510000, "method not allowed")
+	}
+}
+
+// closePosition places a market order that closes qty, percentage or, if both are zero,
+// the whole position of symbol.
+func (s *Server) closePosition(symbol string, qty, percentage decimal.Decimal) (*alpaca.Order, *errResponse) {
+	pos := s.positions[symbol]
+	switch {
+	case !qty.IsZero() && !percentage.IsZero():
+		return nil, unprocessable("qty and percentage are mutually exclusive")
+	case !percentage.IsZero():
+		qty = pos.Qty.Abs().Mul(percentage).Div(decimal.NewFromInt(100)).Round(9)
+	case qty.IsZero():
+		qty = pos.Qty.Abs()
+	}
+	if qty.GreaterThan(pos.Qty.Abs()) {
+		return nil, &errResponse{
+			status: http.StatusForbidden, code: 40310000,
+			message: fmt.Sprintf("insufficient qty available for order (requested: %s, available: %s)", qty, pos.Qty.Abs()),
+		}
+	}
+	side := alpaca.Sell
+	if pos.Qty.IsNegative() {
+		side = alpaca.Buy
+	}
+	tif := alpaca.Day
+	if pos.AssetClass == alpaca.Crypto {
+		tif = alpaca.GTC
+	}
+	return s.placeOrder(alpaca.PlaceOrderRequest{
+		Symbol:      symbol,
+		Qty:         &qty,
+		Side:        side,
+		Type:        alpaca.Market,
+		TimeInForce: tif,
+	})
+}
+
+func (s *Server) positionSymbols() []string {
+	symbols := make([]string, 0, len(s.positions))
+	for symbol := range s.positions {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// valuePosition returns a copy of p valued at the current price.
+func (s *Server) valuePosition(p *alpaca.Position) alpaca.Position {
+	pos := *p
+	pos.Side = "long"
+	if pos.Qty.IsNegative() {
+		pos.Side = "short"
+	}
+	pos.QtyAvailable = pos.Qty
+	pos.CostBasis = pos.Qty.Mul(pos.AvgEntryPrice)
+	price, ok := s.prices[pos.Symbol]
+	if !ok {
+		price = pos.AvgEntryPrice
+	}
+	marketValue := pos.Qty.Mul(price)
+	unrealizedPL := marketValue.Sub(pos.CostBasis)
+	pos.CurrentPrice = &price
+	pos.MarketValue = &marketValue
+	pos.UnrealizedPL = &unrealizedPL
+	if !pos.CostBasis.IsZero() {
+		plpc := unrealizedPL.Div(pos.CostBasis.Abs())
+		pos.UnrealizedPLPC = &plpc
+	}
+	return pos
+}
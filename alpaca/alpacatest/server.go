@@ -0,0 +1,450 @@
+// Package alpacatest provides an in-process fake of the Alpaca trading API for tests.
+//
+// The fake keeps an account, orders, positions, assets and watchlists in memory.
+// Orders are filled against the prices pushed with SetPrice, so tests are
+// deterministic and do not need the network:
+//
+//	srv := alpacatest.NewServer(alpacatest.ServerOpts{})
+//	defer srv.Close()
+//	srv.SetPrice("AAPL", decimal.NewFromInt(180))
+//	client := srv.Client()
+//	order, err := client.PlaceOrder(alpaca.PlaceOrderRequest{...})
+//
+// Every order change is also published on the trade updates endpoint,
+// so StreamTradeUpdates works end to end.
+package alpacatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+)
+
+// ServerOpts configures the fake server. The zero value is a server without
+// authentication, with 100,000 of cash and the default calendar.
+type ServerOpts struct {
+	// APIKey and APISecret are the credentials accepted by the server.
+	// If both are empty, requests are not authenticated.
+	APIKey    string
+	APISecret string
+	// Cash is the initial cash of the account. Defaults to 100,000.
+	Cash decimal.Decimal
+	// Assets are the known assets. Setting the price of an unknown symbol adds it
+	// as an active, tradable asset.
+	Assets []alpaca.Asset
+	// Calendar is the market calendar. Defaults to every weekday from 9:30 to 16:00 New York time.
+	Calendar []alpaca.CalendarDay
+	// Now returns the current time of the server. Defaults to time.Now.
+	Now func() time.Time
+}
+
+// Server is a fake Alpaca trading API server. All methods are safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, e.g. "http://127.0.0.1:1234".
+	URL string
+
+	opts      ServerOpts
+	srv       *httptest.Server
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu          sync.Mutex
+	accountID   string
+	cash        decimal.Decimal
+	orders      []*alpaca.Order
+	triggered   map[string]bool
+	positions   map[string]*alpaca.Position
+	prices      map[string]decimal.Decimal
+	assets      map[string]alpaca.Asset
+	watchlists  []*alpaca.Watchlist
+	clock       *alpaca.Clock
+	events      []alpaca.TradeUpdate
+	eventNotify chan struct{}
+	nextID      int
+}
+
+// NewServer starts a new fake server. Close must be called when the server is not needed anymore.
+func NewServer(opts ServerOpts) *Server {
+	if opts.Cash.IsZero() {
+		opts.Cash = decimal.NewFromInt(100000)
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	s := &Server{
+		opts:        opts,
+		done:        make(chan struct{}),
+		cash:        opts.Cash,
+		triggered:   make(map[string]bool),
+		positions:   make(map[string]*alpaca.Position),
+		prices:      make(map[string]decimal.Decimal),
+		assets:      make(map[string]alpaca.Asset),
+		eventNotify: make(chan struct{}),
+	}
+	s.accountID = s.newID()
+	for _, a := range opts.Assets {
+		s.addAsset(a)
+	}
+	s.srv = httptest.NewServer(s)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close stops the server and ends the open trade update streams.
+// Calling it more than once is a no-op.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.srv.Close()
+	})
+}
+
+// Client returns a client that is configured to use the server.
+func (s *Server) Client() *alpaca.Client {
+	return alpaca.NewClient(alpaca.ClientOpts{
+		APIKey:    s.opts.APIKey,
+		APISecret: s.opts.APISecret,
+		BaseURL:   s.URL,
+	})
+}
+
+// SetPrice sets the current price of symbol and fills the open orders of symbol
+// that can be filled at that price.
+func (s *Server) SetPrice(symbol string, price decimal.Decimal) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.assets[symbol]; !ok {
+		class := alpaca.USEquity
+		if strings.Contains(symbol, "/") {
+			class = alpaca.Crypto
+		}
+		s.addAsset(alpaca.Asset{Symbol: symbol, Class: class})
+	}
+	s.prices[symbol] = price
+	for _, o := range s.orders {
+		if o.Symbol == symbol && o.Status.IsOpen() {
+			s.tryFill(o)
+		}
+	}
+}
+
+// AddAsset adds or replaces a known asset.
+func (s *Server) AddAsset(asset alpaca.Asset) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addAsset(asset)
+}
+
+func (s *Server) addAsset(asset alpaca.Asset) {
+	if asset.ID == "" {
+		asset.ID = s.newID()
+	}
+	if asset.Class == "" {
+		asset.Class = alpaca.USEquity
+	}
+	if asset.Status == "" {
+		asset.Status = alpaca.AssetActive
+		asset.Tradable = true
+		asset.Fractionable = true
+		asset.Shortable = asset.Class == alpaca.USEquity
+		asset.EasyToBorrow = asset.Shortable
+		asset.Marginable = asset.Shortable
+	}
+	if asset.Exchange == "" {
+		asset.Exchange = "NASDAQ"
+		if asset.Class == alpaca.Crypto {
+			asset.Exchange = "CRYPTO"
+		}
+	}
+	s.assets[asset.Symbol] = asset
+}
+
+// SetClock overrides the clock returned by the server, which is otherwise derived
+// from the calendar and the current time.
+func (s *Server) SetClock(clock alpaca.Clock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = &clock
+}
+
+// TradeUpdates returns every trade update published by the server so far.
+func (s *Server) TradeUpdates() []alpaca.TradeUpdate {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]alpaca.TradeUpdate(nil), s.events...)
+}
+
+// newID returns a unique, deterministic UUID.
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.nextID)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, 40110000, "request is not authorized")
+		return
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) == 3 && parts[0] == "v2beta1" && parts[1] == "events" && parts[2] == "trades" && r.Method == http.MethodGet {
+		s.streamTradeUpdates(w, r)
+		return
+	}
+	if len(parts) < 2 || parts[0] != "v2" {
+		writeError(w, http.StatusNotFound, 40410000, "endpoint not found")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch parts[1] {
+	case "account":
+		s.handleAccount(w, r, parts[2:])
+	case "orders", "orders:by_client_order_id":
+		s.handleOrders(w, r, parts[1:])
+	case "positions":
+		s.handlePositions(w, r, parts[2:])
+	case "assets":
+		s.handleAssets(w, r, parts[2:])
+	case "watchlists":
+		s.handleWatchlists(w, r, parts[2:])
+	case "clock":
+		s.handleClock(w, r, parts[2:])
+	case "calendar":
+		s.handleCalendar(w, r, parts[2:])
+	default:
+		writeError(w, http.StatusNotFound, 40410000, "endpoint not found")
+	}
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.opts.APIKey == "" && s.opts.APISecret == "" {
+		return true
+	}
+	return r.Header.Get("APCA-API-KEY-ID") == s.opts.APIKey &&
+		r.Header.Get("APCA-API-SECRET-KEY") == s.opts.APISecret
+}
+
+func (s *Server) handleAccount(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) != 0 || r.Method != http.MethodGet {
+		writeError(w, http.StatusNotFound, 40410000, "endpoint not found")
+		return
+	}
+	acct := alpaca.Account{
+		ID:              s.accountID,
+		AccountNumber:   "PA0000000001",
+		Status:          "ACTIVE",
+		CryptoStatus:    "ACTIVE",
+		Currency:        "USD",
+		Cash:            s.cash,
+		ShortingEnabled: true,
+		Multiplier:      decimal.NewFromInt(1),
+		LastEquity:      s.opts.Cash,
+		CreatedAt:       time.Unix(0, 0).UTC(),
+	}
+	for _, p := range s.positions {
+		p := s.valuePosition(p)
+		if p.Qty.IsNegative() {
+			acct.ShortMarketValue = acct.ShortMarketValue.Add(*p.MarketValue)
+		} else {
+			acct.LongMarketValue = acct.LongMarketValue.Add(*p.MarketValue)
+		}
+	}
+	acct.PositionMarketValue = acct.LongMarketValue.Add(acct.ShortMarketValue)
+	acct.Equity = s.cash.Add(acct.PositionMarketValue)
+	acct.PortfolioValue = acct.Equity
+	acct.BuyingPower = s.buyingPower()
+	acct.RegTBuyingPower = acct.BuyingPower
+	acct.EffectiveBuyingPower = acct.BuyingPower
+	acct.NonMarginBuyingPower = acct.BuyingPower
+	writeJSON(w, http.StatusOK, acct)
+}
+
+// buyingPower is the cash of the account, the fake server does not lend.
+func (s *Server) buyingPower() decimal.Decimal {
+	if s.cash.IsNegative() {
+		return decimal.Zero
+	}
+	return s.cash
+}
+
+func (s *Server) handleAssets(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != http.MethodGet || len(parts) > 1 {
+		writeError(w, http.StatusNotFound, 40410000, "endpoint not found")
+		return
+	}
+	if len(parts) == 1 {
+		asset, ok := s.findAsset(parts[0])
+		if !ok {
+			writeError(w, http.StatusNotFound, 40410000, "asset not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, asset)
+		return
+	}
+	q := r.URL.Query()
+	assets := []alpaca.Asset{}
+	for _, a := range s.assets {
+		if (q.Get("status") == "" || string(a.Status) == q.Get("status")) &&
+			(q.Get("asset_class") == "" || string(a.Class) == q.Get("asset_class")) &&
+			(q.Get("exchange") == "" || a.Exchange == q.Get("exchange")) {
+			assets = append(assets, a)
+		}
+	}
+	sortAssets(assets)
+	writeJSON(w, http.StatusOK, assets)
+}
+
+// findAsset returns an asset by symbol or ID.
+func (s *Server) findAsset(symbolOrID string) (alpaca.Asset, bool) {
+	if a, ok := s.assets[symbolOrID]; ok {
+		return a, true
+	}
+	for _, a := range s.assets {
+		if a.ID == symbolOrID {
+			return a, true
+		}
+	}
+	return alpaca.Asset{}, false
+}
+
+func (s *Server) handleWatchlists(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			watchlists := make([]alpaca.Watchlist, 0, len(s.watchlists))
+			for _, wl := range s.watchlists {
+				watchlists = append(watchlists, *wl)
+			}
+			writeJSON(w, http.StatusOK, watchlists)
+		case http.MethodPost:
+			var req alpaca.CreateWatchlistRequest
+			if !readJSON(w, r, &req) {
+				return
+			}
+			assets, ok := s.watchlistAssets(w, req.Symbols)
+			if !ok {
+				return
+			}
+			now := s.opts.Now().UTC().Format(time.RFC3339Nano)
+			wl := &alpaca.Watchlist{
+				AccountID: s.accountID,
+				ID:        s.newID(),
+				CreatedAt: now,
+				UpdatedAt: now,
+				Name:      req.Name,
+				Assets:    assets,
+			}
+			s.watchlists = append(s.watchlists, wl)
+			writeJSON(w, http.StatusOK, wl)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, 40510000, "method not allowed")
+		}
+		return
+	}
+
+	idx := -1
+	for i, wl := range s.watchlists {
+		if wl.ID == parts[0] {
+			idx = i
+		}
+	}
+	if idx < 0 || len(parts) > 2 {
+		writeError(w, http.StatusNotFound, 40410000, "watchlist not found")
+		return
+	}
+	wl := s.watchlists[idx]
+	now := s.opts.Now().UTC().Format(time.RFC3339Nano)
+	if len(parts) == 2 {
+		if r.Method != http.MethodDelete {
+			writeError(w, http.StatusMethodNotAllowed, 40510000, "method not allowed")
+			return
+		}
+		assets := wl.Assets[:0]
+		for _, a := range wl.Assets {
+			if a.Symbol != parts[1] {
+				assets = append(assets, a)
+			}
+		}
+		wl.Assets, wl.UpdatedAt = assets, now
+		writeJSON(w, http.StatusOK, wl)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, wl)
+	case http.MethodPut:
+		var req alpaca.UpdateWatchlistRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		assets, ok := s.watchlistAssets(w, req.Symbols)
+		if !ok {
+			return
+		}
+		if req.Name != "" {
+			wl.Name = req.Name
+		}
+		wl.Assets, wl.UpdatedAt = assets, now
+		writeJSON(w, http.StatusOK, wl)
+	case http.MethodPost:
+		var req alpaca.AddSymbolToWatchlistRequest
+		if !readJSON(w, r, &req) {
+			return
+		}
+		assets, ok := s.watchlistAssets(w, []string{req.Symbol})
+		if !ok {
+			return
+		}
+		wl.Assets, wl.UpdatedAt = append(wl.Assets, assets...), now
+		writeJSON(w, http.StatusOK, wl)
+	case http.MethodDelete:
+		s.watchlists = append(s.watchlists[:idx], s.watchlists[idx+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, 40510000, "method not allowed")
+	}
+}
+
+// watchlistAssets looks up the assets of symbols. It writes the error response if one is unknown.
+func (s *Server) watchlistAssets(w http.ResponseWriter, symbols []string) ([]alpaca.Asset, bool) {
+	assets := []alpaca.Asset{}
+	for _, symbol := range symbols {
+		a, ok := s.findAsset(symbol)
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, 40010001, fmt.Sprintf("asset not found for %s", symbol))
+			return nil, false
+		}
+		assets = append(assets, a)
+	}
+	return assets, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	writeJSON(w, status, map[string]interface{}{"code": code, "message": message})
+}
+
+// readJSON decodes the request body into v. It writes the error response if that fails.
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, 40010000, fmt.Sprintf("invalid request body: %v", err))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,360 @@
+package alpacatest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+)
+
+func dec(s string) *decimal.Decimal {
+	d := decimal.RequireFromString(s)
+	return &d
+}
+
+func TestOrdersAndPositions(t *testing.T) {
+	srv := NewServer(ServerOpts{})
+	defer srv.Close()
+	client := srv.Client()
+	srv.SetPrice("AAPL", decimal.NewFromInt(100))
+
+	buy, err := client.PlaceOrder(alpaca.PlaceOrderRequest{
+		Symbol: "AAPL", Qty: dec("10"), Side: alpaca.Buy, Type: alpaca.Market, TimeInForce: alpaca.Day,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, alpaca.OrderStatusFilled, buy.Status)
+	assert.Equal(t, "100", buy.FilledAvgPrice.String())
+
+	pos, err := client.GetPosition("AAPL")
+	require.NoError(t, err)
+	assert.Equal(t, "10", pos.Qty.String())
+	assert.Equal(t, "long", pos.Side)
+
+	sell, err := client.PlaceOrder(alpaca.PlaceOrderRequest{
+		Symbol: "AAPL", Qty: dec("10"), Side: alpaca.Sell, Type: alpaca.Limit, LimitPrice: dec("110"),
+		TimeInForce: alpaca.GTC, ClientOrderID: "take-profit",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, alpaca.OrderStatusNew, sell.Status)
+
+	srv.SetPrice("AAPL", decimal.NewFromInt(105))
+	open, err := client.GetOrders(alpaca.GetOrdersRequest{})
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	assert.Equal(t, sell.ID, open[0].ID)
+
+	srv.SetPrice("AAPL", decimal.NewFromInt(111))
+	sell, err = client.GetOrderByClientOrderID("take-profit")
+	require.NoError(t, err)
+	assert.Equal(t, alpaca.OrderStatusFilled, sell.Status)
+	assert.Equal(t, "111", sell.FilledAvgPrice.String())
+
+	_, err = client.GetPosition("AAPL")
+	var apiErr *alpaca.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	acct, err := client.GetAccount()
+	require.NoError(t, err)
+	assert.Equal(t, "100110", acct.Cash.String())
+	assert.Equal(t, "100110", acct.Equity.String())
+
+	all, err := client.GetOrders(alpaca.GetOrdersRequest{Status: "all", Direction: "asc"})
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, buy.ID, all[0].ID)
+}
+
+func TestStopOrders(t *testing.T) {
+	srv := NewServer(ServerOpts{})
+	defer srv.Close()
+	client := srv.Client()
+	srv.SetPrice("AAPL", decimal.NewFromInt(100))
+
+	_, err := client.PlaceOrder(alpaca.PlaceOrderRequest{
+		Symbol: "AAPL", Qty: dec("10"), Side: alpaca.Buy, Type: alpaca.Market, TimeInForce: alpaca.Day,
+	})
+	require.NoError(t, err)
+	trailing, err := client.PlaceOrder(alpaca.PlaceOrderRequest{
+		Symbol: "AAPL", Qty: dec("4"), Side: alpaca.Sell, Type: alpaca.TrailingStop, TrailPrice: dec("5"),
+		TimeInForce: alpaca.GTC,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "95", trailing.StopPrice.String())
+	stopLimit, err := client.PlaceOrder(alpaca.PlaceOrderRequest{
+		Symbol: "AAPL", Qty: dec("6"), Side: alpaca.Sell, Type: alpaca.StopLimit, StopPrice: dec("90"),
+		LimitPrice: dec("89"), TimeInForce: alpaca.GTC,
+	})
+	require.NoError(t, err)
+
+	srv.SetPrice("AAPL", decimal.NewFromInt(110))
+	trailing, err = client.GetOrder(trailing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "105", trailing.StopPrice.String())
+
+	srv.SetPrice("AAPL", decimal.NewFromInt(104))
+	trailing, err = client.GetOrder(trailing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alpaca.OrderStatusFilled, trailing.Status)
+	assert.Equal(t, "104", trailing.FilledAvgPrice.String())
+
+	// the stop is triggered, but the price falls below the limit
+	srv.SetPrice("AAPL", decimal.NewFromInt(88))
+	stopLimit, err = client.GetOrder(stopLimit.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alpaca.OrderStatusNew, stopLimit.Status)
+
+	srv.SetPrice("AAPL", decimal.RequireFromString("89.5"))
+	stopLimit, err = client.GetOrder(stopLimit.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alpaca.OrderStatusFilled, stopLimit.Status)
+}
+
+func TestReplaceAndCancel(t *testing.T) {
+	srv := NewServer(ServerOpts{})
+	defer srv.Close()
+	client := srv.Client()
+	srv.SetPrice("AAPL", decimal.NewFromInt(100))
+
+	order, err := client.PlaceOrder(alpaca.PlaceOrderRequest{
+		Symbol: "AAPL", Qty: dec("1"), Side: alpaca.Buy, Type: alpaca.Limit, LimitPrice: dec("90"),
+		TimeInForce: alpaca.Day,
+	})
+	require.NoError(t, err)
+
+	replacement, err := client.ReplaceOrder(order.ID, alpaca.ReplaceOrderRequest{LimitPrice: dec("95")})
+	require.NoError(t, err)
+	require.NotNil(t, replacement.Replaces)
+	assert.Equal(t, order.ID, *replacement.Replaces)
+	assert.Equal(t, "95", replacement.LimitPrice.String())
+	order, err = client.GetOrder(order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, alpaca.OrderStatusReplaced, order.Status)
+
+	require.NoError(t, client.CancelOrder(replacement.ID))
+	err = client.CancelOrder(replacement.ID)
+	var apiErr *alpaca.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+
+	// immediate or cancel orders are canceled if they can not be filled right away
+	ioc, err := client.PlaceOrder(alpaca.PlaceOrderRequest{
+		Symbol: "AAPL", Qty: dec("1"), Side: alpaca.Buy, Type: alpaca.Limit, LimitPrice: dec("90"),
+		TimeInForce: alpaca.IOC,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, alpaca.OrderStatusCanceled, ioc.Status)
+
+	events := srv.TradeUpdates()
+	var got []alpaca.TradeEvent
+	for _, tu := range events {
+		got = append(got, tu.Event)
+	}
+	assert.Equal(t, []alpaca.TradeEvent{
+		alpaca.TradeEventNew, alpaca.TradeEventReplaced, alpaca.TradeEventNew, alpaca.TradeEventCanceled,
+		alpaca.TradeEventNew, alpaca.TradeEventCanceled,
+	}, got)
+}
+
+func TestRejectedOrders(t *testing.T) {
+	srv := NewServer(ServerOpts{Cash: decimal.NewFromInt(1000)})
+	defer srv.Close()
+	client := srv.Client()
+	srv.SetPrice("AAPL", decimal.NewFromInt(100))
+
+	for _, tt := range []struct {
+		name   string
+		req    alpaca.PlaceOrderRequest
+		status int
+	}{
+		{
+			name:   "unknown asset",
+			req:    alpaca.PlaceOrderRequest{Symbol: "MSFT", Qty: dec("1"), Side: alpaca.Buy, Type: alpaca.Market, TimeInForce: alpaca.Day},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "missing limit price",
+			req:    alpaca.PlaceOrderRequest{Symbol: "AAPL", Qty: dec("1"), Side: alpaca.Buy, Type: alpaca.Limit, TimeInForce: alpaca.Day},
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "insufficient buying power",
+			req:    alpaca.PlaceOrderRequest{Symbol: "AAPL", Qty: dec("11"), Side: alpaca.Buy, Type: alpaca.Market, TimeInForce: alpaca.Day},
+			status: http.StatusForbidden,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.PlaceOrder(tt.req)
+			var apiErr *alpaca.APIError
+			require.True(t, errors.As(err, &apiErr))
+			assert.Equal(t, tt.status, apiErr.StatusCode)
+		})
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	srv := NewServer(ServerOpts{APIKey: "key", APISecret: "secret"})
+	defer srv.Close()
+
+	_, err := srv.Client().GetAccount()
+	require.NoError(t, err)
+
+	_, err = alpaca.NewClient(alpaca.ClientOpts{APIKey: "key", APISecret: "wrong", BaseURL: srv.URL}).GetAccount()
+	var apiErr *alpaca.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
+
+func TestCloseTwice(t *testing.T) {
+	srv := NewServer(ServerOpts{})
+	srv.Close()
+	assert.NotPanics(t, srv.Close)
+}
+
+func TestCloseAllPositions(t *testing.T) {
+	srv := NewServer(ServerOpts{})
+	defer srv.Close()
+	client := srv.Client()
+	srv.SetPrice("AAPL", decimal.NewFromInt(100))
+	srv.SetPrice("BTC/USD", decimal.NewFromInt(60000))
+
+	for _, req := range []alpaca.PlaceOrderRequest{
+		{Symbol: "AAPL", Qty: dec("5"), Side: alpaca.Sell, Type: alpaca.Market, TimeInForce: alpaca.Day},
+		{Symbol: "BTC/USD", Notional: dec("600"), Side: alpaca.Buy, Type: alpaca.Market, TimeInForce: alpaca.GTC},
+		{Symbol: "AAPL", Qty: dec("1"), Side: alpaca.Buy, Type: alpaca.Limit, LimitPrice: dec("50"), TimeInForce: alpaca.GTC},
+	} {
+		_, err := client.PlaceOrder(req)
+		require.NoError(t, err)
+	}
+	positions, err := client.GetPositions()
+	require.NoError(t, err)
+	require.Len(t, positions, 2)
+	assert.Equal(t, "-5", positions[0].Qty.String())
+	assert.Equal(t, "short", positions[0].Side)
+	assert.Equal(t, "0.01", positions[1].Qty.String())
+	assert.Equal(t, alpaca.Crypto, positions[1].AssetClass)
+
+	result, err := client.Flatten(alpaca.FlattenRequest{})
+	require.NoError(t, err)
+	assert.Len(t, result.CanceledOrders, 1)
+	require.Len(t, result.ClosedPositions, 2)
+	assert.Equal(t, alpaca.Buy, result.ClosedPositions[0].Order.Side)
+
+	positions, err = client.GetPositions()
+	require.NoError(t, err)
+	assert.Empty(t, positions)
+}
+
+func TestWatchlistsAndAssets(t *testing.T) {
+	srv := NewServer(ServerOpts{Assets: []alpaca.Asset{{Symbol: "AAPL"}, {Symbol: "MSFT"}, {Symbol: "ETH/USD", Class: alpaca.Crypto}}})
+	defer srv.Close()
+	client := srv.Client()
+
+	assets, err := client.GetAssets(alpaca.GetAssetsRequest{AssetClass: "us_equity"})
+	require.NoError(t, err)
+	require.Len(t, assets, 2)
+	assert.Equal(t, "AAPL", assets[0].Symbol)
+	asset, err := client.GetAsset(assets[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "MSFT", asset.Symbol)
+
+	wl, err := client.CreateWatchlist(alpaca.CreateWatchlistRequest{Name: "tech", Symbols: []string{"AAPL"}})
+	require.NoError(t, err)
+	wl, err = client.AddSymbolToWatchlist(wl.ID, alpaca.AddSymbolToWatchlistRequest{Symbol: "MSFT"})
+	require.NoError(t, err)
+	require.Len(t, wl.Assets, 2)
+	require.NoError(t, client.RemoveSymbolFromWatchlist(wl.ID, alpaca.RemoveSymbolFromWatchlistRequest{Symbol: "AAPL"}))
+	wl, err = client.GetWatchlist(wl.ID)
+	require.NoError(t, err)
+	require.Len(t, wl.Assets, 1)
+	assert.Equal(t, "MSFT", wl.Assets[0].Symbol)
+
+	_, err = client.UpdateWatchlist(wl.ID, alpaca.UpdateWatchlistRequest{Symbols: []string{"TSLA"}})
+	assert.Error(t, err)
+
+	require.NoError(t, client.DeleteWatchlist(wl.ID))
+	watchlists, err := client.GetWatchlists()
+	require.NoError(t, err)
+	assert.Empty(t, watchlists)
+}
+
+func TestClockAndCalendar(t *testing.T) {
+	// Friday, 2024-03-01 10:00 New York time
+	now := time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC)
+	srv := NewServer(ServerOpts{Now: func() time.Time { return now }})
+	defer srv.Close()
+	client := srv.Client()
+
+	clock, err := client.GetClock()
+	require.NoError(t, err)
+	assert.True(t, clock.IsOpen)
+	assert.True(t, time.Date(2024, 3, 1, 21, 0, 0, 0, time.UTC).Equal(clock.NextClose))
+	assert.True(t, time.Date(2024, 3, 4, 14, 30, 0, 0, time.UTC).Equal(clock.NextOpen))
+
+	calendar, err := client.GetCalendar(alpaca.GetCalendarRequest{
+		Start: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Len(t, calendar, 3)
+	assert.Equal(t, "2024-03-04", calendar[1].Date)
+
+	srv.SetClock(alpaca.Clock{Timestamp: now, IsOpen: false})
+	clock, err = client.GetClock()
+	require.NoError(t, err)
+	assert.False(t, clock.IsOpen)
+}
+
+func TestStreamTradeUpdates(t *testing.T) {
+	srv := NewServer(ServerOpts{})
+	defer srv.Close()
+	client := srv.Client()
+	srv.SetPrice("AAPL", decimal.NewFromInt(100))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	updates := make(chan alpaca.TradeUpdate, 10)
+	go func() {
+		_ = client.StreamTradeUpdates(ctx, func(tu alpaca.TradeUpdate) { updates <- tu }, alpaca.StreamTradeUpdatesRequest{})
+	}()
+
+	order, err := client.PlaceOrder(alpaca.PlaceOrderRequest{
+		Symbol: "AAPL", Qty: dec("2"), Side: alpaca.Buy, Type: alpaca.Market, TimeInForce: alpaca.Day,
+	})
+	require.NoError(t, err)
+
+	var received []alpaca.TradeUpdate
+	for len(received) < 2 {
+		select {
+		case tu := <-updates:
+			received = append(received, tu)
+		case <-time.After(time.Second):
+			require.Fail(t, "trade update not received in time")
+		}
+	}
+	assert.Equal(t, alpaca.TradeEventNew, received[0].Event)
+	assert.Equal(t, alpaca.TradeEventFill, received[1].Event)
+	assert.Equal(t, order.ID, received[1].Order.ID)
+	require.NotNil(t, received[1].PositionQty)
+	assert.Equal(t, "2", received[1].PositionQty.String())
+
+	// resuming from an event only replays the later ones
+	resumed := make(chan alpaca.TradeUpdate, 10)
+	go func() {
+		_ = client.StreamTradeUpdates(ctx, func(tu alpaca.TradeUpdate) { resumed <- tu },
+			alpaca.StreamTradeUpdatesRequest{SinceID: received[0].EventID})
+	}()
+	select {
+	case tu := <-resumed:
+		assert.Equal(t, received[1].EventID, tu.EventID)
+	case <-time.After(time.Second):
+		require.Fail(t, "trade update not replayed in time")
+	}
+}
@@ -0,0 +1,85 @@
+package alpacatest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+)
+
+type fill struct {
+	qty         decimal.Decimal
+	price       decimal.Decimal
+	positionQty decimal.Decimal
+}
+
+// publish records a trade update for o and wakes up the open streams.
+func (s *Server) publish(event alpaca.TradeEvent, o *alpaca.Order, f *fill) {
+	now := s.opts.Now()
+	tu := alpaca.TradeUpdate{
+		At:      now,
+		Event:   event,
+		EventID: fmt.Sprintf("%026d", len(s.events)+1),
+		Order:   *o,
+	}
+	if f != nil {
+		tu.ExecutionID = s.newID()
+		tu.Qty, tu.Price, tu.PositionQty = &f.qty, &f.price, &f.positionQty
+		tu.Timestamp = &now
+	}
+	s.events = append(s.events, tu)
+	close(s.eventNotify)
+	s.eventNotify = make(chan struct{})
+}
+
+// streamTradeUpdates serves the trade updates as server-sent events. The updates after
+// since_id (or all of them if it is missing) are sent first, then the new ones as they happen.
+func (s *Server) streamTradeUpdates(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, 50010000, "streaming unsupported")
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	lastID := r.URL.Query().Get("since_id")
+	for {
+		s.mu.Lock()
+		var pending []alpaca.TradeUpdate
+		for _, tu := range s.events {
+			// event IDs are zero padded, so they sort as strings
+			if tu.EventID > lastID {
+				pending = append(pending, tu)
+			}
+		}
+		notify := s.eventNotify
+		s.mu.Unlock()
+
+		for _, tu := range pending {
+			data, err := json.Marshal(tu)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\ndata: %s\n\n", tu.EventID, data); err != nil {
+				return
+			}
+			lastID = tu.EventID
+		}
+		flusher.Flush()
+
+		select {
+		case <-notify:
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		}
+	}
+}
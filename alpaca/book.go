@@ -0,0 +1,435 @@
+package alpaca
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// BookPosition is a position tracked by an AccountBook.
+type BookPosition struct {
+	Symbol     string
+	AssetClass AssetClass
+	// Qty is negative for short positions.
+	Qty           decimal.Decimal
+	AvgEntryPrice decimal.Decimal
+	// RealizedPL is the profit or loss realized by the fills seen since the book was created.
+	RealizedPL decimal.Decimal
+	UpdatedAt  time.Time
+}
+
+// AccountBookChange describes a change of an AccountBook.
+type AccountBookChange struct {
+	// Update is the trade update that caused the change. It is nil if the book was resynced.
+	Update *TradeUpdate
+	// Order is the order after the change. It is nil if the book was resynced.
+	Order *Order
+	// Position is the position after the change, if a fill changed it.
+	Position *BookPosition
+	// Resynced is true if the book was rebuilt from the REST API.
+	Resynced bool
+}
+
+// AccountBookSnapshot is a consistent copy of the content of an AccountBook.
+type AccountBookSnapshot struct {
+	Orders    []Order
+	Positions []BookPosition
+	SyncedAt  time.Time
+}
+
+// AccountBookOpts configures an AccountBook.
+type AccountBookOpts struct {
+	// OrdersLimit is the number of most recent orders loaded when the book is synced. Defaults to 500.
+	OrdersLimit int
+	// OnError is called when an automatic resync fails. The book keeps applying trade updates
+	// and retries the resync on the next gap.
+	OnError func(err error)
+	// Context bounds the automatic resyncs, which stop once it is done. Defaults to context.Background().
+	Context context.Context
+}
+
+// maxBookSyncAttempts is the number of times Sync fetches the account while fills handled
+// during the previous attempt leave the book in doubt.
+const maxBookSyncAttempts = 3
+
+// AccountBook is an in-memory order book and position book of the account.
+//
+// It is bootstrapped from the REST API by Sync, then kept current by HandleTradeUpdate,
+// which can be passed directly to StreamTradeUpdates. When a trade update reveals that
+// events were missed (the filled quantity of an order or the position quantity does not
+// add up) the book resyncs itself from the REST API in the background.
+//
+// Positions are keyed by the symbol returned by the positions endpoints, e.g. "BTCUSD"
+// for crypto, whose orders use "BTC/USD".
+//
+// All methods are safe for concurrent use.
+type AccountBook struct {
+	client *Client
+	opts   AccountBookOpts
+
+	mu        sync.RWMutex
+	orders    map[string]Order
+	positions map[string]BookPosition
+	syncedAt  time.Time
+	// syncing is the number of running Syncs. While it is positive, the applied trade updates
+	// are buffered in pending so that Sync can reapply them on top of the fetched state.
+	syncing int
+	seq     int
+	pending []bookUpdate
+	// resyncing is set while a background resync runs, resyncAgain if a gap was found meanwhile.
+	resyncing   bool
+	resyncAgain bool
+
+	subsMu sync.Mutex
+	subs   map[int]func(AccountBookChange)
+	nextID int
+}
+
+type bookUpdate struct {
+	seq int
+	tu  TradeUpdate
+}
+
+// NewAccountBook creates an empty book that uses client to sync.
+func NewAccountBook(client *Client, opts AccountBookOpts) *AccountBook {
+	if opts.OrdersLimit == 0 {
+		opts.OrdersLimit = 500
+	}
+	if opts.Context == nil {
+		opts.Context = context.Background()
+	}
+	return &AccountBook{
+		client:    client,
+		opts:      opts,
+		orders:    make(map[string]Order),
+		positions: make(map[string]BookPosition),
+		subs:      make(map[int]func(AccountBookChange)),
+	}
+}
+
+// Sync rebuilds the book from the orders and positions returned by the REST API.
+// The realized P&L of the positions is kept. Trade updates handled while the REST API
+// is queried are applied again on top of its results, so they are not lost. If that
+// leaves a position in doubt, the REST API is queried again.
+func (b *AccountBook) Sync(ctx context.Context) error {
+	for attempt := 1; ; attempt++ {
+		gap, err := b.syncOnce(ctx)
+		if err != nil || !gap || attempt == maxBookSyncAttempts {
+			return err
+		}
+	}
+}
+
+// syncOnce rebuilds the book once and tells whether the trade updates applied again revealed a gap.
+func (b *AccountBook) syncOnce(ctx context.Context) (bool, error) {
+	b.mu.Lock()
+	b.syncing++
+	start := b.seq
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		b.syncing--
+		if b.syncing == 0 {
+			b.pending = nil
+		}
+		b.mu.Unlock()
+	}()
+
+	orders, err := b.client.GetOrdersWithContext(ctx, GetOrdersRequest{
+		Status: "all",
+		Limit:  b.opts.OrdersLimit,
+		Nested: true,
+	})
+	if err != nil {
+		return false, err
+	}
+	positions, err := b.client.GetPositionsWithContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	b.mu.Lock()
+	realized := make(map[string]decimal.Decimal, len(b.positions))
+	for symbol, p := range b.positions {
+		realized[symbol] = p.RealizedPL
+	}
+	b.orders = make(map[string]Order, len(orders))
+	var index func(orders []Order)
+	index = func(orders []Order) {
+		for _, o := range orders {
+			b.orders[o.ID] = o
+			// the legs of bracket and OTO orders are orders of their own
+			index(o.Legs)
+		}
+	}
+	index(orders)
+	b.positions = make(map[string]BookPosition, len(positions))
+	now := time.Now()
+	for _, p := range positions {
+		qty := p.Qty
+		if p.Side == "short" && qty.IsPositive() {
+			qty = qty.Neg()
+		}
+		b.positions[p.Symbol] = BookPosition{
+			Symbol:        p.Symbol,
+			AssetClass:    p.AssetClass,
+			Qty:           qty,
+			AvgEntryPrice: p.AvgEntryPrice,
+			RealizedPL:    realized[p.Symbol],
+			UpdatedAt:     now,
+		}
+		delete(realized, p.Symbol)
+	}
+	// Flat positions are kept for their realized P&L.
+	for symbol, pl := range realized {
+		if !pl.IsZero() {
+			b.positions[symbol] = BookPosition{Symbol: symbol, RealizedPL: pl, UpdatedAt: now}
+		}
+	}
+	b.syncedAt = now
+	// The updates already reflected by the REST API are skipped as stale.
+	gap := false
+	for _, u := range b.pending {
+		if u.seq >= start {
+			_, g := b.applyLocked(u.tu, true)
+			gap = gap || g
+		}
+	}
+	b.mu.Unlock()
+
+	b.notify(AccountBookChange{Resynced: true})
+	return gap, nil
+}
+
+// HandleTradeUpdate applies a trade update to the book. If the update shows that earlier
+// updates were missed, the book is resynced in the background and OnError is called if that fails.
+func (b *AccountBook) HandleTradeUpdate(tu TradeUpdate) {
+	change, gap := b.apply(tu)
+	if change != nil {
+		b.notify(*change)
+	}
+	if gap {
+		b.resync()
+	}
+}
+
+// resync syncs the book in the background, so that trade updates keep being delivered.
+// Gaps found while a resync runs are merged into a single resync after it.
+func (b *AccountBook) resync() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.resyncing {
+		b.resyncAgain = true
+		return
+	}
+	b.resyncing = true
+	go func() {
+		ctx := b.opts.Context
+		for {
+			if err := b.Sync(ctx); err != nil && b.opts.OnError != nil {
+				b.opts.OnError(err)
+			}
+			b.mu.Lock()
+			again := b.resyncAgain && ctx.Err() == nil
+			b.resyncing, b.resyncAgain = again, false
+			b.mu.Unlock()
+			if !again {
+				return
+			}
+		}
+	}()
+}
+
+// apply updates the book from tu. It returns the change to publish, nil if tu was stale,
+// and whether tu revealed a gap.
+func (b *AccountBook) apply(tu TradeUpdate) (*AccountBookChange, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.syncing > 0 {
+		b.pending = append(b.pending, bookUpdate{seq: b.seq, tu: tu})
+	}
+	b.seq++
+	return b.applyLocked(tu, false)
+}
+
+// applyLocked applies tu to the book. replay is set when Sync applies tu again on top of
+// the state fetched from the REST API.
+func (b *AccountBook) applyLocked(tu TradeUpdate, replay bool) (*AccountBookChange, bool) {
+	order := tu.Order
+	prev, known := b.orders[order.ID]
+	if known && (!prev.Status.CanTransitionTo(order.Status) || order.FilledQty.LessThan(prev.FilledQty)) {
+		// a stale update, e.g. one replayed after a reconnect
+		return nil, false
+	}
+	b.orders[order.ID] = order
+	change := &AccountBookChange{Update: &tu, Order: &order}
+
+	isFill := tu.Event == TradeEventFill || tu.Event == TradeEventPartialFill
+	if !isFill || tu.Qty == nil || tu.Price == nil {
+		return change, false
+	}
+
+	prevFilled := decimal.Zero
+	if known {
+		prevFilled = prev.FilledQty
+	}
+	if order.FilledQty.Equal(prevFilled) {
+		// this fill was already applied
+		return change, false
+	}
+	gap := !prevFilled.Add(*tu.Qty).Equal(order.FilledQty)
+
+	symbol := positionSymbol(order.Symbol)
+	pos := b.positions[symbol]
+	realized := pos.RealizedPL
+	if replay {
+		// The fetched position may include the fill even if the fetched order does not,
+		// they are not fetched at once. Only the position quantity of the update tells.
+		if tu.PositionQty == nil {
+			return change, true
+		}
+		if tu.PositionQty.Equal(pos.Qty) {
+			return change, gap
+		}
+	}
+	pos.Symbol = symbol
+	pos.AssetClass = order.AssetClass
+	fillPosition(&pos, order.Side, *tu.Qty, *tu.Price)
+	if replay {
+		// The P&L of the fill was realized when it was handled before the sync.
+		pos.RealizedPL = realized
+	}
+	pos.UpdatedAt = tu.At
+	b.positions[symbol] = pos
+	change.Position = &pos
+
+	if tu.PositionQty != nil && !tu.PositionQty.Equal(pos.Qty) {
+		gap = true
+	}
+	return change, gap
+}
+
+// positionSymbol returns the symbol the positions endpoints use for symbol,
+// e.g. "BTCUSD" for the crypto orders of "BTC/USD".
+func positionSymbol(symbol string) string {
+	return strings.ReplaceAll(symbol, "/", "")
+}
+
+// fillPosition updates the quantity, average entry price and realized P&L of pos with a fill.
+func fillPosition(pos *BookPosition, side Side, qty, price decimal.Decimal) {
+	signed := qty
+	if side == Sell {
+		signed = qty.Neg()
+	}
+	multiplier := decimal.NewFromInt(1)
+	if pos.AssetClass == USOption {
+		multiplier = decimal.NewFromInt(100)
+	}
+
+	if pos.Qty.IsZero() || pos.Qty.Sign() == signed.Sign() {
+		// opening or increasing the position
+		cost := pos.Qty.Abs().Mul(pos.AvgEntryPrice).Add(qty.Mul(price))
+		pos.Qty = pos.Qty.Add(signed)
+		pos.AvgEntryPrice = cost.Div(pos.Qty.Abs())
+		return
+	}
+
+	closed := decimal.Min(qty, pos.Qty.Abs())
+	pl := price.Sub(pos.AvgEntryPrice).Mul(closed).Mul(multiplier)
+	if pos.Qty.IsNegative() {
+		pl = pl.Neg()
+	}
+	pos.RealizedPL = pos.RealizedPL.Add(pl)
+	pos.Qty = pos.Qty.Add(signed)
+	switch {
+	case pos.Qty.IsZero():
+		pos.AvgEntryPrice = decimal.Zero
+	case pos.Qty.Sign() == signed.Sign():
+		// the fill flipped the position, the rest of it opened a new one
+		pos.AvgEntryPrice = price
+	}
+}
+
+// Snapshot returns a copy of the orders and positions of the book, sorted by
+// submission time and symbol respectively. Flat positions are omitted.
+func (b *AccountBook) Snapshot() AccountBookSnapshot {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	s := AccountBookSnapshot{
+		Orders:    make([]Order, 0, len(b.orders)),
+		Positions: make([]BookPosition, 0, len(b.positions)),
+		SyncedAt:  b.syncedAt,
+	}
+	for _, o := range b.orders {
+		s.Orders = append(s.Orders, o)
+	}
+	for _, p := range b.positions {
+		if !p.Qty.IsZero() {
+			s.Positions = append(s.Positions, p)
+		}
+	}
+	sort.Slice(s.Orders, func(i, j int) bool { return s.Orders[i].SubmittedAt.Before(s.Orders[j].SubmittedAt) })
+	sort.Slice(s.Positions, func(i, j int) bool { return s.Positions[i].Symbol < s.Positions[j].Symbol })
+	return s
+}
+
+// OpenOrders returns the orders of the book that are still open.
+func (b *AccountBook) OpenOrders() []Order {
+	var open []Order
+	for _, o := range b.Snapshot().Orders {
+		if o.Status.IsOpen() {
+			open = append(open, o)
+		}
+	}
+	return open
+}
+
+// Order returns an order of the book by ID.
+func (b *AccountBook) Order(orderID string) (Order, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	o, ok := b.orders[orderID]
+	return o, ok
+}
+
+// Position returns the position of the book for symbol, which can also be given as it is
+// in orders (e.g. "BTC/USD"). A flat position is returned if it has realized P&L.
+func (b *AccountBook) Position(symbol string) (BookPosition, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	p, ok := b.positions[positionSymbol(symbol)]
+	return p, ok
+}
+
+// Subscribe registers fn to be called after every change of the book.
+// fn is called synchronously from HandleTradeUpdate and Sync, including the background
+// resyncs, so it should return quickly.
+// The returned function removes the subscription.
+func (b *AccountBook) Subscribe(fn func(AccountBookChange)) (unsubscribe func()) {
+	b.subsMu.Lock()
+	defer b.subsMu.Unlock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = fn
+	return func() {
+		b.subsMu.Lock()
+		defer b.subsMu.Unlock()
+		delete(b.subs, id)
+	}
+}
+
+func (b *AccountBook) notify(change AccountBookChange) {
+	b.subsMu.Lock()
+	subs := make([]func(AccountBookChange), 0, len(b.subs))
+	for _, fn := range b.subs {
+		subs = append(subs, fn)
+	}
+	b.subsMu.Unlock()
+	for _, fn := range subs {
+		fn(change)
+	}
+}
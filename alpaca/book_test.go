@@ -0,0 +1,250 @@
+package alpaca
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountBook(t *testing.T) {
+	dec := decimal.RequireFromString
+	ptr := func(s string) *decimal.Decimal {
+		d := dec(s)
+		return &d
+	}
+	serverOrders := []Order{
+		{ID: "o1", Symbol: "AAPL", Side: Buy, Qty: ptr("10"), Status: OrderStatusNew, SubmittedAt: time.Unix(1, 0)},
+		{ID: "o0", Symbol: "AAPL", Side: Buy, Qty: ptr("5"), FilledQty: dec("5"), Status: OrderStatusFilled},
+	}
+	serverPositions := []Position{{Symbol: "AAPL", Qty: dec("5"), AvgEntryPrice: dec("100"), Side: "long"}}
+	syncs := 0
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/v2/orders":
+			syncs++
+			assert.Equal(t, "all", req.URL.Query().Get("status"))
+			return &http.Response{Body: genBody(serverOrders)}, nil
+		case "/v2/positions":
+			return &http.Response{Body: genBody(serverPositions)}, nil
+		}
+		return nil, &APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+	}
+
+	book := NewAccountBook(c, AccountBookOpts{})
+	var changes []AccountBookChange
+	resynced := make(chan struct{}, 1)
+	unsubscribe := book.Subscribe(func(change AccountBookChange) {
+		changes = append(changes, change)
+		if change.Resynced {
+			resynced <- struct{}{}
+		}
+	})
+	require.NoError(t, book.Sync(context.Background()))
+	<-resynced
+	assert.Equal(t, 1, syncs)
+	require.Len(t, changes, 1)
+	assert.True(t, changes[0].Resynced)
+	require.Len(t, book.OpenOrders(), 1)
+
+	// buying more
+	fill := TradeUpdate{
+		Event: TradeEventPartialFill, Qty: ptr("5"), Price: ptr("110"), PositionQty: ptr("10"),
+		Order: Order{ID: "o1", Symbol: "AAPL", Side: Buy, Qty: ptr("10"), FilledQty: dec("5"), Status: OrderStatusPartiallyFilled},
+	}
+	book.HandleTradeUpdate(fill)
+	pos, ok := book.Position("AAPL")
+	require.True(t, ok)
+	assert.True(t, dec("10").Equal(pos.Qty))
+	assert.True(t, dec("105").Equal(pos.AvgEntryPrice))
+	require.Len(t, changes, 2)
+	require.NotNil(t, changes[1].Position)
+
+	// a replayed update is not applied twice
+	book.HandleTradeUpdate(fill)
+	pos, _ = book.Position("AAPL")
+	assert.True(t, dec("10").Equal(pos.Qty))
+
+	// selling through zero realizes P&L and opens a short
+	book.HandleTradeUpdate(TradeUpdate{
+		Event: TradeEventNew,
+		Order: Order{ID: "o2", Symbol: "AAPL", Side: Sell, Qty: ptr("12"), Status: OrderStatusNew},
+	})
+	book.HandleTradeUpdate(TradeUpdate{
+		Event: TradeEventFill, Qty: ptr("12"), Price: ptr("120"), PositionQty: ptr("-2"),
+		Order: Order{ID: "o2", Symbol: "AAPL", Side: Sell, Qty: ptr("12"), FilledQty: dec("12"), Status: OrderStatusFilled},
+	})
+	pos, _ = book.Position("AAPL")
+	assert.True(t, dec("-2").Equal(pos.Qty))
+	assert.True(t, dec("120").Equal(pos.AvgEntryPrice))
+	assert.True(t, dec("150").Equal(pos.RealizedPL))
+	assert.Equal(t, 1, syncs)
+
+	// a stale status is ignored
+	before := len(changes)
+	book.HandleTradeUpdate(TradeUpdate{
+		Event: TradeEventNew,
+		Order: Order{ID: "o2", Symbol: "AAPL", Side: Sell, Qty: ptr("12"), Status: OrderStatusNew},
+	})
+	assert.Len(t, changes, before)
+	o2, ok := book.Order("o2")
+	require.True(t, ok)
+	assert.Equal(t, OrderStatusFilled, o2.Status)
+
+	// a missed fill triggers a resync in the background
+	serverPositions = []Position{{Symbol: "AAPL", Qty: dec("4"), AvgEntryPrice: dec("130"), Side: "long"}}
+	serverOrders = append(serverOrders, Order{ID: "o3", Symbol: "AAPL", Side: Buy, Qty: ptr("6"), FilledQty: dec("6"), Status: OrderStatusFilled})
+	book.HandleTradeUpdate(TradeUpdate{
+		Event: TradeEventFill, Qty: ptr("3"), Price: ptr("130"), PositionQty: ptr("4"),
+		Order: Order{ID: "o3", Symbol: "AAPL", Side: Buy, Qty: ptr("6"), FilledQty: dec("6"), Status: OrderStatusFilled},
+	})
+	select {
+	case <-resynced:
+	case <-time.After(time.Second):
+		t.Fatal("the book was not resynced")
+	}
+	assert.Equal(t, 2, syncs)
+	assert.True(t, changes[len(changes)-1].Resynced)
+	snapshot := book.Snapshot()
+	require.Len(t, snapshot.Positions, 1)
+	assert.True(t, dec("4").Equal(snapshot.Positions[0].Qty))
+	assert.True(t, dec("130").Equal(snapshot.Positions[0].RealizedPL), "realized P&L survives a resync")
+	assert.Len(t, snapshot.Orders, 3)
+
+	unsubscribe()
+	before = len(changes)
+	require.NoError(t, book.Sync(context.Background()))
+	assert.Equal(t, 3, syncs)
+	assert.Len(t, changes, before)
+}
+
+func TestAccountBookSyncKeepsConcurrentUpdates(t *testing.T) {
+	dec := decimal.RequireFromString
+	ptr := func(s string) *decimal.Decimal {
+		d := dec(s)
+		return &d
+	}
+	var book *AccountBook
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/v2/orders":
+			return &http.Response{Body: genBody([]Order{{
+				ID: "o1", Symbol: "AAPL", Side: Buy, Qty: ptr("5"), Status: OrderStatusNew, OrderClass: Bracket,
+				Legs: []Order{
+					{ID: "tp", Symbol: "AAPL", Side: Sell, Qty: ptr("5"), Status: OrderStatusHeld},
+					{ID: "sl", Symbol: "AAPL", Side: Sell, Qty: ptr("5"), Status: OrderStatusHeld},
+				},
+			}})}, nil
+		case "/v2/positions":
+			// Trade updates arriving between the two requests.
+			book.HandleTradeUpdate(TradeUpdate{
+				Event: TradeEventFill, Qty: ptr("5"), Price: ptr("100"), PositionQty: ptr("5"),
+				Order: Order{ID: "o1", Symbol: "AAPL", Side: Buy, Qty: ptr("5"), FilledQty: dec("5"), Status: OrderStatusFilled},
+			})
+			book.HandleTradeUpdate(TradeUpdate{
+				Event: TradeEventNew,
+				Order: Order{ID: "o2", Symbol: "MSFT", Side: Buy, Qty: ptr("1"), Status: OrderStatusNew},
+			})
+			return &http.Response{Body: genBody([]Position{})}, nil
+		}
+		return nil, &APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+	}
+	book = NewAccountBook(c, AccountBookOpts{})
+	require.NoError(t, book.Sync(context.Background()))
+
+	o1, ok := book.Order("o1")
+	require.True(t, ok)
+	assert.Equal(t, OrderStatusFilled, o1.Status)
+	_, ok = book.Order("o2")
+	assert.True(t, ok)
+	pos, ok := book.Position("AAPL")
+	require.True(t, ok)
+	assert.True(t, dec("5").Equal(pos.Qty))
+	for _, id := range []string{"tp", "sl"} {
+		leg, ok := book.Order(id)
+		require.True(t, ok, id)
+		assert.Equal(t, OrderStatusHeld, leg.Status)
+	}
+}
+
+func TestAccountBookSyncDoesNotDoubleCountFills(t *testing.T) {
+	dec := decimal.RequireFromString
+	ptr := func(s string) *decimal.Decimal {
+		d := dec(s)
+		return &d
+	}
+	var book *AccountBook
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/v2/orders":
+			return &http.Response{Body: genBody([]Order{
+				{ID: "o1", Symbol: "AAPL", Side: Buy, Qty: ptr("5"), Status: OrderStatusNew},
+			})}, nil
+		case "/v2/positions":
+			// The order was fetched before the fill, the position after it.
+			book.HandleTradeUpdate(TradeUpdate{
+				Event: TradeEventFill, Qty: ptr("5"), Price: ptr("100"), PositionQty: ptr("5"),
+				Order: Order{ID: "o1", Symbol: "AAPL", Side: Buy, Qty: ptr("5"), FilledQty: dec("5"), Status: OrderStatusFilled},
+			})
+			return &http.Response{Body: genBody([]Position{
+				{Symbol: "AAPL", Qty: dec("5"), AvgEntryPrice: dec("100"), Side: "long"},
+			})}, nil
+		}
+		return nil, &APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+	}
+	book = NewAccountBook(c, AccountBookOpts{})
+	require.NoError(t, book.Sync(context.Background()))
+
+	o1, ok := book.Order("o1")
+	require.True(t, ok)
+	assert.Equal(t, OrderStatusFilled, o1.Status)
+	pos, ok := book.Position("AAPL")
+	require.True(t, ok)
+	assert.True(t, dec("5").Equal(pos.Qty), pos.Qty.String())
+	assert.True(t, dec("100").Equal(pos.AvgEntryPrice))
+}
+
+func TestAccountBookCrypto(t *testing.T) {
+	dec := decimal.RequireFromString
+	ptr := func(s string) *decimal.Decimal {
+		d := dec(s)
+		return &d
+	}
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		switch req.URL.Path {
+		case "/v2/orders":
+			return &http.Response{Body: genBody([]Order{})}, nil
+		case "/v2/positions":
+			return &http.Response{Body: genBody([]Position{
+				{Symbol: "BTCUSD", AssetClass: Crypto, Qty: dec("1"), AvgEntryPrice: dec("60000"), Side: "long"},
+			})}, nil
+		}
+		return nil, &APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+	}
+	book := NewAccountBook(c, AccountBookOpts{})
+	require.NoError(t, book.Sync(context.Background()))
+
+	book.HandleTradeUpdate(TradeUpdate{
+		Event: TradeEventFill, Qty: ptr("0.5"), Price: ptr("70000"), PositionQty: ptr("0.5"),
+		Order: Order{
+			ID: "o1", Symbol: "BTC/USD", AssetClass: Crypto, Side: Sell, Qty: ptr("0.5"),
+			FilledQty: dec("0.5"), Status: OrderStatusFilled,
+		},
+	})
+	snapshot := book.Snapshot()
+	require.Len(t, snapshot.Positions, 1)
+	assert.Equal(t, "BTCUSD", snapshot.Positions[0].Symbol)
+	assert.True(t, dec("0.5").Equal(snapshot.Positions[0].Qty))
+	assert.True(t, dec("5000").Equal(snapshot.Positions[0].RealizedPL))
+	pos, ok := book.Position("BTC/USD")
+	require.True(t, ok)
+	assert.Equal(t, snapshot.Positions[0], pos)
+}
@@ -0,0 +1,283 @@
+package alpaca
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// OrderBuilder builds a PlaceOrderRequest step by step, e.g.
+//
+//	req, err := alpaca.BuyOrder("AAPL").Qty(decimal.NewFromInt(10)).Limit(price).TakeProfit(tp).StopLoss(stop, limit).GTC().Build()
+//
+// The order class follows from the exit legs: a take profit and a stop loss make a bracket
+// order, only one of them an OTO order. Call OCO to build an OCO exit order instead.
+//
+// Every method returns a modified copy, so a partially built order can be reused as a template.
+type OrderBuilder struct {
+	req       PlaceOrderRequest
+	oco       bool
+	reference *decimal.Decimal
+
+	takeProfit    *decimal.Decimal
+	stopLoss      *decimal.Decimal
+	stopLossLimit *decimal.Decimal
+
+	takeProfitPercent    *decimal.Decimal
+	stopLossPercent      *decimal.Decimal
+	stopLossLimitPercent *decimal.Decimal
+}
+
+// BuyOrder starts building a buy order of symbol. Without further calls it is a market day order.
+func BuyOrder(symbol string) OrderBuilder {
+	return OrderBuilder{req: PlaceOrderRequest{Symbol: symbol, Side: Buy}}
+}
+
+// SellOrder starts building a sell order of symbol. Without further calls it is a market day order.
+func SellOrder(symbol string) OrderBuilder {
+	return OrderBuilder{req: PlaceOrderRequest{Symbol: symbol, Side: Sell}}
+}
+
+// Qty sets the number of shares (or coins) to trade.
+func (b OrderBuilder) Qty(qty decimal.Decimal) OrderBuilder {
+	b.req.Qty = &qty
+	b.req.Notional = nil
+	return b
+}
+
+// Notional sets the dollar amount to trade instead of a quantity.
+func (b OrderBuilder) Notional(amount decimal.Decimal) OrderBuilder {
+	b.req.Notional = &amount
+	b.req.Qty = nil
+	return b
+}
+
+// Market makes the order a market order. This is the default.
+func (b OrderBuilder) Market() OrderBuilder {
+	b.req.Type = Market
+	b.req.LimitPrice, b.req.StopPrice = nil, nil
+	return b
+}
+
+// Limit makes the order a limit order.
+func (b OrderBuilder) Limit(price decimal.Decimal) OrderBuilder {
+	b.req.Type = Limit
+	b.req.LimitPrice, b.req.StopPrice = &price, nil
+	return b
+}
+
+// Stop makes the order a stop order.
+func (b OrderBuilder) Stop(stop decimal.Decimal) OrderBuilder {
+	b.req.Type = Stop
+	b.req.LimitPrice, b.req.StopPrice = nil, &stop
+	return b
+}
+
+// StopLimit makes the order a stop limit order.
+func (b OrderBuilder) StopLimit(stop, limit decimal.Decimal) OrderBuilder {
+	b.req.Type = StopLimit
+	b.req.LimitPrice, b.req.StopPrice = &limit, &stop
+	return b
+}
+
+// TrailingStop makes the order a trailing stop order trailing the price by a dollar amount.
+func (b OrderBuilder) TrailingStop(trail decimal.Decimal) OrderBuilder {
+	b.req.Type = TrailingStop
+	b.req.LimitPrice, b.req.StopPrice = nil, nil
+	b.req.TrailPrice, b.req.TrailPercent = &trail, nil
+	return b
+}
+
+// TrailingStopPercent makes the order a trailing stop order trailing the price by a percentage.
+func (b OrderBuilder) TrailingStopPercent(percent decimal.Decimal) OrderBuilder {
+	b.req.Type = TrailingStop
+	b.req.LimitPrice, b.req.StopPrice = nil, nil
+	b.req.TrailPrice, b.req.TrailPercent = nil, &percent
+	return b
+}
+
+// TakeProfit adds a take profit leg at the given limit price.
+func (b OrderBuilder) TakeProfit(limit decimal.Decimal) OrderBuilder {
+	b.takeProfit, b.takeProfitPercent = &limit, nil
+	return b
+}
+
+// TakeProfitPercent adds a take profit leg percent away from the reference price,
+// above it when the position is long and below it when it is short.
+func (b OrderBuilder) TakeProfitPercent(percent decimal.Decimal) OrderBuilder {
+	b.takeProfit, b.takeProfitPercent = nil, &percent
+	return b
+}
+
+// StopLoss adds a stop loss leg triggered at stop. The leg is a stop limit order
+// if limit is not zero, and a stop (market) order otherwise.
+func (b OrderBuilder) StopLoss(stop, limit decimal.Decimal) OrderBuilder {
+	b.stopLoss, b.stopLossPercent = &stop, nil
+	b.stopLossLimit, b.stopLossLimitPercent = nil, nil
+	if !limit.IsZero() {
+		b.stopLossLimit = &limit
+	}
+	return b
+}
+
+// StopLossPercent adds a stop loss leg triggered stopPercent away from the reference price,
+// below it when the position is long and above it when it is short. The leg is a stop limit
+// order with its limit limitPercent away from the reference price if limitPercent is not zero.
+func (b OrderBuilder) StopLossPercent(stopPercent, limitPercent decimal.Decimal) OrderBuilder {
+	b.stopLoss, b.stopLossPercent = nil, &stopPercent
+	b.stopLossLimit, b.stopLossLimitPercent = nil, nil
+	if !limitPercent.IsZero() {
+		b.stopLossLimitPercent = &limitPercent
+	}
+	return b
+}
+
+// Reference sets the price the percentage offsets of TakeProfitPercent and StopLossPercent
+// are relative to. It defaults to the limit price, then the stop price of the order.
+func (b OrderBuilder) Reference(price decimal.Decimal) OrderBuilder {
+	b.reference = &price
+	return b
+}
+
+// OCO makes the order a one-cancels-other exit order: a limit take profit leg and a stop loss
+// leg closing an existing position. Use SellOrder to close a long position and BuyOrder to close a short one.
+func (b OrderBuilder) OCO() OrderBuilder {
+	b.oco = true
+	return b
+}
+
+// TimeInForce sets how long the order stays open. It defaults to day, or gtc for crypto.
+func (b OrderBuilder) TimeInForce(tif TimeInForce) OrderBuilder {
+	b.req.TimeInForce = tif
+	return b
+}
+
+// Day makes the order expire at the end of the trading day.
+func (b OrderBuilder) Day() OrderBuilder { return b.TimeInForce(Day) }
+
+// GTC keeps the order open until it is filled or canceled.
+func (b OrderBuilder) GTC() OrderBuilder { return b.TimeInForce(GTC) }
+
+// IOC fills the order immediately, in whole or in part, and cancels the rest.
+func (b OrderBuilder) IOC() OrderBuilder { return b.TimeInForce(IOC) }
+
+// FOK fills the whole order immediately or cancels it.
+func (b OrderBuilder) FOK() OrderBuilder { return b.TimeInForce(FOK) }
+
+// OPG makes the order execute in the opening auction only.
+func (b OrderBuilder) OPG() OrderBuilder { return b.TimeInForce(OPG) }
+
+// CLS makes the order execute in the closing auction only.
+func (b OrderBuilder) CLS() OrderBuilder { return b.TimeInForce(CLS) }
+
+// ExtendedHours allows the order to fill in the pre-market and after-hours sessions.
+func (b OrderBuilder) ExtendedHours() OrderBuilder {
+	b.req.ExtendedHours = true
+	return b
+}
+
+// ClientOrderID sets the client order ID, a unique identifier chosen by the client.
+func (b OrderBuilder) ClientOrderID(id string) OrderBuilder {
+	b.req.ClientOrderID = id
+	return b
+}
+
+// PositionIntent tells whether the order opens or closes a position (for options).
+func (b OrderBuilder) PositionIntent(intent PositionIntent) OrderBuilder {
+	b.req.PositionIntent = intent
+	return b
+}
+
+// Build returns the order request, or a *ValidationError if it is invalid (see PlaceOrderRequest.Validate).
+// Unless the symbol is a crypto pair or an option, prices (including the trail price) are rounded
+// with RoundLimitPrice in the favor of the order: buy prices are rounded up and sell prices are rounded down.
+func (b OrderBuilder) Build() (PlaceOrderRequest, error) {
+	req := b.req
+	class := orderAssetClass(req.Symbol, nil)
+	crypto := class == Crypto
+	if req.Type == "" {
+		req.Type = Market
+	}
+	if b.oco {
+		req.Type = Limit
+	}
+	if req.TimeInForce == "" {
+		req.TimeInForce = Day
+		if crypto {
+			req.TimeInForce = GTC
+		}
+	}
+
+	// The exit legs of an entry order trade the opposite side, those of an OCO order are the order itself.
+	exitSide := Sell
+	if req.Side == Sell {
+		exitSide = Buy
+	}
+	if b.oco {
+		exitSide = req.Side
+	}
+	// A long position profits when the price rises.
+	long := exitSide == Sell
+
+	var errs fieldErrors
+	reference := b.reference
+	if reference == nil {
+		reference = req.LimitPrice
+	}
+	if reference == nil {
+		reference = req.StopPrice
+	}
+	offset := func(field string, percent *decimal.Decimal, up bool) *decimal.Decimal {
+		if reference == nil {
+			errs.add(field, "a reference price is required for percentage offsets")
+			return nil
+		}
+		delta := reference.Mul(*percent).Div(decimal.NewFromInt(100))
+		if !up {
+			delta = delta.Neg()
+		}
+		price := reference.Add(delta)
+		return &price
+	}
+	round := func(price *decimal.Decimal, side Side) *decimal.Decimal {
+		// Options have their own price increments, the equity rule does not apply.
+		if price == nil || class != USEquity {
+			return price
+		}
+		return RoundLimitPrice(*price, side)
+	}
+
+	takeProfit := b.takeProfit
+	if b.takeProfitPercent != nil {
+		takeProfit = offset("take_profit.limit_price", b.takeProfitPercent, long)
+	}
+	stopLoss, stopLossLimit := b.stopLoss, b.stopLossLimit
+	if b.stopLossPercent != nil {
+		stopLoss = offset("stop_loss.stop_price", b.stopLossPercent, !long)
+	}
+	if b.stopLossLimitPercent != nil {
+		stopLossLimit = offset("stop_loss.limit_price", b.stopLossLimitPercent, !long)
+	}
+	if len(errs) > 0 {
+		return req, errs.err()
+	}
+
+	req.LimitPrice = round(req.LimitPrice, req.Side)
+	req.StopPrice = round(req.StopPrice, req.Side)
+	req.TrailPrice = round(req.TrailPrice, req.Side)
+	if takeProfit != nil {
+		req.TakeProfit = &TakeProfit{LimitPrice: round(takeProfit, exitSide)}
+	}
+	if stopLoss != nil || stopLossLimit != nil {
+		req.StopLoss = &StopLoss{StopPrice: round(stopLoss, exitSide), LimitPrice: round(stopLossLimit, exitSide)}
+	}
+
+	switch {
+	case b.oco:
+		req.OrderClass = OCO
+	case req.TakeProfit != nil && req.StopLoss != nil:
+		req.OrderClass = Bracket
+	case req.TakeProfit != nil || req.StopLoss != nil:
+		req.OrderClass = OTO
+	}
+
+	return req, req.Validate(nil)
+}
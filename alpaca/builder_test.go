@@ -0,0 +1,132 @@
+package alpaca
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderBuilder(t *testing.T) {
+	d := decimal.RequireFromString
+	assertPrice := func(t *testing.T, expected string, actual *decimal.Decimal) {
+		t.Helper()
+		require.NotNil(t, actual)
+		assert.Equal(t, expected, actual.String())
+	}
+
+	t.Run("market day order by default", func(t *testing.T) {
+		req, err := BuyOrder("AAPL").Qty(d("10")).Build()
+		require.NoError(t, err)
+		assert.Equal(t, Market, req.Type)
+		assert.Equal(t, Day, req.TimeInForce)
+		assert.Equal(t, OrderClass(""), req.OrderClass)
+		assertPrice(t, "10", req.Qty)
+	})
+
+	t.Run("bracket", func(t *testing.T) {
+		req, err := BuyOrder("AAPL").Qty(d("10")).Limit(d("180.123")).
+			TakeProfit(d("190.009")).StopLoss(d("170.001"), d("169.999")).GTC().Build()
+		require.NoError(t, err)
+		assert.Equal(t, Bracket, req.OrderClass)
+		assert.Equal(t, Limit, req.Type)
+		assert.Equal(t, GTC, req.TimeInForce)
+		// The entry is a buy, rounded up, the exits are sells, rounded down.
+		assertPrice(t, "180.13", req.LimitPrice)
+		assertPrice(t, "190", req.TakeProfit.LimitPrice)
+		assertPrice(t, "170", req.StopLoss.StopPrice)
+		assertPrice(t, "169.99", req.StopLoss.LimitPrice)
+	})
+
+	t.Run("oto", func(t *testing.T) {
+		req, err := SellOrder("AAPL").Qty(d("5")).Market().StopLoss(d("200"), decimal.Zero).Build()
+		require.NoError(t, err)
+		assert.Equal(t, OTO, req.OrderClass)
+		assertPrice(t, "200", req.StopLoss.StopPrice)
+		assert.Nil(t, req.StopLoss.LimitPrice)
+		assert.Nil(t, req.TakeProfit)
+	})
+
+	t.Run("percentage offsets of a long entry", func(t *testing.T) {
+		req, err := BuyOrder("AAPL").Qty(d("1")).Limit(d("100")).TakeProfitPercent(d("10")).StopLossPercent(d("5"), d("5.5")).Build()
+		require.NoError(t, err)
+		assertPrice(t, "110", req.TakeProfit.LimitPrice)
+		assertPrice(t, "95", req.StopLoss.StopPrice)
+		assertPrice(t, "94.5", req.StopLoss.LimitPrice)
+	})
+
+	t.Run("percentage offsets of a short entry", func(t *testing.T) {
+		req, err := SellOrder("AAPL").Qty(d("1")).Market().Reference(d("50")).TakeProfitPercent(d("2")).StopLossPercent(d("1"), decimal.Zero).Build()
+		require.NoError(t, err)
+		assertPrice(t, "49", req.TakeProfit.LimitPrice)
+		assertPrice(t, "50.5", req.StopLoss.StopPrice)
+		assert.Nil(t, req.LimitPrice)
+	})
+
+	t.Run("oco closing a long position", func(t *testing.T) {
+		req, err := SellOrder("AAPL").Qty(d("100")).OCO().Reference(d("300")).TakeProfitPercent(d("1")).StopLossPercent(d("1"), decimal.Zero).GTC().Build()
+		require.NoError(t, err)
+		assert.Equal(t, OCO, req.OrderClass)
+		assert.Equal(t, Limit, req.Type)
+		assertPrice(t, "303", req.TakeProfit.LimitPrice)
+		assertPrice(t, "297", req.StopLoss.StopPrice)
+	})
+
+	t.Run("trailing stop", func(t *testing.T) {
+		req, err := SellOrder("AAPL").Qty(d("1")).TrailingStopPercent(d("2.5")).Build()
+		require.NoError(t, err)
+		assert.Equal(t, TrailingStop, req.Type)
+		assertPrice(t, "2.5", req.TrailPercent)
+		assert.Nil(t, req.TrailPrice)
+	})
+
+	t.Run("trail price is rounded", func(t *testing.T) {
+		req, err := SellOrder("AAPL").Qty(d("1")).TrailingStop(d("1.239")).Build()
+		require.NoError(t, err)
+		assertPrice(t, "1.23", req.TrailPrice)
+		assert.Nil(t, req.TrailPercent)
+	})
+
+	t.Run("crypto prices are not rounded", func(t *testing.T) {
+		req, err := BuyOrder("BTC/USD").Notional(d("100")).Limit(d("65000.123456")).Build()
+		require.NoError(t, err)
+		assert.Equal(t, GTC, req.TimeInForce)
+		assertPrice(t, "65000.123456", req.LimitPrice)
+		assertPrice(t, "100", req.Notional)
+		assert.Nil(t, req.Qty)
+	})
+
+	t.Run("option prices are not rounded", func(t *testing.T) {
+		req, err := BuyOrder("AAPL250620C00200000").Qty(d("1")).Limit(d("2.555")).Build()
+		require.NoError(t, err)
+		assert.Equal(t, Day, req.TimeInForce)
+		assertPrice(t, "2.555", req.LimitPrice)
+	})
+
+	t.Run("templates are not modified", func(t *testing.T) {
+		base := BuyOrder("AAPL").Qty(d("1"))
+		_, err := base.Limit(d("10")).TakeProfit(d("11")).Build()
+		require.NoError(t, err)
+		req, err := base.Build()
+		require.NoError(t, err)
+		assert.Equal(t, Market, req.Type)
+		assert.Nil(t, req.TakeProfit)
+	})
+
+	t.Run("percentage without reference", func(t *testing.T) {
+		_, err := BuyOrder("AAPL").Qty(d("1")).TakeProfitPercent(d("5")).Build()
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.True(t, validationErr.HasField("take_profit.limit_price"))
+	})
+
+	t.Run("invalid order", func(t *testing.T) {
+		_, err := BuyOrder("AAPL").Limit(d("10")).ExtendedHours().GTC().Build()
+		var validationErr *ValidationError
+		require.True(t, errors.As(err, &validationErr))
+		assert.True(t, validationErr.HasField("qty"))
+		assert.True(t, validationErr.HasField("extended_hours"))
+	})
+}
@@ -0,0 +1,391 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/civil"
+)
+
+// ErrNoSession is returned when the market calendar has no session in the searched period.
+var ErrNoSession = errors.New("no market session found")
+
+// TimeWindow is the time between Start (inclusive) and End (exclusive).
+type TimeWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Contains tells whether t is in the window.
+func (w TimeWindow) Contains(t time.Time) bool {
+	return !t.Before(w.Start) && t.Before(w.End)
+}
+
+// Duration returns the length of the window.
+func (w TimeWindow) Duration() time.Duration {
+	return w.End.Sub(w.Start)
+}
+
+// overlap returns how much of the window is between from and to.
+func (w TimeWindow) overlap(from, to time.Time) time.Duration {
+	start, end := w.Start, w.End
+	if from.After(start) {
+		start = from
+	}
+	if to.Before(end) {
+		end = to
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+// MarketPhase is the part of the trading day the market is in.
+type MarketPhase string
+
+const (
+	MarketPreMarket  MarketPhase = "pre_market"
+	MarketRegular    MarketPhase = "regular"
+	MarketAfterHours MarketPhase = "after_hours"
+	MarketClosed     MarketPhase = "closed"
+)
+
+// Session is a trading day of the market calendar. Its times are in America/New_York.
+type Session struct {
+	Date       civil.Date
+	PreMarket  TimeWindow
+	Regular    TimeWindow
+	AfterHours TimeWindow
+	// EarlyClose is set if the regular session closes before 16:00.
+	EarlyClose bool
+}
+
+// Extended returns the whole session, from the start of the pre-market to the end of the after-hours.
+func (s Session) Extended() TimeWindow {
+	return TimeWindow{Start: s.PreMarket.Start, End: s.AfterHours.End}
+}
+
+// Phase returns the phase of the session at t, MarketClosed if t is outside of the session.
+func (s Session) Phase(t time.Time) MarketPhase {
+	switch {
+	case s.Regular.Contains(t):
+		return MarketRegular
+	case s.PreMarket.Contains(t):
+		return MarketPreMarket
+	case s.AfterHours.Contains(t):
+		return MarketAfterHours
+	default:
+		return MarketClosed
+	}
+}
+
+// MarketCalendar answers questions about the trading sessions of the market. The calendar is
+// fetched with GetCalendar a year at a time, the first time a date of that year is needed,
+// and cached for the lifetime of the MarketCalendar. It is safe for concurrent use.
+type MarketCalendar struct {
+	client *Client
+	loc    *time.Location
+	locErr error
+
+	mu sync.Mutex
+	// years holds the years fetched so far, or being fetched.
+	years map[int]*calendarYear
+}
+
+// calendarYear is the result of fetching the sessions of a year, available once done is closed.
+type calendarYear struct {
+	done chan struct{}
+	// sessions are sorted by date.
+	sessions []Session
+	err      error
+}
+
+// NewMarketCalendar returns a market calendar fetching the sessions with client.
+func NewMarketCalendar(client *Client) *MarketCalendar {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		err = fmt.Errorf("failed to load the market time zone: %w", err)
+	}
+	return &MarketCalendar{
+		client: client,
+		loc:    loc,
+		locErr: err,
+		years:  make(map[int]*calendarYear),
+	}
+}
+
+// Load fetches the sessions between start and end unless they are cached already.
+// The other methods load the sessions they need on demand, Load allows doing it
+// upfront and with a context.
+func (mc *MarketCalendar) Load(ctx context.Context, start, end time.Time) error {
+	if mc.locErr != nil {
+		return mc.locErr
+	}
+	for year := start.In(mc.loc).Year(); year <= end.In(mc.loc).Year(); year++ {
+		if _, err := mc.year(ctx, year); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Session returns the session of date, or nil if the market is closed on that day.
+func (mc *MarketCalendar) Session(date civil.Date) (*Session, error) {
+	return mc.SessionWithContext(context.Background(), date)
+}
+
+// SessionWithContext returns the session of date, or nil if the market is closed on that day.
+func (mc *MarketCalendar) SessionWithContext(ctx context.Context, date civil.Date) (*Session, error) {
+	sessions, err := mc.year(ctx, date.Year)
+	if err != nil {
+		return nil, err
+	}
+	i := sort.Search(len(sessions), func(i int) bool { return !sessions[i].Date.Before(date) })
+	if i < len(sessions) && sessions[i].Date == date {
+		session := sessions[i]
+		return &session, nil
+	}
+	return nil, nil
+}
+
+// IsTradingDay tells whether the market is open on date.
+func (mc *MarketCalendar) IsTradingDay(date civil.Date) (bool, error) {
+	return mc.IsTradingDayWithContext(context.Background(), date)
+}
+
+// IsTradingDayWithContext tells whether the market is open on date.
+func (mc *MarketCalendar) IsTradingDayWithContext(ctx context.Context, date civil.Date) (bool, error) {
+	session, err := mc.SessionWithContext(ctx, date)
+	return session != nil, err
+}
+
+// SessionAt returns the session whose extended hours contain t, or nil if there is none.
+func (mc *MarketCalendar) SessionAt(t time.Time) (*Session, error) {
+	return mc.SessionAtWithContext(context.Background(), t)
+}
+
+// SessionAtWithContext returns the session whose extended hours contain t, or nil if there is none.
+func (mc *MarketCalendar) SessionAtWithContext(ctx context.Context, t time.Time) (*Session, error) {
+	if mc.locErr != nil {
+		return nil, mc.locErr
+	}
+	session, err := mc.SessionWithContext(ctx, civil.DateOf(t.In(mc.loc)))
+	if err != nil || session == nil || !session.Extended().Contains(t) {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Phase returns the phase of the market at t.
+func (mc *MarketCalendar) Phase(t time.Time) (MarketPhase, error) {
+	return mc.PhaseWithContext(context.Background(), t)
+}
+
+// PhaseWithContext returns the phase of the market at t.
+func (mc *MarketCalendar) PhaseWithContext(ctx context.Context, t time.Time) (MarketPhase, error) {
+	session, err := mc.SessionAtWithContext(ctx, t)
+	if err != nil || session == nil {
+		return MarketClosed, err
+	}
+	return session.Phase(t), nil
+}
+
+// NextSession returns the first session whose regular hours open after t.
+func (mc *MarketCalendar) NextSession(t time.Time) (*Session, error) {
+	return mc.NextSessionWithContext(context.Background(), t)
+}
+
+// NextSessionWithContext returns the first session whose regular hours open after t.
+func (mc *MarketCalendar) NextSessionWithContext(ctx context.Context, t time.Time) (*Session, error) {
+	return mc.sessionAfter(ctx, t, func(s *Session) time.Time { return s.Regular.Start })
+}
+
+// sessionAfter returns the first session whose time returned by at is after t.
+func (mc *MarketCalendar) sessionAfter(ctx context.Context, t time.Time, at func(s *Session) time.Time) (*Session, error) {
+	if mc.locErr != nil {
+		return nil, mc.locErr
+	}
+	// A session is at most a few days away, searching the next year covers the end of December.
+	year := t.In(mc.loc).Year()
+	for y := year; y <= year+1; y++ {
+		sessions, err := mc.year(ctx, y)
+		if err != nil {
+			return nil, err
+		}
+		for i := range sessions {
+			if at(&sessions[i]).After(t) {
+				session := sessions[i]
+				return &session, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w after %s", ErrNoSession, t)
+}
+
+// PrevSession returns the last session whose regular hours closed at or before t.
+func (mc *MarketCalendar) PrevSession(t time.Time) (*Session, error) {
+	return mc.PrevSessionWithContext(context.Background(), t)
+}
+
+// PrevSessionWithContext returns the last session whose regular hours closed at or before t.
+func (mc *MarketCalendar) PrevSessionWithContext(ctx context.Context, t time.Time) (*Session, error) {
+	if mc.locErr != nil {
+		return nil, mc.locErr
+	}
+	year := t.In(mc.loc).Year()
+	for y := year; y >= year-1; y-- {
+		sessions, err := mc.year(ctx, y)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(sessions) - 1; i >= 0; i-- {
+			if !sessions[i].Regular.End.After(t) {
+				session := sessions[i]
+				return &session, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w before %s", ErrNoSession, t)
+}
+
+// TradingMinutes returns the number of whole minutes the market is open between from and to.
+// If extendedHours is set, the pre-market and after-hours sessions are counted too.
+func (mc *MarketCalendar) TradingMinutes(from, to time.Time, extendedHours bool) (int, error) {
+	return mc.TradingMinutesWithContext(context.Background(), from, to, extendedHours)
+}
+
+// TradingMinutesWithContext returns the number of whole minutes the market is open between from and to.
+// If extendedHours is set, the pre-market and after-hours sessions are counted too.
+func (mc *MarketCalendar) TradingMinutesWithContext(
+	ctx context.Context, from, to time.Time, extendedHours bool,
+) (int, error) {
+	if mc.locErr != nil {
+		return 0, mc.locErr
+	}
+	if !to.After(from) {
+		return 0, nil
+	}
+	var total time.Duration
+	for year := from.In(mc.loc).Year(); year <= to.In(mc.loc).Year(); year++ {
+		sessions, err := mc.year(ctx, year)
+		if err != nil {
+			return 0, err
+		}
+		for _, session := range sessions {
+			window := session.Regular
+			if extendedHours {
+				window = session.Extended()
+			}
+			total += window.overlap(from, to)
+		}
+	}
+	return int(total / time.Minute), nil
+}
+
+// year returns the sessions of year, fetching them if needed. Concurrent calls for the same
+// year share a single fetch, and the lock is not held while fetching.
+func (mc *MarketCalendar) year(ctx context.Context, year int) ([]Session, error) {
+	if mc.locErr != nil {
+		return nil, mc.locErr
+	}
+	for {
+		mc.mu.Lock()
+		y, ok := mc.years[year]
+		if !ok {
+			y = &calendarYear{done: make(chan struct{})}
+			mc.years[year] = y
+		}
+		mc.mu.Unlock()
+
+		if !ok {
+			y.sessions, y.err = mc.fetchYear(ctx, year)
+			if y.err != nil {
+				// Failures are not cached, the next call tries again.
+				mc.mu.Lock()
+				delete(mc.years, year)
+				mc.mu.Unlock()
+			}
+			close(y.done)
+			return y.sessions, y.err
+		}
+
+		select {
+		case <-y.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if y.err != nil && (errors.Is(y.err, context.Canceled) || errors.Is(y.err, context.DeadlineExceeded)) {
+			// The context of the caller that fetched the year was done, not ours: try again.
+			continue
+		}
+		return y.sessions, y.err
+	}
+}
+
+// fetchYear fetches and parses the sessions of year.
+func (mc *MarketCalendar) fetchYear(ctx context.Context, year int) ([]Session, error) {
+	days, err := mc.client.GetCalendarWithContext(ctx, GetCalendarRequest{
+		Start: time.Date(year, time.January, 1, 0, 0, 0, 0, mc.loc),
+		End:   time.Date(year, time.December, 31, 0, 0, 0, 0, mc.loc),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the market calendar of %d: %w", year, err)
+	}
+	sessions := make([]Session, 0, len(days))
+	for _, day := range days {
+		session, err := parseSession(day, mc.loc)
+		if err != nil {
+			return nil, err
+		}
+		if session.Date.Year == year {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].Date.Before(sessions[j].Date) })
+	return sessions, nil
+}
+
+// parseSession converts a calendar day to a session. The extended hours default to 04:00 - 20:00
+// if the calendar day does not have them.
+func parseSession(day CalendarDay, loc *time.Location) (Session, error) {
+	date, err := civil.ParseDate(day.Date)
+	if err != nil {
+		return Session{}, fmt.Errorf("invalid calendar date %q: %w", day.Date, err)
+	}
+	at := func(field, value, fallback string) (time.Time, error) {
+		if value == "" {
+			value = fallback
+		}
+		// The regular hours are formatted as 15:04 and the extended hours as 1504.
+		t, err := time.Parse("1504", strings.Replace(value, ":", "", 1))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s %q of %s: %w", field, value, day.Date, err)
+		}
+		return time.Date(date.Year, date.Month, date.Day, t.Hour(), t.Minute(), 0, 0, loc), nil
+	}
+
+	var times [4]time.Time
+	for i, f := range []struct{ field, value, fallback string }{
+		{"session_open", day.SessionOpen, "0400"},
+		{"open", day.Open, ""},
+		{"close", day.Close, ""},
+		{"session_close", day.SessionClose, "2000"},
+	} {
+		if times[i], err = at(f.field, f.value, f.fallback); err != nil {
+			return Session{}, err
+		}
+	}
+	return Session{
+		Date:       date,
+		PreMarket:  TimeWindow{Start: times[0], End: times[1]},
+		Regular:    TimeWindow{Start: times[1], End: times[2]},
+		AfterHours: TimeWindow{Start: times[2], End: times[3]},
+		EarlyClose: times[2].Hour() < 16,
+	}, nil
+}
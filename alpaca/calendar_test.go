@@ -0,0 +1,175 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"cloud.google.com/go/civil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// calendarClient serves a calendar with the trading days around Thanksgiving and New Year 2024.
+func calendarClient(t *testing.T, requests *[]string) *Client {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		require.Equal(t, "/v2/calendar", req.URL.Path)
+		*requests = append(*requests, req.URL.RawQuery)
+		var body string
+		switch req.URL.Query().Get("start") {
+		case "2024-01-01":
+			body = `[
+				{"date":"2024-11-26","open":"09:30","close":"16:00","session_open":"0400","session_close":"2000"},
+				{"date":"2024-11-27","open":"09:30","close":"16:00","session_open":"0400","session_close":"2000"},
+				{"date":"2024-11-29","open":"09:30","close":"13:00","session_open":"0400","session_close":"1700"},
+				{"date":"2024-12-31","open":"09:30","close":"16:00","session_open":"0400","session_close":"2000"}
+			]`
+		case "2025-01-01":
+			body = `[{"date":"2025-01-02","open":"09:30","close":"16:00"}]`
+		default:
+			return nil, errors.New("unexpected calendar request")
+		}
+		return &http.Response{Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+	return c
+}
+
+func TestMarketCalendar(t *testing.T) {
+	var requests []string
+	mc := NewMarketCalendar(calendarClient(t, &requests))
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, ny)
+	}
+
+	session, err := mc.Session(civil.Date{Year: 2024, Month: time.November, Day: 29})
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.True(t, session.EarlyClose)
+	assert.Equal(t, at(time.November, 29, 4, 0), session.PreMarket.Start)
+	assert.Equal(t, at(time.November, 29, 9, 30), session.Regular.Start)
+	assert.Equal(t, at(time.November, 29, 13, 0), session.Regular.End)
+	assert.Equal(t, at(time.November, 29, 17, 0), session.AfterHours.End)
+	assert.Equal(t, 210*time.Minute, session.Regular.Duration())
+	// 9:30 EST is 14:30 UTC.
+	assert.Equal(t, time.Date(2024, time.November, 29, 14, 30, 0, 0, time.UTC), session.Regular.Start.UTC())
+
+	ok, err := mc.IsTradingDay(civil.Date{Year: 2024, Month: time.November, Day: 28})
+	require.NoError(t, err)
+	assert.False(t, ok, "thanksgiving")
+	ok, err = mc.IsTradingDay(civil.Date{Year: 2024, Month: time.November, Day: 27})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	for _, tt := range []struct {
+		t     time.Time
+		phase MarketPhase
+	}{
+		{at(time.November, 27, 3, 59), MarketClosed},
+		{at(time.November, 27, 4, 0), MarketPreMarket},
+		{at(time.November, 27, 9, 30), MarketRegular},
+		{at(time.November, 27, 16, 0), MarketAfterHours},
+		{at(time.November, 27, 20, 0), MarketClosed},
+		{at(time.November, 28, 12, 0), MarketClosed},
+		{at(time.November, 29, 13, 30), MarketAfterHours},
+	} {
+		phase, err := mc.Phase(tt.t)
+		require.NoError(t, err)
+		assert.Equal(t, tt.phase, phase, tt.t.String())
+	}
+
+	next, err := mc.NextSession(at(time.November, 27, 10, 0))
+	require.NoError(t, err)
+	assert.Equal(t, civil.Date{Year: 2024, Month: time.November, Day: 29}, next.Date)
+	next, err = mc.NextSession(at(time.December, 31, 10, 0))
+	require.NoError(t, err)
+	assert.Equal(t, civil.Date{Year: 2025, Month: time.January, Day: 2}, next.Date)
+	// Without extended hours in the calendar, the defaults apply.
+	assert.Equal(t, 4, next.PreMarket.Start.Hour())
+	assert.Equal(t, 20, next.AfterHours.End.Hour())
+
+	prev, err := mc.PrevSession(at(time.November, 29, 12, 0))
+	require.NoError(t, err)
+	assert.Equal(t, civil.Date{Year: 2024, Month: time.November, Day: 27}, prev.Date)
+	prev, err = mc.PrevSession(at(time.November, 29, 13, 0))
+	require.NoError(t, err)
+	assert.Equal(t, civil.Date{Year: 2024, Month: time.November, Day: 29}, prev.Date)
+
+	minutes, err := mc.TradingMinutes(at(time.November, 27, 15, 0), at(time.November, 29, 10, 0), false)
+	require.NoError(t, err)
+	assert.Equal(t, 60+30, minutes)
+	minutes, err = mc.TradingMinutes(at(time.November, 27, 15, 0), at(time.November, 29, 10, 0), true)
+	require.NoError(t, err)
+	assert.Equal(t, 5*60+6*60, minutes)
+
+	// Every year was fetched once.
+	assert.Equal(t, []string{"end=2024-12-31&start=2024-01-01", "end=2025-12-31&start=2025-01-01"}, requests)
+}
+
+func TestMarketCalendarErrors(t *testing.T) {
+	var requests []string
+	mc := NewMarketCalendar(calendarClient(t, &requests))
+
+	err := mc.Load(context.Background(), time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), time.Now())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "market calendar of 2023")
+
+	_, err = mc.NextSession(time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+	require.Error(t, err)
+
+	mc = NewMarketCalendar(calendarClient(t, &requests))
+	// Years without sessions.
+	done := make(chan struct{})
+	close(done)
+	mc.years[2024] = &calendarYear{done: done}
+	mc.years[2023] = &calendarYear{done: done}
+	_, err = mc.PrevSession(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	assert.ErrorIs(t, err, ErrNoSession)
+}
+
+func TestMarketCalendarConcurrentFetch(t *testing.T) {
+	var requests []string
+	client := calendarClient(t, &requests)
+	serve := client.do
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	client.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		once.Do(func() { close(started) })
+		select {
+		case <-release:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		return serve(c, req)
+	}
+	mc := NewMarketCalendar(client)
+	date := civil.Date{Year: 2024, Month: time.November, Day: 27}
+
+	// A reader waiting for a slow fetch gives up when its context is done.
+	fetched := make(chan struct{})
+	go func() {
+		defer close(fetched)
+		_, _ = mc.Session(date)
+	}()
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := mc.SessionWithContext(ctx, date)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	<-fetched
+	session, err := mc.Session(date)
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.Len(t, requests, 1, "concurrent readers share a single fetch")
+}
@@ -0,0 +1,350 @@
+// Package cassette records the HTTP interactions of the trading and marketdata clients
+// to files and replays them offline.
+//
+// A Recorder is plugged into a client through ClientOpts.HTTPClient:
+//
+//	rec, err := cassette.New("testdata/account.json", cassette.Options{Mode: cassette.ModeRecord})
+//	if err != nil {
+//		return err
+//	}
+//	defer rec.Save()
+//	client := alpaca.NewClient(alpaca.ClientOpts{HTTPClient: rec.HTTPClient()})
+//
+// In record mode the requests are sent to the real server and the request/response pairs
+// are saved with the credentials scrubbed. In replay mode (the default) no request leaves
+// the process: every request is answered by the first unused recorded interaction that
+// matches it.
+package cassette
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Mode is the operating mode of a Recorder.
+type Mode int
+
+const (
+	// ModeReplay serves the requests from the cassette file without any network traffic.
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests to the server and records the interactions.
+	ModeRecord
+)
+
+// Strictness determines which recorded interactions match a request.
+type Strictness int
+
+const (
+	// MatchQuery matches the method, the path and the normalized query of the request.
+	MatchQuery Strictness = iota
+	// MatchBody matches like MatchQuery and additionally requires identical request bodies.
+	MatchBody
+	// MatchPath only matches the method and the path of the request, ignoring the query.
+	// The interactions of the same endpoint are then served in the recorded order.
+	MatchPath
+)
+
+// ErrNoInteraction is returned in replay mode when no unused recorded interaction matches the request.
+var ErrNoInteraction = errors.New("cassette: no matching interaction")
+
+// scrubbedHeaders are never written to the cassette files.
+var scrubbedHeaders = []string{
+	"Authorization",
+	"APCA-API-KEY-ID",
+	"APCA-API-SECRET-KEY",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// Options configures a Recorder.
+type Options struct {
+	// Mode is ModeReplay by default.
+	Mode Mode
+	// Strictness is MatchQuery by default.
+	Strictness Strictness
+	// IgnoreQuery lists query parameters that are left out of the matching, e.g. timestamps
+	// that differ between the recording and the replay.
+	IgnoreQuery []string
+	// ScrubHeaders lists further headers to remove before the interactions are saved.
+	ScrubHeaders []string
+	// Transport sends the requests in record mode. http.DefaultTransport is used if nil.
+	Transport http.RoundTripper
+}
+
+// Interaction is a recorded request/response pair.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is an HTTP body. It is stored as a string if it is valid UTF-8, and base64-encoded otherwise.
+type Body []byte
+
+// MarshalJSON implements json.Marshaler.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+	return json.Marshal(map[string]string{"base64": base64.StdEncoding.EncodeToString(b)})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = Body(s)
+		return nil
+	}
+	var encoded struct {
+		Base64 string `json:"base64"`
+	}
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded.Base64)
+	if err != nil {
+		return err
+	}
+	*b = decoded
+	return nil
+}
+
+type file struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records or replays the interactions of a cassette file.
+// It is safe for concurrent use.
+type Recorder struct {
+	path string
+	opts Options
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// New creates a Recorder for the cassette file at path. In replay mode the file must exist.
+// In record mode it is created or overwritten by Save.
+func New(path string, opts Options) (*Recorder, error) {
+	if opts.Transport == nil {
+		opts.Transport = http.DefaultTransport
+	}
+	r := &Recorder{path: path, opts: opts}
+	if opts.Mode == ModeRecord {
+		return r, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %w", err)
+	}
+	var f file
+	if err := json.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("cassette: failed to parse %s: %w", path, err)
+	}
+	r.interactions = f.Interactions
+	r.used = make([]bool, len(f.Interactions))
+	return r, nil
+}
+
+// HTTPClient returns an http.Client that uses r as its transport.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns the interactions recorded or loaded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.interactions...)
+}
+
+// Save writes the recorded interactions to the cassette file. It is a no-op in replay mode.
+func (r *Recorder) Save() error {
+	if r.opts.Mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	b, err := json.MarshalIndent(file{Interactions: r.interactions}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if r.opts.Mode == ModeRecord {
+		return r.record(req, body)
+	}
+	return r.replay(req, body)
+}
+
+func (r *Recorder) record(req *http.Request, reqBody []byte) (*http.Response, error) {
+	resp, err := r.opts.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	header := resp.Header.Clone()
+	// The bodies are stored decoded so that the cassettes remain readable.
+	if strings.EqualFold(header.Get("Content-Encoding"), "gzip") {
+		gr, err := gzip.NewReader(bytes.NewReader(respBody))
+		if err != nil {
+			return nil, err
+		}
+		if respBody, err = io.ReadAll(gr); err != nil {
+			return nil, err
+		}
+		header.Del("Content-Encoding")
+		header.Del("Content-Length")
+	}
+
+	in := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: r.scrub(req.Header),
+			Body:   reqBody,
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     r.scrub(header),
+			Body:       respBody,
+		},
+	}
+	r.mu.Lock()
+	r.interactions = append(r.interactions, in)
+	r.used = append(r.used, true)
+	r.mu.Unlock()
+	return in.Response.httpResponse(req), nil
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.interactions {
+		if r.used[i] || !r.matches(in.Request, req, body) {
+			continue
+		}
+		r.used[i] = true
+		return in.Response.httpResponse(req), nil
+	}
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, req.Method, req.URL)
+}
+
+func (r *Recorder) matches(recorded Request, req *http.Request, body []byte) bool {
+	if recorded.Method != req.Method {
+		return false
+	}
+	u, err := url.Parse(recorded.URL)
+	if err != nil || u.Path != req.URL.Path {
+		return false
+	}
+	if r.opts.Strictness == MatchPath {
+		return true
+	}
+	if r.normalizeQuery(u.Query()) != r.normalizeQuery(req.URL.Query()) {
+		return false
+	}
+	if r.opts.Strictness == MatchBody {
+		return bytes.Equal(recorded.Body, body)
+	}
+	return true
+}
+
+// normalizeQuery encodes q with the keys sorted and the ignored parameters removed.
+func (r *Recorder) normalizeQuery(q url.Values) string {
+	for _, key := range r.opts.IgnoreQuery {
+		q.Del(key)
+	}
+	return q.Encode()
+}
+
+func (r *Recorder) scrub(header http.Header) http.Header {
+	header = header.Clone()
+	for _, key := range scrubbedHeaders {
+		header.Del(key)
+	}
+	for _, key := range r.opts.ScrubHeaders {
+		header.Del(key)
+	}
+	if len(header) == 0 {
+		return nil
+	}
+	return header
+}
+
+func (resp Response) httpResponse(req *http.Request) *http.Response {
+	header := resp.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode)),
+		StatusCode:    resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(resp.Body)),
+		ContentLength: int64(len(resp.Body)),
+		Request:       req,
+	}
+}
+
+// readRequestBody reads the body of req and replaces it with an equivalent reader.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,230 @@
+package cassette
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+	"github.com/alpacahq/alpaca-trade-api-go/v3/marketdata"
+)
+
+// unreachableURL makes sure that the replaying clients can not reach any server.
+const unreachableURL = "http://127.0.0.1:1"
+
+func TestRecordAndReplayTrading(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key", r.Header.Get("APCA-API-KEY-ID"))
+		switch r.URL.Path {
+		case "/v2/account":
+			fmt.Fprint(w, `{"id":"account_id","cash":"1000"}`)
+		case "/v2/orders":
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), `"symbol":"AAPL"`)
+			w.Header().Set("Set-Cookie", "session=secret")
+			fmt.Fprint(w, `{"id":"order_id","symbol":"AAPL"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	path := filepath.Join(t.TempDir(), "cassettes", "trading.json")
+
+	rec, err := New(path, Options{Mode: ModeRecord})
+	require.NoError(t, err)
+	c := alpaca.NewClient(alpaca.ClientOpts{
+		APIKey: "key", APISecret: "secret", BaseURL: ts.URL, HTTPClient: rec.HTTPClient(),
+	})
+	acct, err := c.GetAccount()
+	require.NoError(t, err)
+	qty := decimal.NewFromInt(1)
+	_, err = c.PlaceOrder(alpaca.PlaceOrderRequest{
+		Symbol: "AAPL", Qty: &qty, Side: alpaca.Buy, Type: alpaca.Market, TimeInForce: alpaca.Day,
+	})
+	require.NoError(t, err)
+	require.NoError(t, rec.Save())
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "secret")
+	assert.NotContains(t, strings.ToLower(string(b)), "apca-api-key-id")
+	assert.Len(t, rec.Interactions(), 2)
+
+	rep, err := New(path, Options{})
+	require.NoError(t, err)
+	c = alpaca.NewClient(alpaca.ClientOpts{BaseURL: unreachableURL, HTTPClient: rep.HTTPClient()})
+	replayed, err := c.GetAccount()
+	require.NoError(t, err)
+	assert.Equal(t, acct.ID, replayed.ID)
+	assert.True(t, acct.Cash.Equal(replayed.Cash))
+	order, err := c.PlaceOrder(alpaca.PlaceOrderRequest{
+		Symbol: "AAPL", Qty: &qty, Side: alpaca.Buy, Type: alpaca.Market, TimeInForce: alpaca.Day,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "order_id", order.ID)
+
+	// every interaction is only served once
+	_, err = c.GetAccount()
+	assert.ErrorIs(t, err, ErrNoInteraction)
+}
+
+func TestRecordAndReplayPagination(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/stocks/trades", r.URL.Path)
+		// the marketdata client asks for gzip, which is stored decoded
+		w.Header().Set("Content-Encoding", "gzip")
+		gw := gzip.NewWriter(w)
+		defer gw.Close()
+		switch r.URL.Query().Get("page_token") {
+		case "":
+			fmt.Fprint(gw, `{"trades":{"AAPL":[{"t":"2024-03-01T15:00:00Z","p":180,"s":1,"i":1}]},"next_page_token":"page2"}`)
+		case "page2":
+			fmt.Fprint(gw, `{"trades":{"AAPL":[{"t":"2024-03-01T15:00:01Z","p":181,"s":2,"i":2}]},"next_page_token":null}`)
+		}
+	}))
+	defer ts.Close()
+	path := filepath.Join(t.TempDir(), "trades.json")
+	req := marketdata.GetTradesRequest{
+		Start: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	rec, err := New(path, Options{Mode: ModeRecord})
+	require.NoError(t, err)
+	c := marketdata.NewClient(marketdata.ClientOpts{BaseURL: ts.URL, HTTPClient: rec.HTTPClient()})
+	recorded, err := c.GetTrades("AAPL", req)
+	require.NoError(t, err)
+	require.Len(t, recorded, 2)
+	require.NoError(t, rec.Save())
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "next_page_token")
+
+	rep, err := New(path, Options{})
+	require.NoError(t, err)
+	c = marketdata.NewClient(marketdata.ClientOpts{BaseURL: unreachableURL, HTTPClient: rep.HTTPClient()})
+	replayed, err := c.GetTrades("AAPL", req)
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+}
+
+func writeCassette(t *testing.T, interactions ...Interaction) string {
+	t.Helper()
+	rec, err := New(filepath.Join(t.TempDir(), "cassette.json"), Options{Mode: ModeRecord})
+	require.NoError(t, err)
+	rec.interactions = interactions
+	require.NoError(t, rec.Save())
+	return rec.path
+}
+
+func get(t *testing.T, client *http.Client, method, u, body string) (string, error) {
+	t.Helper()
+	req, err := http.NewRequest(method, u, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(b), nil
+}
+
+func TestStrictness(t *testing.T) {
+	path := writeCassette(t,
+		Interaction{
+			Request:  Request{Method: "GET", URL: "https://example.com/v2/bars?symbols=AAPL&start=1&limit=10"},
+			Response: Response{StatusCode: 200, Body: Body("first")},
+		},
+		Interaction{
+			Request:  Request{Method: "POST", URL: "https://example.com/v2/orders", Body: Body(`{"qty":"1"}`)},
+			Response: Response{StatusCode: 200, Body: Body("order")},
+		},
+		Interaction{
+			Request:  Request{Method: "GET", URL: "https://example.com/v2/bars?symbols=MSFT&start=1"},
+			Response: Response{StatusCode: 200, Body: Body("second")},
+		},
+	)
+
+	tests := []struct {
+		name    string
+		opts    Options
+		method  string
+		url     string
+		body    string
+		want    string
+		wantErr bool
+	}{
+		{name: "query order is ignored", method: "GET", url: "http://localhost/v2/bars?limit=10&start=1&symbols=AAPL", want: "first"},
+		{name: "query mismatch", method: "GET", url: "http://localhost/v2/bars?symbols=AAPL&start=2&limit=10", wantErr: true},
+		{
+			name: "ignored query parameter", opts: Options{IgnoreQuery: []string{"start"}},
+			method: "GET", url: "http://localhost/v2/bars?symbols=MSFT&start=2", want: "second",
+		},
+		{name: "method mismatch", method: "DELETE", url: "http://localhost/v2/orders", wantErr: true},
+		{name: "body ignored", method: "POST", url: "http://localhost/v2/orders", body: `{"qty":"2"}`, want: "order"},
+		{
+			name: "body mismatch", opts: Options{Strictness: MatchBody},
+			method: "POST", url: "http://localhost/v2/orders", body: `{"qty":"2"}`, wantErr: true,
+		},
+		{
+			name: "body match", opts: Options{Strictness: MatchBody},
+			method: "POST", url: "http://localhost/v2/orders", body: `{"qty":"1"}`, want: "order",
+		},
+		{
+			name: "path only", opts: Options{Strictness: MatchPath},
+			method: "GET", url: "http://localhost/v2/bars?symbols=TSLA", want: "first",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, err := New(path, tt.opts)
+			require.NoError(t, err)
+			got, err := get(t, rec.HTTPClient(), tt.method, tt.url, tt.body)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrNoInteraction), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// with MatchPath the interactions of an endpoint are served in the recorded order
+	rec, err := New(path, Options{Strictness: MatchPath})
+	require.NoError(t, err)
+	for _, want := range []string{"first", "second"} {
+		got, err := get(t, rec.HTTPClient(), "GET", "http://localhost/v2/bars", "")
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestBinaryBody(t *testing.T) {
+	body := Body{0xff, 0x00, 0xfe}
+	b, err := body.MarshalJSON()
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "base64")
+	var decoded Body
+	require.NoError(t, decoded.UnmarshalJSON(b))
+	assert.Equal(t, body, decoded)
+}
+
+func TestMissingCassette(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.json"), Options{})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
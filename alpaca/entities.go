@@ -74,7 +74,7 @@ type Order struct {
 	Type           OrderType        `json:"type"`
 	Side           Side             `json:"side"`
 	TimeInForce    TimeInForce      `json:"time_in_force"`
-	Status         string           `json:"status"`
+	Status         OrderStatus      `json:"status"`
 	Notional       *decimal.Decimal `json:"notional"`
 	Qty            *decimal.Decimal `json:"qty"`
 	FilledQty      decimal.Decimal  `json:"filled_qty"`
@@ -85,6 +85,8 @@ type Order struct {
 	TrailPercent   *decimal.Decimal `json:"trail_percent"`
 	HWM            *decimal.Decimal `json:"hwm"`
 	ExtendedHours  bool             `json:"extended_hours"`
+	PositionIntent PositionIntent   `json:"position_intent"`
+	RatioQty       *decimal.Decimal `json:"ratio_qty"`
 	Legs           []Order          `json:"legs"`
 }
 
@@ -134,6 +136,58 @@ type Asset struct {
 //easyjson:json
 type assetSlice []Asset
 
+type OptionContract struct {
+	ID                string              `json:"id"`
+	Symbol            string              `json:"symbol"`
+	Name              string              `json:"name"`
+	Status            AssetStatus         `json:"status"`
+	Tradable          bool                `json:"tradable"`
+	ExpirationDate    civil.Date          `json:"expiration_date"`
+	RootSymbol        string              `json:"root_symbol"`
+	UnderlyingSymbol  string              `json:"underlying_symbol"`
+	UnderlyingAssetID string              `json:"underlying_asset_id"`
+	Type              OptionType          `json:"type"`
+	Style             OptionStyle         `json:"style"`
+	StrikePrice       decimal.Decimal     `json:"strike_price"`
+	Multiplier        decimal.Decimal     `json:"multiplier"`
+	Size              decimal.Decimal     `json:"size"`
+	OpenInterest      *decimal.Decimal    `json:"open_interest"`
+	OpenInterestDate  *civil.Date         `json:"open_interest_date"`
+	ClosePrice        *decimal.Decimal    `json:"close_price"`
+	ClosePriceDate    *civil.Date         `json:"close_price_date"`
+	Deliverables      []OptionDeliverable `json:"deliverables"`
+}
+
+type OptionDeliverable struct {
+	Type                 string          `json:"type"`
+	Symbol               string          `json:"symbol"`
+	AssetID              string          `json:"asset_id"`
+	Amount               decimal.Decimal `json:"amount"`
+	AllocationPercentage decimal.Decimal `json:"allocation_percentage"`
+	SettlementType       string          `json:"settlement_type"`
+	SettlementMethod     string          `json:"settlement_method"`
+	DelayedSettlement    bool            `json:"delayed_settlement"`
+}
+
+type optionContractsResponse struct {
+	OptionContracts []OptionContract `json:"option_contracts"`
+	NextPageToken   *string          `json:"next_page_token"`
+}
+
+type OptionType string
+
+const (
+	Call OptionType = "call"
+	Put  OptionType = "put"
+)
+
+type OptionStyle string
+
+const (
+	American OptionStyle = "american"
+	European OptionStyle = "european"
+)
+
 type AssetStatus string
 
 const (
@@ -145,6 +199,7 @@ type AssetClass string
 
 const (
 	USEquity AssetClass = "us_equity"
+	USOption AssetClass = "us_option"
 	Crypto   AssetClass = "crypto"
 )
 
@@ -152,6 +207,10 @@ type CalendarDay struct {
 	Date  string `json:"date"`
 	Open  string `json:"open"`
 	Close string `json:"close"`
+	// SessionOpen and SessionClose are the start and end of the extended hours, e.g. 0400 and 2000.
+	SessionOpen    string `json:"session_open"`
+	SessionClose   string `json:"session_close"`
+	SettlementDate string `json:"settlement_date"`
 }
 
 //easyjson:json
@@ -172,35 +231,78 @@ type AccountConfigurations struct {
 }
 
 type AccountActivity struct {
-	ID              string          `json:"id"`
-	ActivityType    string          `json:"activity_type"`
-	TransactionTime time.Time       `json:"transaction_time"`
-	Type            string          `json:"type"`
-	Price           decimal.Decimal `json:"price"`
-	Qty             decimal.Decimal `json:"qty"`
-	Side            string          `json:"side"`
-	Symbol          string          `json:"symbol"`
-	LeavesQty       decimal.Decimal `json:"leaves_qty"`
-	CumQty          decimal.Decimal `json:"cum_qty"`
-	Date            civil.Date      `json:"date"`
-	NetAmount       decimal.Decimal `json:"net_amount"`
-	Description     string          `json:"description"`
-	PerShareAmount  decimal.Decimal `json:"per_share_amount"`
-	OrderID         string          `json:"order_id"`
-	OrderStatus     string          `json:"order_status"`
-	Status          string          `json:"status"`
+	ID              string           `json:"id"`
+	ActivityType    ActivityType     `json:"activity_type"`
+	ActivitySubType string           `json:"activity_sub_type"`
+	TransactionTime time.Time        `json:"transaction_time"`
+	Type            FillType         `json:"type"`
+	Price           decimal.Decimal  `json:"price"`
+	Qty             decimal.Decimal  `json:"qty"`
+	Side            Side             `json:"side"`
+	Symbol          string           `json:"symbol"`
+	CUSIP           string           `json:"cusip"`
+	LeavesQty       decimal.Decimal  `json:"leaves_qty"`
+	CumQty          decimal.Decimal  `json:"cum_qty"`
+	Date            civil.Date       `json:"date"`
+	NetAmount       decimal.Decimal  `json:"net_amount"`
+	Description     string           `json:"description"`
+	PerShareAmount  decimal.Decimal  `json:"per_share_amount"`
+	OrderID         string           `json:"order_id"`
+	OrderStatus     OrderStatus      `json:"order_status"`
+	Status          string           `json:"status"`
+	GroupID         string           `json:"group_id"`
+	CreatedAt       *time.Time       `json:"created_at"`
+	SwapRate        *decimal.Decimal `json:"swap_rate"`
 }
 
 //easyjson:json
 type accountSlice []AccountActivity
 
+// TradeActivity is an execution (FILL) account activity.
+type TradeActivity struct {
+	ID              string
+	ActivityType    ActivityType
+	TransactionTime time.Time
+	Type            FillType
+	Price           decimal.Decimal
+	Qty             decimal.Decimal
+	Side            Side
+	Symbol          string
+	LeavesQty       decimal.Decimal
+	CumQty          decimal.Decimal
+	OrderID         string
+	OrderStatus     OrderStatus
+	SwapRate        *decimal.Decimal
+}
+
+// NonTradeActivity is any account activity other than an execution,
+// e.g. a dividend, a fee or a cash transfer.
+type NonTradeActivity struct {
+	ID              string
+	ActivityType    ActivityType
+	ActivitySubType string
+	Date            civil.Date
+	NetAmount       decimal.Decimal
+	Description     string
+	Symbol          string
+	CUSIP           string
+	Qty             decimal.Decimal
+	PerShareAmount  decimal.Decimal
+	Status          string
+	GroupID         string
+	CreatedAt       *time.Time
+}
+
 type PortfolioHistory struct {
 	BaseValue     decimal.Decimal   `json:"base_value"`
+	BaseValueAsOf *civil.Date       `json:"base_value_asof"`
 	Equity        []decimal.Decimal `json:"equity"`
 	ProfitLoss    []decimal.Decimal `json:"profit_loss"`
 	ProfitLossPct []decimal.Decimal `json:"profit_loss_pct"`
 	Timeframe     TimeFrame         `json:"timeframe"`
 	Timestamp     []int64           `json:"timestamp"`
+	// Cashflow holds the cash flows of the requested activity types, parallel to Timestamp.
+	Cashflow map[ActivityType][]decimal.Decimal `json:"cashflow"`
 }
 
 type Side string
@@ -236,6 +338,30 @@ const (
 	OTO     OrderClass = "oto"
 	OCO     OrderClass = "oco"
 	Simple  OrderClass = "simple"
+	// MLeg is a multi-leg options order, e.g. a vertical spread or an iron condor.
+	MLeg OrderClass = "mleg"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusNew                OrderStatus = "new"
+	OrderStatusPartiallyFilled    OrderStatus = "partially_filled"
+	OrderStatusFilled             OrderStatus = "filled"
+	OrderStatusDoneForDay         OrderStatus = "done_for_day"
+	OrderStatusCanceled           OrderStatus = "canceled"
+	OrderStatusExpired            OrderStatus = "expired"
+	OrderStatusReplaced           OrderStatus = "replaced"
+	OrderStatusPendingCancel      OrderStatus = "pending_cancel"
+	OrderStatusPendingReplace     OrderStatus = "pending_replace"
+	OrderStatusAccepted           OrderStatus = "accepted"
+	OrderStatusPendingNew         OrderStatus = "pending_new"
+	OrderStatusAcceptedForBidding OrderStatus = "accepted_for_bidding"
+	OrderStatusStopped            OrderStatus = "stopped"
+	OrderStatusRejected           OrderStatus = "rejected"
+	OrderStatusSuspended          OrderStatus = "suspended"
+	OrderStatusCalculated         OrderStatus = "calculated"
+	OrderStatusHeld               OrderStatus = "held"
 )
 
 type TimeInForce string
@@ -280,7 +406,7 @@ const (
 
 type TradeUpdate struct {
 	At          time.Time        `json:"at"`
-	Event       string           `json:"event"`
+	Event       TradeEvent       `json:"event"`
 	EventID     string           `json:"event_id"`
 	ExecutionID string           `json:"execution_id"`
 	Order       Order            `json:"order"`
@@ -290,6 +416,93 @@ type TradeUpdate struct {
 	Timestamp   *time.Time       `json:"timestamp"`
 }
 
+type ActivityType string
+
+const (
+	ActivityFill                     ActivityType = "FILL"
+	ActivityTransaction              ActivityType = "TRANS"
+	ActivityMisc                     ActivityType = "MISC"
+	ActivityACATCash                 ActivityType = "ACATC"
+	ActivityACATSecurities           ActivityType = "ACATS"
+	ActivityCryptoFee                ActivityType = "CFEE"
+	ActivityCashDeposit              ActivityType = "CSD"
+	ActivityCashWithdrawal           ActivityType = "CSW"
+	ActivityDividend                 ActivityType = "DIV"
+	ActivityDividendCapitalGainLong  ActivityType = "DIVCGL"
+	ActivityDividendCapitalGainShort ActivityType = "DIVCGS"
+	ActivityDividendFee              ActivityType = "DIVFEE"
+	ActivityDividendForeignTax       ActivityType = "DIVFT"
+	ActivityDividendNRAWithheld      ActivityType = "DIVNRA"
+	ActivityDividendReturnOfCapital  ActivityType = "DIVROC"
+	ActivityDividendTefraWithheld    ActivityType = "DIVTW"
+	ActivityDividendTaxExempt        ActivityType = "DIVTXEX"
+	ActivityFee                      ActivityType = "FEE"
+	ActivityInterest                 ActivityType = "INT"
+	ActivityInterestNRAWithheld      ActivityType = "INTNRA"
+	ActivityInterestTefraWithheld    ActivityType = "INTTW"
+	ActivityJournal                  ActivityType = "JNL"
+	ActivityJournalCash              ActivityType = "JNLC"
+	ActivityJournalStock             ActivityType = "JNLS"
+	ActivityMerger                   ActivityType = "MA"
+	ActivityNameChange               ActivityType = "NC"
+	ActivityOptionAssignment         ActivityType = "OPASN"
+	ActivityOptionCorporateAction    ActivityType = "OPCA"
+	ActivityOptionCashDelivery       ActivityType = "OPCSH"
+	ActivityOptionExercise           ActivityType = "OPEXC"
+	ActivityOptionExpiration         ActivityType = "OPEXP"
+	ActivityOptionTrade              ActivityType = "OPTRD"
+	ActivityPassThroughCharge        ActivityType = "PTC"
+	ActivityPassThroughRebate        ActivityType = "PTR"
+	ActivityReorg                    ActivityType = "REORG"
+	ActivitySymbolChange             ActivityType = "SC"
+	ActivityStockSpinoff             ActivityType = "SSO"
+	ActivityStockSplit               ActivityType = "SSP"
+)
+
+type FillType string
+
+const (
+	FillTypeFill        FillType = "fill"
+	FillTypePartialFill FillType = "partial_fill"
+)
+
+type TradeEvent string
+
+const (
+	TradeEventNew                  TradeEvent = "new"
+	TradeEventFill                 TradeEvent = "fill"
+	TradeEventPartialFill          TradeEvent = "partial_fill"
+	TradeEventCanceled             TradeEvent = "canceled"
+	TradeEventExpired              TradeEvent = "expired"
+	TradeEventDoneForDay           TradeEvent = "done_for_day"
+	TradeEventReplaced             TradeEvent = "replaced"
+	TradeEventAccepted             TradeEvent = "accepted"
+	TradeEventRejected             TradeEvent = "rejected"
+	TradeEventPendingNew           TradeEvent = "pending_new"
+	TradeEventStopped              TradeEvent = "stopped"
+	TradeEventPendingCancel        TradeEvent = "pending_cancel"
+	TradeEventPendingReplace       TradeEvent = "pending_replace"
+	TradeEventCalculated           TradeEvent = "calculated"
+	TradeEventSuspended            TradeEvent = "suspended"
+	TradeEventOrderReplaceRejected TradeEvent = "order_replace_rejected"
+	TradeEventOrderCancelRejected  TradeEvent = "order_cancel_rejected"
+)
+
+type IntradayReporting string
+
+const (
+	IntradayReportingMarketHours   IntradayReporting = "market_hours"
+	IntradayReportingExtendedHours IntradayReporting = "extended_hours"
+	IntradayReportingContinuous    IntradayReporting = "continuous"
+)
+
+type PnLReset string
+
+const (
+	PnLResetPerDay  PnLReset = "per_day"
+	PnLResetNoReset PnLReset = "no_reset"
+)
+
 type DateType string
 
 const (
@@ -351,34 +564,58 @@ type RemoveSymbolFromWatchlistRequest struct {
 }
 
 // APIError wraps the detailed code and message supplied
-// by Alpaca's API for debugging purposes
+// by Alpaca's API for debugging purposes. Common failures can be
+// matched with errors.Is, e.g. errors.Is(err, ErrInsufficientBuyingPower).
 type APIError struct {
 	StatusCode int    `json:"-"`
 	Code       int    `json:"code"`
 	Message    string `json:"message"`
 	Body       string `json:"-"`
+	// RequestID is the X-Request-ID header of the response. Include it when contacting support.
+	RequestID string `json:"-"`
+	// Method and Path identify the request that failed.
+	Method string `json:"-"`
+	Path   string `json:"-"`
 }
 
+// APIErrorFromResponse reads the error of a failed response. It always returns an *APIError,
+// unless reading the body fails. If the body is not in the JSON format of the API, it is used
+// as the message.
 func APIErrorFromResponse(resp *http.Response) error {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return err
 	}
-	var apiErr APIError
+	apiErr := APIError{
+		StatusCode: resp.StatusCode,
+		Body:       strings.TrimSpace(string(body)),
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			apiErr.Path = resp.Request.URL.Path
+		}
+	}
 	if err := json.Unmarshal(body, &apiErr); err != nil {
-		// If the error is not in our JSON format, we simply return the HTTP response
-		return fmt.Errorf("%s (HTTP %d)", body, resp.StatusCode)
+		// If the error is not in our JSON format, the body is the message
+		apiErr.Code, apiErr.Message = 0, apiErr.Body
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
 	}
-	apiErr.StatusCode = resp.StatusCode
-	apiErr.Body = strings.TrimSpace(string(body))
 	return &apiErr
 }
 
 func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s (HTTP %d", e.Message, e.StatusCode)
 	if e.Code != 0 {
-		return fmt.Sprintf("%s (HTTP %d, Code %d)", e.Message, e.StatusCode, e.Code)
+		msg += fmt.Sprintf(", Code %d", e.Code)
+	}
+	if e.RequestID != "" {
+		msg += ", Request ID " + e.RequestID
 	}
-	return fmt.Sprintf("%s (HTTP %d)", e.Message, e.StatusCode)
+	return msg + ")"
 }
 
 //easyjson:json
@@ -389,3 +626,12 @@ type closeAllPositionsResponse struct {
 	Status int             `json:"status"`
 	Body   json.RawMessage `json:"body,omitempty"`
 }
+
+//easyjson:json
+type cancelAllOrdersSlice []cancelAllOrdersResponse
+
+type cancelAllOrdersResponse struct {
+	ID     string          `json:"id"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
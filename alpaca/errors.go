@@ -0,0 +1,78 @@
+package alpaca
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors of common API failures. An *APIError matches them with errors.Is,
+// based on its status code, message and the request that failed:
+//
+//	order, err := client.PlaceOrder(req)
+//	if errors.Is(err, alpaca.ErrInsufficientBuyingPower) {
+//		...
+//	}
+//
+// errors.As can still be used to get the *APIError itself.
+var (
+	ErrUnauthorized            = errors.New("unauthorized")
+	ErrNotFound                = errors.New("not found")
+	ErrRateLimited             = errors.New("rate limited")
+	ErrInsufficientBuyingPower = errors.New("insufficient buying power")
+	ErrInsufficientQty         = errors.New("insufficient qty")
+	ErrPatternDayTrader        = errors.New("pattern day trading protection")
+	ErrMarketClosed            = errors.New("market closed")
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrOrderNotCancelable      = errors.New("order not cancelable")
+	ErrWashTrade               = errors.New("potential wash trade")
+	ErrForbiddenSymbol         = errors.New("symbol not tradable")
+)
+
+// Is reports whether the error is one of the sentinel errors above.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrInsufficientBuyingPower:
+		return e.messageContains("insufficient buying power", "insufficient day trading buying power")
+	case ErrInsufficientQty:
+		return e.messageContains("insufficient qty")
+	case ErrPatternDayTrader:
+		return e.messageContains("pattern day trad")
+	case ErrMarketClosed:
+		return e.messageContains("market is closed", "market closed", "only allowed during market hours")
+	case ErrOrderNotFound:
+		return e.messageContains("order not found") ||
+			e.StatusCode == http.StatusNotFound && e.ordersPath()
+	case ErrOrderNotCancelable:
+		return e.messageContains("not cancelable", "not cancellable") ||
+			e.StatusCode == http.StatusUnprocessableEntity && e.Method == http.MethodDelete && e.ordersPath()
+	case ErrWashTrade:
+		return e.messageContains("wash trade")
+	case ErrForbiddenSymbol:
+		return e.messageContains("not tradable", "cannot be traded", "forbidden symbol", "symbol is restricted")
+	}
+	return false
+}
+
+func (e *APIError) messageContains(substrings ...string) bool {
+	msg := strings.ToLower(e.Message)
+	for _, s := range substrings {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ordersPath tells whether the failed request was about a single order,
+// e.g. GET /v2/orders/{id} or DELETE /v2/orders/{id}.
+func (e *APIError) ordersPath() bool {
+	_, id, ok := strings.Cut(e.Path, "/orders/")
+	return ok && id != ""
+}
@@ -0,0 +1,120 @@
+package alpaca
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIErrorFromResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-123")
+		switch r.URL.Path {
+		case "/v2/orders":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"code":40310000,"message":"insufficient buying power"}`)
+		case "/v2/account":
+			http.Error(w, "upstream timed out", http.StatusGatewayTimeout)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+	c := NewClient(ClientOpts{BaseURL: ts.URL})
+
+	_, err := c.PlaceOrder(PlaceOrderRequest{Symbol: "AAPL"})
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.Equal(t, 40310000, apiErr.Code)
+	assert.Equal(t, "req-123", apiErr.RequestID)
+	assert.Equal(t, http.MethodPost, apiErr.Method)
+	assert.Equal(t, "/v2/orders", apiErr.Path)
+	assert.Equal(t, "insufficient buying power (HTTP 403, Code 40310000, Request ID req-123)", err.Error())
+	assert.ErrorIs(t, err, ErrInsufficientBuyingPower)
+	assert.NotErrorIs(t, err, ErrInsufficientQty)
+
+	// Bodies that are not JSON are kept as the message.
+	_, err = c.GetAccount()
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusGatewayTimeout, apiErr.StatusCode)
+	assert.Equal(t, "upstream timed out", apiErr.Message)
+	assert.Equal(t, http.MethodGet, apiErr.Method)
+
+	// Empty bodies get the status text.
+	_, err = c.GetOrder("abc")
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "Not Found", apiErr.Message)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    APIError
+		target error
+	}{
+		{"unauthorized", APIError{StatusCode: 401, Message: "request is not authorized"}, ErrUnauthorized},
+		{"rate limited", APIError{StatusCode: 429, Message: "rate limit exceeded"}, ErrRateLimited},
+		{
+			"buying power",
+			APIError{StatusCode: 403, Code: 40310000, Message: "insufficient day trading buying power"},
+			ErrInsufficientBuyingPower,
+		},
+		{
+			"qty",
+			APIError{StatusCode: 403, Code: 40310000, Message: "insufficient qty available for order (requested: 10, available: 0)"},
+			ErrInsufficientQty,
+		},
+		{
+			"pattern day trader",
+			APIError{StatusCode: 403, Code: 40310100, Message: "trade denied due to pattern day trading protection"},
+			ErrPatternDayTrader,
+		},
+		{
+			"market closed",
+			APIError{StatusCode: 422, Message: "options market orders are only allowed during market hours"},
+			ErrMarketClosed,
+		},
+		{"order not found", APIError{StatusCode: 422, Message: "order not found for abc"}, ErrOrderNotFound},
+		{"not cancelable", APIError{StatusCode: 422, Message: "order is not cancelable"}, ErrOrderNotCancelable},
+		{
+			"not cancelable by request",
+			APIError{StatusCode: 422, Message: `order is already in "filled" state`, Method: http.MethodDelete, Path: "/v2/orders/abc"},
+			ErrOrderNotCancelable,
+		},
+		{
+			"wash trade",
+			APIError{StatusCode: 403, Code: 40310000, Message: "potential wash trade detected. use complex orders"},
+			ErrWashTrade,
+		},
+		{"forbidden symbol", APIError{StatusCode: 422, Message: `asset "XYZ" is not tradable`}, ErrForbiddenSymbol},
+	}
+	sentinels := []error{
+		ErrUnauthorized, ErrNotFound, ErrRateLimited, ErrInsufficientBuyingPower, ErrInsufficientQty,
+		ErrPatternDayTrader, ErrMarketClosed, ErrOrderNotFound, ErrOrderNotCancelable, ErrWashTrade,
+		ErrForbiddenSymbol,
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &tt.err)
+			for _, sentinel := range sentinels {
+				assert.Equal(t, sentinel == tt.target, errors.Is(err, sentinel), sentinel.Error())
+			}
+		})
+	}
+
+	// A 404 is ErrOrderNotFound only if the request was about an order.
+	err := &APIError{StatusCode: 404, Message: "resource not found", Method: http.MethodGet, Path: "/v2/orders/abc"}
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+	err = &APIError{StatusCode: 404, Message: "asset not found", Method: http.MethodGet, Path: "/v2/assets/XYZ"}
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrOrderNotFound)
+}
@@ -0,0 +1,139 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// FlattenRequest configures how Flatten retries the cancels and closes that failed.
+type FlattenRequest struct {
+	// MaxRetries is the number of times failed cancels and closes are retried. Defaults to 3,
+	// set it to a negative value to disable the retries.
+	MaxRetries int
+	// RetryDelay is the wait before each retry of the failures. Defaults to one second.
+	RetryDelay time.Duration
+}
+
+// FlattenResult holds the final outcome of every cancel and close attempted by Flatten.
+type FlattenResult struct {
+	CanceledOrders  []CancelOrderResult
+	ClosedPositions []ClosePositionResult
+}
+
+// Flatten cancels all open orders, then liquidates all positions at market price.
+// Only the orders and positions that failed are retried, individually, up to req.MaxRetries times.
+// The returned error joins the failures that remained after the last retry.
+func (c *Client) Flatten(req FlattenRequest) (*FlattenResult, error) {
+	return c.FlattenWithContext(context.Background(), req)
+}
+
+// FlattenWithContext cancels all open orders, then liquidates all positions at market price.
+// Only the orders and positions that failed are retried, individually, up to req.MaxRetries times.
+// The returned error joins the failures that remained after the last retry.
+func (c *Client) FlattenWithContext(ctx context.Context, req FlattenRequest) (*FlattenResult, error) {
+	if req.MaxRetries == 0 {
+		req.MaxRetries = 3
+	}
+	if req.RetryDelay == 0 {
+		req.RetryDelay = time.Second
+	}
+
+	cancels, err := c.CancelAllOrdersResultsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.retryFailedCancels(ctx, req, cancels); err != nil {
+		return nil, err
+	}
+
+	// Canceling the orders again catches the ones placed since the previous step.
+	closes, err := c.CloseAllPositionsResultsWithContext(ctx, CloseAllPositionsRequest{CancelOrders: true})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.retryFailedCloses(ctx, req, closes); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, r := range cancels {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("cancel order %s: %w", r.OrderID, r.Err))
+		}
+	}
+	for _, r := range closes {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("close position %s: %w", r.Symbol, r.Err))
+		}
+	}
+	return &FlattenResult{CanceledOrders: cancels, ClosedPositions: closes}, errors.Join(errs...)
+}
+
+// retryFailedCancels retries the failed entries of results in place.
+// It only returns an error if ctx is done.
+func (c *Client) retryFailedCancels(ctx context.Context, req FlattenRequest, results []CancelOrderResult) error {
+	for retry := 0; retry < req.MaxRetries; retry++ {
+		failed := false
+		for _, r := range results {
+			failed = failed || r.Err != nil
+		}
+		if !failed {
+			return nil
+		}
+		if err := sleepContext(ctx, req.RetryDelay); err != nil {
+			return err
+		}
+		for i := range results {
+			if results[i].Err == nil {
+				continue
+			}
+			err := c.CancelOrderWithContext(ctx, results[i].OrderID)
+			results[i].StatusCode = resultStatusCode(err, http.StatusNoContent)
+			results[i].Err = err
+		}
+	}
+	return nil
+}
+
+// retryFailedCloses retries the failed entries of results in place.
+// It only returns an error if ctx is done.
+func (c *Client) retryFailedCloses(ctx context.Context, req FlattenRequest, results []ClosePositionResult) error {
+	for retry := 0; retry < req.MaxRetries; retry++ {
+		failed := false
+		for _, r := range results {
+			failed = failed || r.Err != nil
+		}
+		if !failed {
+			return nil
+		}
+		if err := sleepContext(ctx, req.RetryDelay); err != nil {
+			return err
+		}
+		for i := range results {
+			if results[i].Err == nil {
+				continue
+			}
+			order, err := c.ClosePositionWithContext(ctx, results[i].Symbol, ClosePositionRequest{})
+			results[i].StatusCode = resultStatusCode(err, http.StatusOK)
+			results[i].Order = order
+			results[i].Err = err
+		}
+	}
+	return nil
+}
+
+// resultStatusCode returns the HTTP status behind err, or success if err is nil.
+// It returns 0 if the request failed without a response.
+func resultStatusCode(err error, success int) int {
+	if err == nil {
+		return success
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
@@ -0,0 +1,97 @@
+package alpaca
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlatten(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	var requests []string
+	cancelAttempts, closeAttempts := 0, 0
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch req.URL.Path {
+		case "/v2/orders":
+			return &http.Response{Body: io.NopCloser(strings.NewReader(`[
+				{"id": "order1", "status": 200},
+				{"id": "order2", "status": 500}
+			]`))}, nil
+		case "/v2/orders/order2":
+			cancelAttempts++
+			return &http.Response{Body: io.NopCloser(strings.NewReader(""))}, nil
+		case "/v2/positions":
+			assert.Equal(t, "true", req.URL.Query().Get("cancel_orders"))
+			return &http.Response{Body: io.NopCloser(strings.NewReader(`[
+				{"symbol": "AAPL", "status": 200, "body": {"id": "close1", "symbol": "AAPL"}},
+				{"symbol": "TSLA", "status": 500, "body": {"code": 50010000, "message": "internal error"}},
+				{"symbol": "SPY", "status": 403, "body": {"code": 40310000, "message": "position is locked"}}
+			]`))}, nil
+		case "/v2/positions/TSLA":
+			closeAttempts++
+			if closeAttempts == 1 {
+				return nil, &APIError{StatusCode: http.StatusServiceUnavailable, Message: "unavailable"}
+			}
+			return &http.Response{Body: genBody(Order{ID: "close2", Symbol: "TSLA"})}, nil
+		case "/v2/positions/SPY":
+			return nil, &APIError{StatusCode: http.StatusForbidden, Code: 40310000, Message: "position is locked"}
+		}
+		return nil, &APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+	}
+
+	result, err := c.Flatten(FlattenRequest{MaxRetries: 2, RetryDelay: time.Nanosecond})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "close position SPY")
+	assert.NotContains(t, err.Error(), "TSLA")
+	assert.Equal(t, 1, cancelAttempts)
+	assert.Equal(t, 2, closeAttempts)
+	assert.NotContains(t, requests, "DELETE /v2/orders/order1", "successful cancels must not be retried")
+	assert.NotContains(t, requests, "DELETE /v2/positions/AAPL", "successful closes must not be retried")
+
+	require.Len(t, result.CanceledOrders, 2)
+	assert.NoError(t, result.CanceledOrders[1].Err)
+	assert.Equal(t, http.StatusNoContent, result.CanceledOrders[1].StatusCode)
+	require.Len(t, result.ClosedPositions, 3)
+	assert.Equal(t, "close1", result.ClosedPositions[0].Order.ID)
+	assert.NoError(t, result.ClosedPositions[1].Err)
+	assert.Equal(t, "close2", result.ClosedPositions[1].Order.ID)
+	assert.Error(t, result.ClosedPositions[2].Err)
+	assert.Equal(t, http.StatusForbidden, result.ClosedPositions[2].StatusCode)
+}
+
+func TestFlatten_ContextCancelled(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		return &http.Response{Body: io.NopCloser(strings.NewReader(`[{"id": "order1", "status": 500}]`))}, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := c.FlattenWithContext(ctx, FlattenRequest{RetryDelay: time.Hour})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFlatten_NoRetries(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	var requests []string
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		requests = append(requests, req.Method+" "+req.URL.Path)
+		switch req.URL.Path {
+		case "/v2/orders":
+			return &http.Response{Body: io.NopCloser(strings.NewReader(`[{"id": "order1", "status": 500}]`))}, nil
+		case "/v2/positions":
+			return &http.Response{Body: io.NopCloser(strings.NewReader(`[]`))}, nil
+		}
+		return nil, &APIError{StatusCode: http.StatusNotFound, Message: "not found"}
+	}
+	_, err := c.Flatten(FlattenRequest{MaxRetries: -1, RetryDelay: time.Hour})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cancel order order1")
+	assert.Equal(t, []string{"DELETE /v2/orders", "DELETE /v2/positions"}, requests)
+}
@@ -0,0 +1,93 @@
+package alpaca
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// placeOrderIdempotent submits req and recovers from ambiguous failures by looking
+// the order up by its client order ID instead of blindly resubmitting it.
+func (c *Client) placeOrderIdempotent(ctx context.Context, req PlaceOrderRequest) (*Order, error) {
+	if req.ClientOrderID == "" {
+		id, err := newClientOrderID()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate client order id: %w", err)
+		}
+		req.ClientOrderID = id
+	}
+	for attempt := 0; ; attempt++ {
+		order, err := c.placeOrder(ctx, req)
+		if err == nil {
+			return order, nil
+		}
+		// A resubmission rejected as a duplicate means an earlier submission did go through,
+		// it was just not visible yet when it was looked up.
+		duplicate := attempt > 0 && isDuplicateClientOrderIDError(err)
+		if !duplicate && !isAmbiguousOrderError(ctx, err) {
+			return order, err
+		}
+		existing, lookupErr := c.GetOrderByClientOrderIDWithContext(ctx, req.ClientOrderID)
+		if lookupErr == nil {
+			return existing, nil
+		}
+		var apiErr *APIError
+		if !errors.As(lookupErr, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+			return nil, fmt.Errorf("order %s may have been submitted, lookup failed: %w",
+				req.ClientOrderID, errors.Join(err, lookupErr))
+		}
+		if attempt >= c.opts.RetryLimit {
+			return nil, err
+		}
+		// An earlier submission may still be in flight, give it time to show up before resubmitting.
+		if err := sleepContext(ctx, c.resubmitDelay(attempt+1)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// resubmitDelay returns the delay before the nth resubmission of an order, following the
+// backoff of the retry policy.
+func (c *Client) resubmitDelay(n int) time.Duration {
+	if p, ok := c.opts.RetryPolicy.(*BackoffRetryPolicy); ok {
+		return p.backoff(n)
+	}
+	return c.opts.RetryDelay
+}
+
+// isDuplicateClientOrderIDError tells whether err is the rejection of an order whose
+// client order ID is already used.
+func isDuplicateClientOrderIDError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnprocessableEntity &&
+		apiErr.messageContains("client_order_id must be unique")
+}
+
+// isAmbiguousOrderError tells whether the order might have reached the broker despite err.
+// Client errors are definitive rejections, everything else (network errors, timeouts,
+// server errors, truncated responses) leaves the outcome of the submission unknown.
+func isAmbiguousOrderError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		// The order can not be looked up anymore.
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// newClientOrderID returns a random (version 4) UUID.
+func newClientOrderID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
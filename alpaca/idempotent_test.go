@@ -0,0 +1,163 @@
+package alpaca
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceOrder_IdempotentGeneratesClientOrderID(t *testing.T) {
+	c := NewClient(ClientOpts{IdempotentOrders: true})
+	var sent PlaceOrderRequest
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&sent))
+		return &http.Response{
+			Body: genBody(Order{ID: "order_id", ClientOrderID: sent.ClientOrderID}),
+		}, nil
+	}
+	qty := decimal.NewFromInt(1)
+	order, err := c.PlaceOrder(PlaceOrderRequest{Symbol: "AAPL", Qty: &qty, Side: Buy, Type: Market, TimeInForce: Day})
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), sent.ClientOrderID)
+	assert.Equal(t, sent.ClientOrderID, order.ClientOrderID)
+}
+
+func TestPlaceOrder_IdempotentLostResponse(t *testing.T) {
+	c := NewClient(ClientOpts{IdempotentOrders: true})
+	var posts, lookups int
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		switch req.Method {
+		case http.MethodPost:
+			posts++
+			// the order reaches the broker, but the response is lost
+			return nil, errors.New("connection reset by peer")
+		case http.MethodGet:
+			lookups++
+			assert.Equal(t, "/v2/orders:by_client_order_id", req.URL.Path)
+			assert.Equal(t, "my_id", req.URL.Query().Get("client_order_id"))
+			return &http.Response{
+				Body: genBody(Order{ID: "order_id", ClientOrderID: "my_id"}),
+			}, nil
+		}
+		return nil, errors.New("unexpected request")
+	}
+	qty := decimal.NewFromInt(1)
+	order, err := c.PlaceOrder(PlaceOrderRequest{
+		Symbol: "AAPL", Qty: &qty, Side: Buy, Type: Market, TimeInForce: Day, ClientOrderID: "my_id",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "order_id", order.ID)
+	assert.Equal(t, 1, posts, "the order must not be resubmitted")
+	assert.Equal(t, 1, lookups)
+}
+
+func TestPlaceOrder_IdempotentResubmitsWhenNotFound(t *testing.T) {
+	c := NewClient(ClientOpts{IdempotentOrders: true, RetryDelay: 20 * time.Millisecond})
+	var posts, lookups int
+	var clientOrderIDs []string
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		switch req.Method {
+		case http.MethodPost:
+			posts++
+			var por PlaceOrderRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&por))
+			clientOrderIDs = append(clientOrderIDs, por.ClientOrderID)
+			if posts == 1 {
+				// the request never reached the broker
+				return nil, &APIError{StatusCode: http.StatusBadGateway, Message: "bad gateway"}
+			}
+			return &http.Response{
+				Body: genBody(Order{ID: "order_id", ClientOrderID: por.ClientOrderID}),
+			}, nil
+		case http.MethodGet:
+			lookups++
+			return nil, &APIError{StatusCode: http.StatusNotFound, Message: "order not found"}
+		}
+		return nil, errors.New("unexpected request")
+	}
+	qty := decimal.NewFromInt(1)
+	start := time.Now()
+	order, err := c.PlaceOrder(PlaceOrderRequest{Symbol: "AAPL", Qty: &qty, Side: Buy, Type: Market, TimeInForce: Day})
+	require.NoError(t, err)
+	assert.Equal(t, "order_id", order.ID)
+	assert.Equal(t, 2, posts)
+	assert.Equal(t, 1, lookups)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond, "the resubmission must wait")
+	require.Len(t, clientOrderIDs, 2)
+	assert.Equal(t, clientOrderIDs[0], clientOrderIDs[1], "the resubmission must reuse the client order id")
+}
+
+func TestPlaceOrder_IdempotentDefinitiveRejection(t *testing.T) {
+	c := NewClient(ClientOpts{IdempotentOrders: true})
+	var posts, lookups int
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			lookups++
+		} else {
+			posts++
+		}
+		return nil, &APIError{StatusCode: http.StatusForbidden, Code: 40310000, Message: "insufficient buying power"}
+	}
+	qty := decimal.NewFromInt(1)
+	_, err := c.PlaceOrder(PlaceOrderRequest{Symbol: "AAPL", Qty: &qty, Side: Buy, Type: Market, TimeInForce: Day})
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.Equal(t, 1, posts)
+	assert.Equal(t, 0, lookups)
+}
+
+func TestPlaceOrder_IdempotentGivesUp(t *testing.T) {
+	c := NewClient(ClientOpts{IdempotentOrders: true, RetryLimit: 2, RetryDelay: time.Millisecond})
+	var posts int
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			return nil, &APIError{StatusCode: http.StatusNotFound, Message: "order not found"}
+		}
+		posts++
+		return nil, errors.New("i/o timeout")
+	}
+	qty := decimal.NewFromInt(1)
+	_, err := c.PlaceOrder(PlaceOrderRequest{Symbol: "AAPL", Qty: &qty, Side: Buy, Type: Market, TimeInForce: Day})
+	require.Error(t, err)
+	assert.Equal(t, 3, posts)
+}
+
+func TestPlaceOrder_IdempotentDuplicateResubmission(t *testing.T) {
+	c := NewClient(ClientOpts{IdempotentOrders: true, RetryDelay: time.Millisecond})
+	var posts, lookups int
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		switch req.Method {
+		case http.MethodPost:
+			posts++
+			if posts == 1 {
+				return nil, errors.New("i/o timeout")
+			}
+			// the first submission went through after all
+			return nil, &APIError{StatusCode: http.StatusUnprocessableEntity, Code: 40010001, Message: "client_order_id must be unique"}
+		case http.MethodGet:
+			lookups++
+			if lookups == 1 {
+				// the first submission is not visible yet
+				return nil, &APIError{StatusCode: http.StatusNotFound, Message: "order not found"}
+			}
+			return &http.Response{Body: genBody(Order{ID: "order_id", ClientOrderID: "my_id"})}, nil
+		}
+		return nil, errors.New("unexpected request")
+	}
+	qty := decimal.NewFromInt(1)
+	order, err := c.PlaceOrder(PlaceOrderRequest{
+		Symbol: "AAPL", Qty: &qty, Side: Buy, Type: Market, TimeInForce: Day, ClientOrderID: "my_id",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "order_id", order.ID)
+	assert.Equal(t, 2, posts)
+	assert.Equal(t, 2, lookups)
+}
@@ -0,0 +1,152 @@
+package alpaca
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidTransition is returned by OrderLifecycle when an order status change is not possible,
+// e.g. a filled order becoming canceled.
+var ErrInvalidTransition = errors.New("invalid order status transition")
+
+// IsTerminal returns true if the order reached a final status and will never change again.
+func (s OrderStatus) IsTerminal() bool {
+	switch s {
+	case OrderStatusFilled, OrderStatusCanceled, OrderStatusExpired, OrderStatusReplaced, OrderStatusRejected:
+		return true
+	}
+	return false
+}
+
+// IsOpen returns true if the order is still working or may still be working, including
+// orders waiting for a pending cancel or replace to be confirmed.
+func (s OrderStatus) IsOpen() bool {
+	return s != "" && !s.IsTerminal()
+}
+
+// CanCancel returns true if a cancel request can be sent for an order in this status.
+func (s OrderStatus) CanCancel() bool {
+	return s.IsOpen() && s != OrderStatusPendingCancel
+}
+
+// CanReplace returns true if a replace request can be sent for an order in this status.
+func (s OrderStatus) CanReplace() bool {
+	switch s {
+	case OrderStatusPendingNew, OrderStatusPendingCancel, OrderStatusPendingReplace:
+		return false
+	}
+	return s.IsOpen()
+}
+
+// phase orders the main line of the order lifecycle: pending_new, then accepted (or held),
+// then new, then partially_filled, then a terminal status. Statuses off the main line
+// (pending cancel/replace, done for the day, etc.) return -1, because the order may
+// return from them to where it was before.
+func (s OrderStatus) phase() int {
+	switch s {
+	case OrderStatusPendingNew:
+		return 0
+	case OrderStatusAccepted, OrderStatusAcceptedForBidding, OrderStatusHeld:
+		return 1
+	case OrderStatusNew:
+		return 2
+	case OrderStatusPartiallyFilled:
+		return 3
+	case OrderStatusFilled, OrderStatusCanceled, OrderStatusExpired, OrderStatusReplaced, OrderStatusRejected:
+		return 4
+	}
+	return -1
+}
+
+func (s OrderStatus) known() bool {
+	switch s {
+	case OrderStatusPendingCancel, OrderStatusPendingReplace, OrderStatusDoneForDay,
+		OrderStatusStopped, OrderStatusSuspended, OrderStatusCalculated:
+		return true
+	}
+	return s.phase() >= 0
+}
+
+// CanTransitionTo returns true if an order in status s can move to status next.
+//
+// Terminal statuses never change, no order ever returns to pending_new, and on the main
+// line of the lifecycle an order never moves backwards (e.g. from partially_filled to new).
+// Repeating the current status is always valid, so duplicate updates are accepted.
+// Statuses unknown to this package are accepted so that new server side statuses
+// do not break clients.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	switch {
+	case s == next:
+		return true
+	case s.IsTerminal():
+		return false
+	case next == OrderStatusPendingNew:
+		return false
+	case !s.known() || !next.known():
+		return true
+	}
+	from, to := s.phase(), next.phase()
+	if from < 0 || to < 0 {
+		return true
+	}
+	return to >= from
+}
+
+// OrderStatus returns the status an order has after receiving this event.
+// It returns an empty string for the order_cancel_rejected and order_replace_rejected
+// events: those leave the order in the status it had before the request.
+func (e TradeEvent) OrderStatus() OrderStatus {
+	switch e {
+	case TradeEventFill:
+		return OrderStatusFilled
+	case TradeEventPartialFill:
+		return OrderStatusPartiallyFilled
+	case TradeEventOrderCancelRejected, TradeEventOrderReplaceRejected:
+		return ""
+	}
+	return OrderStatus(e)
+}
+
+// OrderLifecycle tracks the status of a single order and rejects impossible status changes.
+// It can be fed both from orders returned by the REST API and from trade updates.
+type OrderLifecycle struct {
+	status OrderStatus
+}
+
+// NewOrderLifecycle creates a lifecycle for an order that is currently in the given status.
+func NewOrderLifecycle(status OrderStatus) *OrderLifecycle {
+	return &OrderLifecycle{status: status}
+}
+
+// Status returns the current status of the order.
+func (l *OrderLifecycle) Status() OrderStatus {
+	return l.status
+}
+
+// Transition moves the order to the next status, or returns an error wrapping
+// ErrInvalidTransition if it is not possible. The status is unchanged on error.
+func (l *OrderLifecycle) Transition(next OrderStatus) error {
+	if next == "" {
+		return nil
+	}
+	if l.status != "" && !l.status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, l.status, next)
+	}
+	l.status = next
+	return nil
+}
+
+// ApplyOrder moves the lifecycle to the status of an order, e.g. one returned by GetOrder.
+func (l *OrderLifecycle) ApplyOrder(order Order) error {
+	return l.Transition(order.Status)
+}
+
+// ApplyTradeUpdate moves the lifecycle to the status carried by a trade update.
+// The status of the order in the update takes precedence over the one implied by the event.
+func (l *OrderLifecycle) ApplyTradeUpdate(tu TradeUpdate) error {
+	next := tu.Order.Status
+	if next == "" {
+		next = tu.Event.OrderStatus()
+	}
+	return l.Transition(next)
+}
@@ -0,0 +1,102 @@
+package alpaca
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderStatusHelpers(t *testing.T) {
+	tests := []struct {
+		status                                OrderStatus
+		terminal, open, canCancel, canReplace bool
+	}{
+		{OrderStatusNew, false, true, true, true},
+		{OrderStatusPartiallyFilled, false, true, true, true},
+		{OrderStatusHeld, false, true, true, true},
+		{OrderStatusPendingNew, false, true, true, false},
+		{OrderStatusPendingCancel, false, true, false, false},
+		{OrderStatusPendingReplace, false, true, true, false},
+		{OrderStatusDoneForDay, false, true, true, true},
+		{OrderStatusFilled, true, false, false, false},
+		{OrderStatusCanceled, true, false, false, false},
+		{OrderStatusExpired, true, false, false, false},
+		{OrderStatusReplaced, true, false, false, false},
+		{OrderStatusRejected, true, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.terminal, tt.status.IsTerminal())
+			assert.Equal(t, tt.open, tt.status.IsOpen())
+			assert.Equal(t, tt.canCancel, tt.status.CanCancel())
+			assert.Equal(t, tt.canReplace, tt.status.CanReplace())
+		})
+	}
+}
+
+func TestOrderStatusCanTransitionTo(t *testing.T) {
+	valid := [][2]OrderStatus{
+		{OrderStatusPendingNew, OrderStatusAccepted},
+		{OrderStatusAccepted, OrderStatusNew},
+		{OrderStatusHeld, OrderStatusNew},
+		{OrderStatusNew, OrderStatusPartiallyFilled},
+		{OrderStatusNew, OrderStatusFilled},
+		{OrderStatusPartiallyFilled, OrderStatusPendingCancel},
+		{OrderStatusPendingCancel, OrderStatusPartiallyFilled},
+		{OrderStatusPendingCancel, OrderStatusFilled},
+		{OrderStatusPendingReplace, OrderStatusReplaced},
+		{OrderStatusPartiallyFilled, OrderStatusDoneForDay},
+		{OrderStatusDoneForDay, OrderStatusPartiallyFilled},
+		{OrderStatusFilled, OrderStatusFilled},
+		{OrderStatusNew, "some_future_status"},
+	}
+	for _, tr := range valid {
+		assert.True(t, tr[0].CanTransitionTo(tr[1]), "%s -> %s", tr[0], tr[1])
+	}
+	invalid := [][2]OrderStatus{
+		{OrderStatusFilled, OrderStatusCanceled},
+		{OrderStatusCanceled, OrderStatusNew},
+		{OrderStatusReplaced, OrderStatusPendingReplace},
+		{OrderStatusNew, OrderStatusPendingNew},
+		{OrderStatusNew, OrderStatusAccepted},
+		{OrderStatusPartiallyFilled, OrderStatusNew},
+	}
+	for _, tr := range invalid {
+		assert.False(t, tr[0].CanTransitionTo(tr[1]), "%s -> %s", tr[0], tr[1])
+	}
+}
+
+func TestTradeEventOrderStatus(t *testing.T) {
+	assert.Equal(t, OrderStatusFilled, TradeEventFill.OrderStatus())
+	assert.Equal(t, OrderStatusPartiallyFilled, TradeEventPartialFill.OrderStatus())
+	assert.Equal(t, OrderStatusCanceled, TradeEventCanceled.OrderStatus())
+	assert.Equal(t, OrderStatusPendingNew, TradeEventPendingNew.OrderStatus())
+	assert.Empty(t, TradeEventOrderCancelRejected.OrderStatus())
+	assert.Empty(t, TradeEventOrderReplaceRejected.OrderStatus())
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	l := NewOrderLifecycle(OrderStatusPendingNew)
+	require.NoError(t, l.ApplyTradeUpdate(TradeUpdate{Event: TradeEventNew}))
+	assert.Equal(t, OrderStatusNew, l.Status())
+	require.NoError(t, l.ApplyTradeUpdate(TradeUpdate{Event: TradeEventPartialFill}))
+	require.NoError(t, l.ApplyTradeUpdate(TradeUpdate{Event: TradeEventPendingCancel}))
+	// the cancel was rejected, the order is back to partially filled
+	require.NoError(t, l.ApplyTradeUpdate(TradeUpdate{
+		Event: TradeEventOrderCancelRejected,
+		Order: Order{Status: OrderStatusPartiallyFilled},
+	}))
+	assert.Equal(t, OrderStatusPartiallyFilled, l.Status())
+	require.NoError(t, l.ApplyOrder(Order{Status: OrderStatusFilled}))
+	assert.True(t, l.Status().IsTerminal())
+
+	err := l.ApplyTradeUpdate(TradeUpdate{Event: TradeEventCanceled})
+	require.ErrorIs(t, err, ErrInvalidTransition)
+	assert.Contains(t, err.Error(), "filled -> canceled")
+	assert.Equal(t, OrderStatusFilled, l.Status())
+
+	// events without a status change are ignored
+	require.NoError(t, l.ApplyTradeUpdate(TradeUpdate{Event: TradeEventOrderReplaceRejected}))
+	assert.Equal(t, OrderStatusFilled, l.Status())
+}
@@ -0,0 +1,76 @@
+package alpaca
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrInvalidLegs is returned when a multi-leg order is rejected locally, before it is submitted.
+var ErrInvalidLegs = errors.New("invalid multi-leg order")
+
+const maxLegs = 4
+
+// validateLegs checks the rules of multi-leg options orders: 2 to 4 legs on distinct contracts,
+// positive integer ratios in their simplest form and position intents that agree with the side.
+func validateLegs(req PlaceOrderRequest) error {
+	if err := checkLegs(req); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLegs, err)
+	}
+	return nil
+}
+
+func checkLegs(req PlaceOrderRequest) error {
+	if req.OrderClass != MLeg {
+		return fmt.Errorf("legs require order class %q", MLeg)
+	}
+	if len(req.Legs) < 2 || len(req.Legs) > maxLegs {
+		return fmt.Errorf("must have between 2 and %d legs, got %d", maxLegs, len(req.Legs))
+	}
+	if req.Qty == nil || !req.Qty.IsPositive() || !req.Qty.IsInteger() {
+		return errors.New("qty must be a positive integer")
+	}
+	if req.Notional != nil {
+		return errors.New("notional is not supported")
+	}
+	symbols := make(map[string]struct{}, len(req.Legs))
+	gcd := new(big.Int)
+	for i, leg := range req.Legs {
+		if leg.Symbol == "" {
+			return fmt.Errorf("leg %d: symbol missing", i)
+		}
+		if _, ok := symbols[leg.Symbol]; ok {
+			return fmt.Errorf("leg %d: duplicate symbol %s", i, leg.Symbol)
+		}
+		symbols[leg.Symbol] = struct{}{}
+		if !leg.RatioQty.IsPositive() || !leg.RatioQty.IsInteger() {
+			return fmt.Errorf("leg %d: ratio_qty must be a positive integer", i)
+		}
+		gcd.GCD(nil, nil, gcd, leg.RatioQty.BigInt())
+		if err := validateLegIntent(leg); err != nil {
+			return fmt.Errorf("leg %d: %v", i, err)
+		}
+	}
+	if gcd.Cmp(big.NewInt(1)) != 0 {
+		return fmt.Errorf("leg ratios must be in their simplest form (divisible by %s)", gcd)
+	}
+	return nil
+}
+
+func validateLegIntent(leg OrderLeg) error {
+	switch leg.Side {
+	case Buy:
+		switch leg.PositionIntent {
+		case "", BuyToOpen, BuyToClose:
+			return nil
+		}
+	case Sell:
+		switch leg.PositionIntent {
+		case "", SellToOpen, SellToClose:
+			return nil
+		}
+	default:
+		return fmt.Errorf("invalid side %q", leg.Side)
+	}
+	return fmt.Errorf("position intent %q does not match side %q", leg.PositionIntent, leg.Side)
+}
@@ -0,0 +1,144 @@
+package alpaca
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceOrder_MultiLeg(t *testing.T) {
+	c := DefaultClient
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		assert.Equal(t, "mleg", body["order_class"])
+		for _, key := range []string{"symbol", "side", "position_intent"} {
+			assert.NotContains(t, body, key, "multi-leg orders have no top-level %s", key)
+		}
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{
+				"symbol": "AAPL250620C00200000", "ratio_qty": "1", "side": "buy", "position_intent": "buy_to_open",
+			},
+			map[string]interface{}{
+				"symbol": "AAPL250620C00210000", "ratio_qty": "1", "side": "sell", "position_intent": "sell_to_open",
+			},
+		}, body["legs"])
+		return &http.Response{
+			Body: io.NopCloser(strings.NewReader(`{
+				"id": "7ae1ff52-0c1e-4b2b-9b3e-e5e4d1e2c3b4",
+				"order_class": "mleg",
+				"type": "limit",
+				"limit_price": "1.25",
+				"qty": "2",
+				"status": "accepted",
+				"legs": [
+					{"id": "leg1", "symbol": "AAPL250620C00200000", "asset_class": "us_option", "ratio_qty": "1",
+					 "side": "buy", "position_intent": "buy_to_open", "order_class": "mleg"},
+					{"id": "leg2", "symbol": "AAPL250620C00210000", "asset_class": "us_option", "ratio_qty": "1",
+					 "side": "sell", "position_intent": "sell_to_open", "order_class": "mleg"}
+				]
+			}`)),
+		}, nil
+	}
+
+	qty := decimal.NewFromInt(2)
+	limit := decimal.RequireFromString("1.25")
+	order, err := c.PlaceOrder(PlaceOrderRequest{
+		Qty:         &qty,
+		Type:        Limit,
+		LimitPrice:  &limit,
+		TimeInForce: Day,
+		OrderClass:  MLeg,
+		Legs: []OrderLeg{
+			{Symbol: "AAPL250620C00200000", RatioQty: decimal.NewFromInt(1), Side: Buy, PositionIntent: BuyToOpen},
+			{Symbol: "AAPL250620C00210000", RatioQty: decimal.NewFromInt(1), Side: Sell, PositionIntent: SellToOpen},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, MLeg, order.OrderClass)
+	require.Len(t, order.Legs, 2)
+	assert.Equal(t, USOption, order.Legs[0].AssetClass)
+	assert.Equal(t, BuyToOpen, order.Legs[0].PositionIntent)
+	assert.True(t, decimal.NewFromInt(1).Equal(*order.Legs[1].RatioQty))
+	assert.Equal(t, Sell, order.Legs[1].Side)
+}
+
+func TestPlaceOrder_MultiLegValidation(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		require.Fail(t, "invalid orders must not be submitted")
+		return nil, nil
+	}
+	one := decimal.NewFromInt(1)
+	two := decimal.NewFromInt(2)
+	half := decimal.RequireFromString("0.5")
+	leg := func(symbol string, ratio decimal.Decimal, side Side, intent PositionIntent) OrderLeg {
+		return OrderLeg{Symbol: symbol, RatioQty: ratio, Side: side, PositionIntent: intent}
+	}
+	tests := []struct {
+		name string
+		req  PlaceOrderRequest
+		msg  string
+	}{
+		{
+			name: "wrong order class",
+			req: PlaceOrderRequest{Qty: &one, OrderClass: Simple, Legs: []OrderLeg{
+				leg("A", one, Buy, BuyToOpen), leg("B", one, Sell, SellToOpen),
+			}},
+			msg: "legs require order class",
+		},
+		{
+			name: "single leg",
+			req:  PlaceOrderRequest{Qty: &one, OrderClass: MLeg, Legs: []OrderLeg{leg("A", one, Buy, BuyToOpen)}},
+			msg:  "between 2 and 4 legs",
+		},
+		{
+			name: "fractional qty",
+			req: PlaceOrderRequest{Qty: &half, OrderClass: MLeg, Legs: []OrderLeg{
+				leg("A", one, Buy, BuyToOpen), leg("B", one, Sell, SellToOpen),
+			}},
+			msg: "qty must be a positive integer",
+		},
+		{
+			name: "duplicate symbol",
+			req: PlaceOrderRequest{Qty: &one, OrderClass: MLeg, Legs: []OrderLeg{
+				leg("A", one, Buy, BuyToOpen), leg("A", one, Sell, SellToOpen),
+			}},
+			msg: "duplicate symbol",
+		},
+		{
+			name: "fractional ratio",
+			req: PlaceOrderRequest{Qty: &one, OrderClass: MLeg, Legs: []OrderLeg{
+				leg("A", half, Buy, BuyToOpen), leg("B", one, Sell, SellToOpen),
+			}},
+			msg: "ratio_qty must be a positive integer",
+		},
+		{
+			name: "ratios not in simplest form",
+			req: PlaceOrderRequest{Qty: &one, OrderClass: MLeg, Legs: []OrderLeg{
+				leg("A", two, Buy, BuyToOpen), leg("B", two, Sell, SellToOpen),
+			}},
+			msg: "simplest form",
+		},
+		{
+			name: "intent does not match side",
+			req: PlaceOrderRequest{Qty: &one, OrderClass: MLeg, Legs: []OrderLeg{
+				leg("A", one, Buy, SellToOpen), leg("B", one, Sell, SellToOpen),
+			}},
+			msg: "does not match side",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.PlaceOrder(tt.req)
+			require.ErrorIs(t, err, ErrInvalidLegs)
+			assert.Contains(t, err.Error(), tt.msg)
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package alpaca
+
+import (
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PortfolioHistoryPoint is a single point of a PortfolioHistory.
+type PortfolioHistoryPoint struct {
+	Time          time.Time
+	Equity        decimal.Decimal
+	ProfitLoss    decimal.Decimal
+	ProfitLossPct decimal.Decimal
+	// Cashflow is the sum of the external cash flows returned for this point: deposits are positive
+	// and withdrawals are negative. Dividends, fees, interest and the other activity types are
+	// earned by the portfolio, they are part of its return and not included.
+	Cashflow decimal.Decimal
+}
+
+// Points zips the parallel slices of the history into a time series.
+func (h *PortfolioHistory) Points() []PortfolioHistoryPoint {
+	points := make([]PortfolioHistoryPoint, len(h.Timestamp))
+	for i, ts := range h.Timestamp {
+		p := PortfolioHistoryPoint{Time: time.Unix(ts, 0)}
+		if i < len(h.Equity) {
+			p.Equity = h.Equity[i]
+		}
+		if i < len(h.ProfitLoss) {
+			p.ProfitLoss = h.ProfitLoss[i]
+		}
+		if i < len(h.ProfitLossPct) {
+			p.ProfitLossPct = h.ProfitLossPct[i]
+		}
+		for activityType, flows := range h.Cashflow {
+			if externalCashflow(activityType) && i < len(flows) {
+				p.Cashflow = p.Cashflow.Add(flows[i])
+			}
+		}
+		points[i] = p
+	}
+	return points
+}
+
+// externalCashflow tells whether activities of type t move money in or out of the account.
+func externalCashflow(t ActivityType) bool {
+	switch t {
+	case ActivityCashDeposit, ActivityCashWithdrawal, ActivityJournalCash, ActivityACATCash:
+		return true
+	}
+	return false
+}
+
+// PeriodReturn is the return of the portfolio between two consecutive points.
+type PeriodReturn struct {
+	Start  time.Time
+	End    time.Time
+	Return float64
+}
+
+type PortfolioAnalysisOptions struct {
+	// RiskFreeRate is the annual risk-free rate used by the Sharpe and Sortino ratios, e.g. 0.04 for 4%.
+	RiskFreeRate float64
+	// PeriodsPerYear is used to annualize the metrics. If missing, it is derived from the timeframe
+	// of the history assuming 252 trading days with regular market hours.
+	PeriodsPerYear float64
+}
+
+// PortfolioAnalysis holds the performance metrics of a PortfolioHistory.
+// Returns are fractions (0.01 is 1%) and exclude cash flows, so deposits and withdrawals
+// do not show up as gains or losses.
+type PortfolioAnalysis struct {
+	Start       time.Time
+	End         time.Time
+	StartEquity decimal.Decimal
+	EndEquity   decimal.Decimal
+	NetCashflow decimal.Decimal
+	// Returns are the periodic returns. Periods starting with no equity are skipped.
+	Returns []PeriodReturn
+	// CumulativeReturn is the time-weighted return of the whole history.
+	CumulativeReturn float64
+	// MaxDrawdown is the largest peak to trough decline, as a positive fraction.
+	MaxDrawdown float64
+	// MaxDrawdownPeak and MaxDrawdownTrough are the times of the peak and the trough of the max drawdown.
+	MaxDrawdownPeak   time.Time
+	MaxDrawdownTrough time.Time
+	// MaxDrawdownDuration is the time from the peak of the max drawdown until the portfolio
+	// recovered to the peak, or until the end of the history if it did not.
+	MaxDrawdownDuration time.Duration
+	// Volatility is the standard deviation of the periodic returns.
+	Volatility float64
+	// AnnualizedVolatility is Volatility scaled to a year.
+	AnnualizedVolatility float64
+	// Sharpe is the annualized Sharpe ratio.
+	Sharpe float64
+	// Sortino is the annualized Sortino ratio.
+	Sortino float64
+	// BestPeriod and WorstPeriod are the periods with the highest and the lowest return.
+	BestPeriod  PeriodReturn
+	WorstPeriod PeriodReturn
+}
+
+// Analyze computes the returns, drawdown and risk metrics of the history.
+// Cash flows are assumed to happen at the end of the period they are reported in.
+func (h *PortfolioHistory) Analyze(opts PortfolioAnalysisOptions) PortfolioAnalysis {
+	points := h.Points()
+	var a PortfolioAnalysis
+	if len(points) == 0 {
+		return a
+	}
+	first, last := points[0], points[len(points)-1]
+	a.Start, a.End = first.Time, last.Time
+	a.StartEquity, a.EndEquity = first.Equity, last.Equity
+
+	for i := 1; i < len(points); i++ {
+		a.NetCashflow = a.NetCashflow.Add(points[i].Cashflow)
+		prev := points[i-1].Equity
+		if !prev.IsPositive() {
+			continue
+		}
+		r, _ := points[i].Equity.Sub(points[i].Cashflow).Sub(prev).Div(prev).Float64()
+		a.Returns = append(a.Returns, PeriodReturn{Start: points[i-1].Time, End: points[i].Time, Return: r})
+	}
+	if len(a.Returns) == 0 {
+		return a
+	}
+
+	// The drawdown is computed on the growth of one dollar, so that cash flows do not hide losses.
+	wealth, peak := 1.0, 1.0
+	peakTime := a.Returns[0].Start
+	var ddPeak time.Time
+	ddRecovered := false
+	a.BestPeriod, a.WorstPeriod = a.Returns[0], a.Returns[0]
+	for _, r := range a.Returns {
+		wealth *= 1 + r.Return
+		if r.Return > a.BestPeriod.Return {
+			a.BestPeriod = r
+		}
+		if r.Return < a.WorstPeriod.Return {
+			a.WorstPeriod = r
+		}
+		if wealth >= peak {
+			if !ddRecovered && a.MaxDrawdown > 0 && ddPeak.Equal(peakTime) {
+				a.MaxDrawdownDuration = r.End.Sub(ddPeak)
+				ddRecovered = true
+			}
+			peak, peakTime = wealth, r.End
+			continue
+		}
+		if dd := 1 - wealth/peak; dd > a.MaxDrawdown {
+			a.MaxDrawdown = dd
+			a.MaxDrawdownPeak, a.MaxDrawdownTrough = peakTime, r.End
+			ddPeak, ddRecovered = peakTime, false
+		}
+	}
+	if a.MaxDrawdown > 0 && !ddRecovered {
+		a.MaxDrawdownDuration = a.End.Sub(ddPeak)
+	}
+	a.CumulativeReturn = wealth - 1
+
+	periodsPerYear := opts.PeriodsPerYear
+	if periodsPerYear == 0 {
+		periodsPerYear = periodsPerYearOf(h.Timeframe)
+	}
+	riskFree := opts.RiskFreeRate / periodsPerYear
+
+	var mean float64
+	for _, r := range a.Returns {
+		mean += r.Return
+	}
+	mean /= float64(len(a.Returns))
+	var variance, downside float64
+	for _, r := range a.Returns {
+		variance += (r.Return - mean) * (r.Return - mean)
+		if excess := r.Return - riskFree; excess < 0 {
+			downside += excess * excess
+		}
+	}
+	if len(a.Returns) > 1 {
+		a.Volatility = math.Sqrt(variance / float64(len(a.Returns)-1))
+	}
+	downsideDeviation := math.Sqrt(downside / float64(len(a.Returns)))
+	annualization := math.Sqrt(periodsPerYear)
+	a.AnnualizedVolatility = a.Volatility * annualization
+	if a.Volatility > 0 {
+		a.Sharpe = (mean - riskFree) / a.Volatility * annualization
+	}
+	if downsideDeviation > 0 {
+		a.Sortino = (mean - riskFree) / downsideDeviation * annualization
+	}
+	return a
+}
+
+// periodsPerYearOf returns the number of periods of the timeframe in a year
+// of 252 trading days with 6.5 hour sessions.
+func periodsPerYearOf(tf TimeFrame) float64 {
+	const tradingDays = 252
+	switch tf {
+	case Min1:
+		return tradingDays * 390
+	case Min5:
+		return tradingDays * 78
+	case Min15:
+		return tradingDays * 26
+	case Hour1:
+		return tradingDays * 6.5
+	}
+	return tradingDays
+}
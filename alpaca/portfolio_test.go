@@ -0,0 +1,95 @@
+package alpaca
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPortfolioHistory(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		q := req.URL.Query()
+		assert.Equal(t, "/v2/account/portfolio/history", req.URL.Path)
+		assert.Equal(t, "1D", q.Get("timeframe"))
+		assert.Equal(t, "continuous", q.Get("intraday_reporting"))
+		assert.Equal(t, "no_reset", q.Get("pnl_reset"))
+		assert.Equal(t, "CSD,CSW", q.Get("cashflow_types"))
+		assert.Equal(t, "2024-01-01T00:00:00Z", q.Get("start"))
+		return &http.Response{
+			Body: io.NopCloser(strings.NewReader(`{
+				"timestamp": [1704153600, 1704240000, 1704326400, 1704412800, 1704672000],
+				"equity": [100, 110, 99, 150, 180],
+				"profit_loss": [0, 10, -11, 1, 30],
+				"profit_loss_pct": [0, 0.1, -0.1, 0.0101, 0.2],
+				"base_value": 100,
+				"base_value_asof": "2024-01-01",
+				"timeframe": "1D",
+				"cashflow": {"CSD": [0, 0, 0, 60, 0], "CSW": [0, 0, 0, -10, 0], "DIV": [0, 0, 0, 0, 3]}
+			}`)),
+		}, nil
+	}
+	history, err := c.GetPortfolioHistory(GetPortfolioHistoryRequest{
+		TimeFrame:         Day1,
+		Start:             time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		IntradayReporting: IntradayReportingContinuous,
+		PnLReset:          PnLResetNoReset,
+		CashflowTypes:     []ActivityType{ActivityCashDeposit, ActivityCashWithdrawal},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, history.BaseValueAsOf)
+	assert.Equal(t, 2024, history.BaseValueAsOf.Year)
+
+	points := history.Points()
+	require.Len(t, points, 5)
+	assert.True(t, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC).Equal(points[3].Time))
+	assert.True(t, decimal.NewFromInt(150).Equal(points[3].Equity))
+	assert.True(t, decimal.NewFromInt(50).Equal(points[3].Cashflow))
+	assert.True(t, decimal.NewFromInt(-11).Equal(points[2].ProfitLoss))
+	assert.True(t, points[1].Cashflow.IsZero())
+	assert.True(t, points[4].Cashflow.IsZero(), "dividends are not external cash flows")
+
+	a := history.Analyze(PortfolioAnalysisOptions{})
+	assert.True(t, decimal.NewFromInt(100).Equal(a.StartEquity))
+	assert.True(t, decimal.NewFromInt(180).Equal(a.EndEquity))
+	assert.True(t, decimal.NewFromInt(50).Equal(a.NetCashflow))
+	require.Len(t, a.Returns, 4)
+	assert.InDelta(t, 0.1, a.Returns[0].Return, 1e-9)
+	assert.InDelta(t, 1.0/99, a.Returns[2].Return, 1e-9, "the deposit must not count as a return")
+	assert.InDelta(t, 0.2, a.CumulativeReturn, 1e-9)
+	assert.InDelta(t, 0.1, a.MaxDrawdown, 1e-9)
+	assert.True(t, points[1].Time.Equal(a.MaxDrawdownPeak))
+	assert.True(t, points[2].Time.Equal(a.MaxDrawdownTrough))
+	assert.Equal(t, points[4].Time.Sub(points[1].Time), a.MaxDrawdownDuration)
+	assert.InDelta(t, 0.12788852149912247, a.Volatility, 1e-9)
+	assert.InDelta(t, 2.030167340558492, a.AnnualizedVolatility, 1e-9)
+	assert.InDelta(t, 6.519838720645737, a.Sharpe, 1e-9)
+	assert.InDelta(t, 16.67625068792227, a.Sortino, 1e-9)
+	assert.InDelta(t, 0.2, a.BestPeriod.Return, 1e-9)
+	assert.InDelta(t, -0.1, a.WorstPeriod.Return, 1e-9)
+	assert.True(t, points[1].Time.Equal(a.WorstPeriod.Start))
+}
+
+func TestPortfolioHistoryAnalyze_Unrecovered(t *testing.T) {
+	h := PortfolioHistory{
+		Timestamp: []int64{0, 3600, 7200, 10800},
+		Equity: []decimal.Decimal{
+			decimal.Zero, decimal.NewFromInt(100), decimal.NewFromInt(80), decimal.NewFromInt(90),
+		},
+		Timeframe: Hour1,
+	}
+	a := h.Analyze(PortfolioAnalysisOptions{RiskFreeRate: 0.05})
+	require.Len(t, a.Returns, 2, "periods starting without equity are skipped")
+	assert.InDelta(t, 0.2, a.MaxDrawdown, 1e-9)
+	assert.Equal(t, 2*time.Hour, a.MaxDrawdownDuration)
+	assert.InDelta(t, -0.1, a.CumulativeReturn, 1e-9)
+	assert.Less(t, a.Sharpe, 0.0)
+
+	assert.Zero(t, (&PortfolioHistory{}).Analyze(PortfolioAnalysisOptions{}))
+}
@@ -0,0 +1,230 @@
+package alpaca
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a client-side token bucket limiter. A single RateLimiter can be shared
+// between multiple clients of the same API using the same API key, so that together they
+// stay within the account's rate limit. The trading, broker and market data APIs publish
+// separate budgets: a limiter must not be shared across them, or the responses of each API
+// would keep resetting it to the budget of the other.
+//
+// The limiter adapts itself to the X-RateLimit-Limit, X-RateLimit-Remaining and
+// X-RateLimit-Reset response headers: the bucket never holds more tokens than the
+// server reports as remaining, and once the server budget is exhausted no request
+// is let through until the reset time. The server limit is a number of requests per
+// minute, so it only replaces the configured limit of limiters with a one minute period.
+//
+// The zero value is not usable, limiters must be created with NewRateLimiter.
+type RateLimiter struct {
+	mu sync.Mutex
+
+	limit        int
+	period       time.Duration
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+
+	serverLimit     int
+	serverRemaining int
+	serverReset     time.Time
+
+	requests int64
+	waits    int64
+	waited   time.Duration
+
+	now func() time.Time
+}
+
+// RateLimitStats is a snapshot of the state of a RateLimiter.
+type RateLimitStats struct {
+	// Limit is the number of requests allowed per Period.
+	Limit int
+	// Period is the length of the rate limit window.
+	Period time.Duration
+	// Available is the number of requests that can be sent right now without waiting.
+	Available int
+	// ServerLimit is the last X-RateLimit-Limit value, or 0 if none was seen yet.
+	ServerLimit int
+	// ServerRemaining is the last X-RateLimit-Remaining value, or -1 if none was seen yet.
+	ServerRemaining int
+	// ServerReset is the last X-RateLimit-Reset value.
+	ServerReset time.Time
+	// Requests is the number of requests let through so far.
+	Requests int64
+	// Waits is the number of requests that had to wait for a token.
+	Waits int64
+	// Waited is the total time requests spent waiting for a token.
+	Waited time.Duration
+}
+
+// NewRateLimiter creates a limiter that allows limit requests per period, with bursts of up to limit requests.
+// The trading API allows 200 requests per minute by default.
+// It panics if limit or period is not positive.
+func NewRateLimiter(limit int, period time.Duration) *RateLimiter {
+	if limit <= 0 {
+		panic("alpaca: non-positive limit for NewRateLimiter")
+	}
+	if period <= 0 {
+		panic("alpaca: non-positive period for NewRateLimiter")
+	}
+	l := &RateLimiter{
+		limit:           limit,
+		period:          period,
+		tokens:          float64(limit),
+		serverRemaining: -1,
+		now:             time.Now,
+	}
+	l.last = l.now()
+	return l
+}
+
+// refill must be called with l.mu held.
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(float64(l.limit), l.tokens+elapsed.Seconds()*l.rate())
+		l.last = now
+	}
+}
+
+// rate returns the number of tokens added per second.
+func (l *RateLimiter) rate() float64 {
+	return float64(l.limit) / l.period.Seconds()
+}
+
+// reserve takes a token if one is available, otherwise it returns how long to wait for one.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.refill(now)
+	if now.Before(l.blockedUntil) {
+		return l.blockedUntil.Sub(now)
+	}
+	if l.tokens >= 1 {
+		l.tokens--
+		l.requests++
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate() * float64(time.Second))
+}
+
+// Wait blocks until a request can be sent or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	var waited time.Duration
+	defer func() {
+		if waited > 0 {
+			l.mu.Lock()
+			l.waits++
+			l.waited += waited
+			l.mu.Unlock()
+		}
+	}()
+	for {
+		d := l.reserve()
+		if d <= 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, d); err != nil {
+			return err
+		}
+		waited += d
+	}
+}
+
+// Update adapts the limiter to the rate limit headers of resp, which must come from the API
+// the limiter is used for.
+func (l *RateLimiter) Update(resp *http.Response) {
+	limit, limitErr := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	remaining, remainingErr := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	reset, resetErr := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.refill(now)
+	if limitErr == nil && limit > 0 {
+		l.serverLimit = limit
+		// The server limit is per minute, it is meaningless for other periods.
+		if l.period == time.Minute && limit != l.limit {
+			l.limit = limit
+			l.tokens = math.Min(l.tokens, float64(limit))
+		}
+	}
+	if resetErr == nil {
+		l.serverReset = time.Unix(reset, 0)
+	}
+	if remainingErr == nil && remaining >= 0 {
+		l.serverRemaining = remaining
+		l.tokens = math.Min(l.tokens, float64(remaining))
+		if remaining == 0 && resetErr == nil && l.serverReset.After(now) {
+			l.blockedUntil = l.serverReset
+		}
+	}
+}
+
+// Stats returns the current budget of the limiter.
+func (l *RateLimiter) Stats() RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	l.refill(now)
+	available := int(l.tokens)
+	if now.Before(l.blockedUntil) {
+		available = 0
+	}
+	return RateLimitStats{
+		Limit:           l.limit,
+		Period:          l.period,
+		Available:       available,
+		ServerLimit:     l.serverLimit,
+		ServerRemaining: l.serverRemaining,
+		ServerReset:     l.serverReset,
+		Requests:        l.requests,
+		Waits:           l.waits,
+		Waited:          l.waited,
+	}
+}
+
+// Transport returns an http.RoundTripper that waits for the limiter before every request
+// sent through base (http.DefaultTransport if nil) and updates it from every response.
+func (l *RateLimiter) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &rateLimitedTransport{limiter: l, base: base}
+}
+
+type rateLimitedTransport struct {
+	limiter *RateLimiter
+	base    http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.limiter.Update(resp)
+	return resp, nil
+}
+
+// RateLimitedHTTPClient returns a copy of httpClient that sends its requests through limiter.
+// If limiter is nil, httpClient is returned unchanged.
+func RateLimitedHTTPClient(httpClient *http.Client, limiter *RateLimiter) *http.Client {
+	if limiter == nil {
+		return httpClient
+	}
+	limited := *httpClient
+	limited.Transport = limiter.Transport(httpClient.Transport)
+	return &limited
+}
@@ -0,0 +1,105 @@
+package alpaca
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	l := NewRateLimiter(2, 100*time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	require.NoError(t, l.Wait(ctx))
+	require.NoError(t, l.Wait(ctx))
+	assert.Less(t, time.Since(start), 20*time.Millisecond, "burst should not wait")
+	require.NoError(t, l.Wait(ctx))
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+
+	stats := l.Stats()
+	assert.Equal(t, 2, stats.Limit)
+	assert.EqualValues(t, 3, stats.Requests)
+	assert.EqualValues(t, 1, stats.Waits)
+	assert.Equal(t, -1, stats.ServerRemaining)
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	l = NewRateLimiter(1, time.Hour)
+	require.NoError(t, l.Wait(ctx))
+	require.ErrorIs(t, l.Wait(ctx), context.Canceled)
+}
+
+func TestRateLimiter_Update(t *testing.T) {
+	now := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(200, time.Minute)
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	l.Update(&http.Response{Header: http.Header{
+		"X-Ratelimit-Limit":     []string{"100"},
+		"X-Ratelimit-Remaining": []string{"10"},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(now.Add(30*time.Second).Unix(), 10)},
+	}})
+	stats := l.Stats()
+	assert.Equal(t, 100, stats.Limit)
+	assert.Equal(t, 100, stats.ServerLimit)
+	assert.Equal(t, 10, stats.ServerRemaining)
+	assert.Equal(t, 10, stats.Available)
+	assert.True(t, now.Add(30*time.Second).Equal(stats.ServerReset))
+
+	l.Update(&http.Response{Header: http.Header{
+		"X-Ratelimit-Remaining": []string{"0"},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(now.Add(30*time.Second).Unix(), 10)},
+	}})
+	assert.Equal(t, 0, l.Stats().Available)
+	assert.Equal(t, 30*time.Second, l.reserve())
+
+	now = now.Add(31 * time.Second)
+	assert.Zero(t, l.reserve())
+}
+
+func TestRateLimiter_UpdateOtherPeriod(t *testing.T) {
+	l := NewRateLimiter(10, time.Second)
+	l.Update(&http.Response{Header: http.Header{"X-Ratelimit-Limit": []string{"200"}}})
+	stats := l.Stats()
+	assert.Equal(t, 10, stats.Limit, "a per minute server limit must not replace a per second limit")
+	assert.Equal(t, 200, stats.ServerLimit)
+}
+
+func TestNewRateLimiter_Invalid(t *testing.T) {
+	assert.Panics(t, func() { NewRateLimiter(0, time.Minute) })
+	assert.Panics(t, func() { NewRateLimiter(200, 0) })
+}
+
+func TestRateLimiter_SharedBetweenClients(t *testing.T) {
+	remaining := 50
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remaining--
+		w.Header().Set("X-RateLimit-Limit", "200")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+		fmt.Fprint(w, `{"id":"some_id"}`)
+	}))
+	defer ts.Close()
+
+	limiter := NewRateLimiter(200, time.Minute)
+	c1 := NewClient(ClientOpts{BaseURL: ts.URL, RateLimiter: limiter})
+	c2 := NewClient(ClientOpts{BaseURL: ts.URL, RateLimiter: limiter})
+	_, err := c1.GetAccount()
+	require.NoError(t, err)
+	_, err = c2.GetAccount()
+	require.NoError(t, err)
+
+	stats := limiter.Stats()
+	assert.EqualValues(t, 2, stats.Requests)
+	assert.Equal(t, 48, stats.ServerRemaining)
+	assert.Equal(t, 48, stats.Available)
+}
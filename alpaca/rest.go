@@ -2,6 +2,7 @@ package alpaca
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,20 +12,44 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"cloud.google.com/go/civil"
 	"github.com/mailru/easyjson"
 	"github.com/shopspring/decimal"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/internal/httpbody"
 )
 
 // ClientOpts contains options for the alpaca client
 type ClientOpts struct {
-	APIKey     string
-	APISecret  string
-	OAuth      string
-	BaseURL    string
+	APIKey    string
+	APISecret string
+	OAuth     string
+	BaseURL   string
+	// RetryLimit is the maximum number of retries of rate limited requests. Ignored if RetryPolicy is set.
 	RetryLimit int
+	// RetryDelay is the fixed delay between retries. Ignored if RetryPolicy is set.
 	RetryDelay time.Duration
+	// RetryPolicy decides which failed requests are retried and when. If nil, rate limited
+	// requests are retried RetryLimit times with RetryDelay between the attempts, or the delay
+	// requested by the server capped at DefaultMaxRetryDelay.
+	RetryPolicy RetryPolicy
+	// IdempotentOrders makes PlaceOrder safe to resubmit. A ClientOrderID is generated for requests
+	// that do not have one, and when the outcome of a submission is unknown (e.g. the connection
+	// was lost before the response arrived) the order is looked up by its ClientOrderID before it
+	// is resubmitted. At most RetryLimit resubmissions are made, with the backoff of the retry policy
+	// between them.
+	IdempotentOrders bool
+	// ValidateOrders makes PlaceOrder and ReplaceOrder validate their requests locally (see
+	// PlaceOrderRequest.Validate) and return the *ValidationError instead of submitting invalid
+	// orders. The asset of each symbol ordered is fetched once and cached for the asset checks.
+	// ReplaceOrder fetches the order first if it replaces prices, to check them against its asset.
+	ValidateOrders bool
+	// RateLimiter, if set, delays requests to stay within the rate limit. The same limiter
+	// can be shared between multiple clients of the same API, but not across APIs (see RateLimiter).
+	RateLimiter *RateLimiter
 	// HTTPClient to be used for each http request.
 	HTTPClient *http.Client
 }
@@ -33,6 +58,7 @@ type ClientOpts struct {
 type Client struct {
 	opts       ClientOpts
 	httpClient *http.Client
+	assets     sync.Map
 
 	do func(c *Client, req *http.Request) (*http.Response, error)
 }
@@ -61,12 +87,25 @@ func NewClient(opts ClientOpts) *Client {
 	if opts.RetryDelay == 0 {
 		opts.RetryDelay = time.Second
 	}
+	if opts.RetryPolicy == nil {
+		maxDelay := DefaultMaxRetryDelay
+		if opts.RetryDelay > maxDelay {
+			maxDelay = opts.RetryDelay
+		}
+		opts.RetryPolicy = &BackoffRetryPolicy{
+			MaxRetries:       opts.RetryLimit,
+			BaseDelay:        opts.RetryDelay,
+			MaxDelay:         maxDelay,
+			RetryStatusCodes: []int{http.StatusTooManyRequests},
+		}
+	}
 	httpClient := opts.HTTPClient
 	if httpClient == nil {
 		httpClient = &http.Client{
 			Timeout: 10 * time.Second,
 		}
 	}
+	httpClient = RateLimitedHTTPClient(httpClient, opts.RateLimiter)
 	return &Client{
 		opts:       opts,
 		httpClient: httpClient,
@@ -92,23 +131,12 @@ func defaultDo(c *Client, req *http.Request) (*http.Response, error) {
 		req.Header.Set("APCA-API-SECRET-KEY", c.opts.APISecret)
 	}
 
-	var resp *http.Response
-	var err error
-	for i := 0; ; i++ {
-		resp, err = c.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		if resp.StatusCode != http.StatusTooManyRequests {
-			break
-		}
-		if i >= c.opts.RetryLimit {
-			break
-		}
-		time.Sleep(c.opts.RetryDelay)
+	resp, err := DoWithRetry(c.httpClient, req, c.opts.RetryPolicy)
+	if err != nil {
+		return nil, err
 	}
 
-	if err = verify(resp); err != nil {
+	if err = Verify(resp); err != nil {
 		return nil, err
 	}
 
@@ -117,12 +145,17 @@ func defaultDo(c *Client, req *http.Request) (*http.Response, error) {
 
 // GetAccount returns the user's account information.
 func (c *Client) GetAccount() (*Account, error) {
+	return c.GetAccountWithContext(context.Background())
+}
+
+// GetAccountWithContext returns the user's account information.
+func (c *Client) GetAccountWithContext(ctx context.Context) (*Account, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/account", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -136,12 +169,17 @@ func (c *Client) GetAccount() (*Account, error) {
 
 // GetAccountConfigurations returns the current account configurations
 func (c *Client) GetAccountConfigurations() (*AccountConfigurations, error) {
+	return c.GetAccountConfigurationsWithContext(context.Background())
+}
+
+// GetAccountConfigurationsWithContext returns the current account configurations
+func (c *Client) GetAccountConfigurationsWithContext(ctx context.Context) (*AccountConfigurations, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/account/configurations", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -163,12 +201,17 @@ type UpdateAccountConfigurationsRequest struct {
 
 // UpdateAccountConfigurations updates the account configs.
 func (c *Client) UpdateAccountConfigurations(req UpdateAccountConfigurationsRequest) (*AccountConfigurations, error) {
+	return c.UpdateAccountConfigurationsWithContext(context.Background(), req)
+}
+
+// UpdateAccountConfigurationsWithContext updates the account configs.
+func (c *Client) UpdateAccountConfigurationsWithContext(ctx context.Context, req UpdateAccountConfigurationsRequest) (*AccountConfigurations, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/account/configurations", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.patch(u, req)
+	resp, err := c.patch(ctx, u, req)
 	if err != nil {
 		return nil, err
 	}
@@ -181,18 +224,28 @@ func (c *Client) UpdateAccountConfigurations(req UpdateAccountConfigurationsRequ
 }
 
 type GetAccountActivitiesRequest struct {
-	ActivityTypes []string  `json:"activity_types"`
-	Date          time.Time `json:"date"`
-	Until         time.Time `json:"until"`
-	After         time.Time `json:"after"`
-	Direction     string    `json:"direction"`
-	PageSize      int       `json:"page_size"`
-	PageToken     string    `json:"page_token"`
-	Category      string    `json:"category"`
+	ActivityTypes []ActivityType `json:"activity_types"`
+	Date          time.Time      `json:"date"`
+	Until         time.Time      `json:"until"`
+	After         time.Time      `json:"after"`
+	Direction     string         `json:"direction"`
+	PageSize      int            `json:"page_size"`
+	PageToken     string         `json:"page_token"`
+	Category      string         `json:"category"`
+	// TotalLimit is the maximum number of activities ForEachAccountActivity returns.
+	// If missing, all matching activities are returned. GetAccountActivities ignores it.
+	TotalLimit int `json:"-"`
 }
 
+const accountActivitiesMaxPageSize = 100
+
 // GetAccountActivities returns the account activities.
 func (c *Client) GetAccountActivities(req GetAccountActivitiesRequest) ([]AccountActivity, error) {
+	return c.GetAccountActivitiesWithContext(context.Background(), req)
+}
+
+// GetAccountActivitiesWithContext returns the account activities.
+func (c *Client) GetAccountActivitiesWithContext(ctx context.Context, req GetAccountActivitiesRequest) ([]AccountActivity, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/account/activities", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
@@ -200,7 +253,11 @@ func (c *Client) GetAccountActivities(req GetAccountActivitiesRequest) ([]Accoun
 
 	q := u.Query()
 	if len(req.ActivityTypes) > 0 {
-		q.Set("activity_types", strings.Join(req.ActivityTypes, ","))
+		types := make([]string, len(req.ActivityTypes))
+		for i, t := range req.ActivityTypes {
+			types[i] = string(t)
+		}
+		q.Set("activity_types", strings.Join(types, ","))
 	}
 	if !req.Date.IsZero() {
 		q.Set("date", req.Date.UTC().Format(time.RFC3339Nano))
@@ -225,7 +282,7 @@ func (c *Client) GetAccountActivities(req GetAccountActivitiesRequest) ([]Accoun
 	}
 	u.RawQuery = q.Encode()
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -237,15 +294,78 @@ func (c *Client) GetAccountActivities(req GetAccountActivitiesRequest) ([]Accoun
 	return activities, nil
 }
 
+// ForEachAccountActivity calls callback for every account activity matching req, fetching the
+// pages one by one in the requested direction. Paging starts at req.PageToken if set.
+// If a request fails, callback is called with the error and ForEachAccountActivity returns it.
+// Iteration stops when callback returns false, after req.TotalLimit activities or after the last page.
+func (c *Client) ForEachAccountActivity(req GetAccountActivitiesRequest, callback func(activity AccountActivity, err error) (keepGoing bool)) error {
+	return c.ForEachAccountActivityWithContext(context.Background(), req, callback)
+}
+
+// ForEachAccountActivityWithContext calls callback for every account activity matching req, fetching the
+// pages one by one in the requested direction. Paging starts at req.PageToken if set.
+// If a request fails, callback is called with the error and ForEachAccountActivityWithContext returns it.
+// Iteration stops when callback returns false, after req.TotalLimit activities or after the last page.
+func (c *Client) ForEachAccountActivityWithContext(
+	ctx context.Context, req GetAccountActivitiesRequest, callback func(activity AccountActivity, err error) (keepGoing bool),
+) error {
+	// Larger pages are truncated by the server, which would look like the last page.
+	if req.PageSize <= 0 || req.PageSize > accountActivitiesMaxPageSize {
+		req.PageSize = accountActivitiesMaxPageSize
+	}
+	received := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			callback(AccountActivity{}, err)
+			return err
+		}
+		if req.TotalLimit > 0 && req.TotalLimit-received < req.PageSize {
+			req.PageSize = req.TotalLimit - received
+		}
+		activities, err := c.GetAccountActivitiesWithContext(ctx, req)
+		if err != nil {
+			callback(AccountActivity{}, err)
+			return err
+		}
+		for _, activity := range activities {
+			received++
+			if !callback(activity, nil) {
+				return nil
+			}
+		}
+		if len(activities) < req.PageSize || (req.TotalLimit > 0 && received >= req.TotalLimit) {
+			return nil
+		}
+		// The page token is the ID of the last activity of the previous page,
+		// the next page continues from there in the same direction.
+		req.PageToken = activities[len(activities)-1].ID
+	}
+}
+
 type GetPortfolioHistoryRequest struct {
-	Period        string
-	TimeFrame     TimeFrame
-	DateEnd       time.Time
+	Period    string
+	TimeFrame TimeFrame
+	// Start and End bound the history. Only two of Start, End and Period can be used at the same time.
+	Start   time.Time
+	End     time.Time
+	DateEnd time.Time
+	// ExtendedHours is deprecated, use IntradayReporting instead.
 	ExtendedHours bool
+	// IntradayReporting controls which timestamps are returned for intraday timeframes.
+	IntradayReporting IntradayReporting
+	// PnLReset controls whether the profit/loss baseline resets every day for intraday timeframes.
+	PnLReset PnLReset
+	// CashflowTypes are the activity types returned in the cashflow of the history.
+	CashflowTypes []ActivityType
 }
 
 // GetPortfolioHistory returns the portfolio history.
 func (c *Client) GetPortfolioHistory(req GetPortfolioHistoryRequest) (*PortfolioHistory, error) {
+	return c.GetPortfolioHistoryWithContext(context.Background(), req)
+}
+
+// GetPortfolioHistoryWithContext returns the portfolio history.
+func (c *Client) GetPortfolioHistoryWithContext(ctx context.Context, req GetPortfolioHistoryRequest) (*PortfolioHistory, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/account/portfolio/history", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
@@ -258,13 +378,32 @@ func (c *Client) GetPortfolioHistory(req GetPortfolioHistoryRequest) (*Portfolio
 	if req.TimeFrame != "" {
 		query.Set("timeframe", string(req.TimeFrame))
 	}
+	if !req.Start.IsZero() {
+		query.Set("start", req.Start.Format(time.RFC3339))
+	}
+	if !req.End.IsZero() {
+		query.Set("end", req.End.Format(time.RFC3339))
+	}
 	if !req.DateEnd.IsZero() {
 		query.Set("date_end", req.DateEnd.Format("2006-01-02"))
 	}
 	query.Set("extended_hours", strconv.FormatBool(req.ExtendedHours))
+	if req.IntradayReporting != "" {
+		query.Set("intraday_reporting", string(req.IntradayReporting))
+	}
+	if req.PnLReset != "" {
+		query.Set("pnl_reset", string(req.PnLReset))
+	}
+	if len(req.CashflowTypes) > 0 {
+		types := make([]string, len(req.CashflowTypes))
+		for i, t := range req.CashflowTypes {
+			types[i] = string(t)
+		}
+		query.Set("cashflow_types", strings.Join(types, ","))
+	}
 	u.RawQuery = query.Encode()
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -278,12 +417,17 @@ func (c *Client) GetPortfolioHistory(req GetPortfolioHistoryRequest) (*Portfolio
 
 // GetPositions returns the account's open positions.
 func (c *Client) GetPositions() ([]Position, error) {
+	return c.GetPositionsWithContext(context.Background())
+}
+
+// GetPositionsWithContext returns the account's open positions.
+func (c *Client) GetPositionsWithContext(ctx context.Context) ([]Position, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/positions", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -297,6 +441,11 @@ func (c *Client) GetPositions() ([]Position, error) {
 
 // GetPosition returns the account's position for the provided symbol.
 func (c *Client) GetPosition(symbol string) (*Position, error) {
+	return c.GetPositionWithContext(context.Background(), symbol)
+}
+
+// GetPositionWithContext returns the account's position for the provided symbol.
+func (c *Client) GetPositionWithContext(ctx context.Context, symbol string) (*Position, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/positions/%s", c.opts.BaseURL, apiVersion, symbol))
 	if err != nil {
 		return nil, err
@@ -306,7 +455,7 @@ func (c *Client) GetPosition(symbol string) (*Position, error) {
 	q.Set("symbol", symbol)
 	u.RawQuery = q.Encode()
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -322,10 +471,68 @@ type CloseAllPositionsRequest struct {
 	CancelOrders bool
 }
 
+// ClosePositionResult is the outcome of closing a single position.
+type ClosePositionResult struct {
+	Symbol string
+	// StatusCode is the HTTP status of closing this position.
+	StatusCode int
+	// Order is the order created to close the position. It is nil if closing failed.
+	Order *Order
+	// Err is the reason closing the position failed, typically an *APIError.
+	Err error
+}
+
+// CancelOrderResult is the outcome of canceling a single order.
+type CancelOrderResult struct {
+	OrderID string
+	// StatusCode is the HTTP status of canceling this order.
+	StatusCode int
+	// Order is the canceled order, if the server returned it.
+	Order *Order
+	// Err is the reason canceling the order failed, typically an *APIError.
+	Err error
+}
+
 // CloseAllPositions liquidates all open positions at market price.
 // It returns the list of orders that were created to close the positions.
 // If errors occur while closing some of the positions, the errors will also be returned (possibly among orders)
 func (c *Client) CloseAllPositions(req CloseAllPositionsRequest) ([]Order, error) {
+	return c.CloseAllPositionsWithContext(context.Background(), req)
+}
+
+// CloseAllPositionsWithContext liquidates all open positions at market price.
+// It returns the list of orders that were created to close the positions.
+// If errors occur while closing some of the positions, the errors will also be returned (possibly among orders)
+func (c *Client) CloseAllPositionsWithContext(ctx context.Context, req CloseAllPositionsRequest) ([]Order, error) {
+	results, err := c.CloseAllPositionsResultsWithContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		orders = make([]Order, 0, len(results))
+		errs   = make([]error, 0, len(results))
+	)
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", r.Symbol, r.Err))
+			continue
+		}
+		orders = append(orders, *r.Order)
+	}
+
+	return orders, errors.Join(errs...)
+}
+
+// CloseAllPositionsResults liquidates all open positions at market price.
+// It returns the outcome for every position. The error is only set if the whole request failed.
+func (c *Client) CloseAllPositionsResults(req CloseAllPositionsRequest) ([]ClosePositionResult, error) {
+	return c.CloseAllPositionsResultsWithContext(context.Background(), req)
+}
+
+// CloseAllPositionsResultsWithContext liquidates all open positions at market price.
+// It returns the outcome for every position. The error is only set if the whole request failed.
+func (c *Client) CloseAllPositionsResultsWithContext(ctx context.Context, req CloseAllPositionsRequest) ([]ClosePositionResult, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/positions", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
@@ -335,7 +542,7 @@ func (c *Client) CloseAllPositions(req CloseAllPositionsRequest) ([]Order, error
 	q.Set("cancel_orders", strconv.FormatBool(req.CancelOrders))
 	u.RawQuery = q.Encode()
 
-	resp, err := c.delete(u)
+	resp, err := c.delete(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -345,28 +552,59 @@ func (c *Client) CloseAllPositions(req CloseAllPositionsRequest) ([]Order, error
 		return nil, err
 	}
 
-	var (
-		orders = make([]Order, 0, len(closeAllPositions))
-		errs   = make([]error, 0, len(closeAllPositions))
-	)
+	results := make([]ClosePositionResult, 0, len(closeAllPositions))
 	for _, capr := range closeAllPositions {
-		if capr.Status == http.StatusOK {
-			var order Order
-			if err := easyjson.Unmarshal(capr.Body, &order); err != nil {
-				return nil, err
-			}
-			orders = append(orders, order)
-			continue
-		}
-		var apiErr APIError
-		if err := easyjson.Unmarshal(capr.Body, &apiErr); err != nil {
-			return nil, err
-		}
-		apiErr.StatusCode = capr.Status
-		errs = append(errs, &apiErr)
+		results = append(results, NewClosePositionResult(capr.Symbol, capr.Status, capr.Body))
 	}
+	return results, nil
+}
 
-	return orders, errors.Join(errs...)
+// NewClosePositionResult decodes the entry of symbol in the multi-status response of closing all positions.
+// It is shared with the broker client, which closes the positions of an account the same way.
+func NewClosePositionResult(symbol string, status int, body json.RawMessage) ClosePositionResult {
+	order, err := bulkResult(status, body)
+	if err == nil && order == nil {
+		// Closing a position always creates an order.
+		err = errors.New("missing order in response")
+	}
+	return ClosePositionResult{
+		Symbol:     symbol,
+		StatusCode: status,
+		Order:      order,
+		Err:        err,
+	}
+}
+
+// NewCancelOrderResult decodes the entry of orderID in the multi-status response of canceling all orders.
+// It is shared with the broker client, which cancels the orders of an account the same way.
+func NewCancelOrderResult(orderID string, status int, body json.RawMessage) CancelOrderResult {
+	order, err := bulkResult(status, body)
+	return CancelOrderResult{
+		OrderID:    orderID,
+		StatusCode: status,
+		Order:      order,
+		Err:        err,
+	}
+}
+
+// bulkResult decodes the body of a single entry of a multi-status response:
+// the order on success, the error otherwise.
+func bulkResult(status int, body json.RawMessage) (*Order, error) {
+	if status < 200 || status >= 300 {
+		apiErr := APIError{StatusCode: status, Body: strings.TrimSpace(string(body))}
+		if err := easyjson.Unmarshal(body, &apiErr); err != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(status)
+		}
+		return nil, &apiErr
+	}
+	if len(body) == 0 {
+		return nil, nil
+	}
+	var order Order
+	if err := easyjson.Unmarshal(body, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
 }
 
 type ClosePositionRequest struct {
@@ -381,6 +619,11 @@ type ClosePositionRequest struct {
 
 // ClosePosition liquidates the position for the given symbol at market price.
 func (c *Client) ClosePosition(symbol string, req ClosePositionRequest) (*Order, error) {
+	return c.ClosePositionWithContext(context.Background(), symbol, req)
+}
+
+// ClosePositionWithContext liquidates the position for the given symbol at market price.
+func (c *Client) ClosePositionWithContext(ctx context.Context, symbol string, req ClosePositionRequest) (*Order, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/positions/%s", c.opts.BaseURL, apiVersion, symbol))
 	if err != nil {
 		return nil, err
@@ -395,7 +638,7 @@ func (c *Client) ClosePosition(symbol string, req ClosePositionRequest) (*Order,
 	}
 	u.RawQuery = q.Encode()
 
-	resp, err := c.delete(u)
+	resp, err := c.delete(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -409,12 +652,17 @@ func (c *Client) ClosePosition(symbol string, req ClosePositionRequest) (*Order,
 
 // GetClock returns the current market clock.
 func (c *Client) GetClock() (*Clock, error) {
+	return c.GetClockWithContext(context.Background())
+}
+
+// GetClockWithContext returns the current market clock.
+func (c *Client) GetClockWithContext(ctx context.Context) (*Clock, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/clock", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -433,6 +681,11 @@ type GetCalendarRequest struct {
 
 // GetCalendar returns the market calendar.
 func (c *Client) GetCalendar(req GetCalendarRequest) ([]CalendarDay, error) {
+	return c.GetCalendarWithContext(context.Background(), req)
+}
+
+// GetCalendarWithContext returns the market calendar.
+func (c *Client) GetCalendarWithContext(ctx context.Context, req GetCalendarRequest) ([]CalendarDay, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/calendar", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
@@ -447,7 +700,7 @@ func (c *Client) GetCalendar(req GetCalendarRequest) ([]CalendarDay, error) {
 	}
 	u.RawQuery = q.Encode()
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -473,6 +726,11 @@ type GetOrdersRequest struct {
 
 // GetOrders returns the list of orders for an account.
 func (c *Client) GetOrders(req GetOrdersRequest) ([]Order, error) {
+	return c.GetOrdersWithContext(context.Background(), req)
+}
+
+// GetOrdersWithContext returns the list of orders for an account.
+func (c *Client) GetOrdersWithContext(ctx context.Context, req GetOrdersRequest) ([]Order, error) {
 	urlString := fmt.Sprintf("%s/%s/orders", c.opts.BaseURL, apiVersion)
 
 	u, err := url.Parse(urlString)
@@ -480,7 +738,24 @@ func (c *Client) GetOrders(req GetOrdersRequest) ([]Order, error) {
 		return nil, err
 	}
 
-	q := u.Query()
+	u.RawQuery = req.Query().Encode()
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders orderSlice
+	if err = unmarshal(resp, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// Query returns the query parameters of the request. It is shared with the broker client,
+// which lists the orders of an account with the same parameters.
+func (req GetOrdersRequest) Query() url.Values {
+	q := url.Values{}
 	if req.Status != "" {
 		q.Set("status", req.Status)
 	}
@@ -503,25 +778,16 @@ func (c *Client) GetOrders(req GetOrdersRequest) ([]Order, error) {
 	if len(req.Symbols) > 0 {
 		q.Set("symbols", strings.Join(req.Symbols, ","))
 	}
-	u.RawQuery = q.Encode()
-
-	resp, err := c.get(u)
-	if err != nil {
-		return nil, err
-	}
-
-	var orders orderSlice
-	if err = unmarshal(resp, &orders); err != nil {
-		return nil, err
-	}
-	return orders, nil
+	return q
 }
 
+// PlaceOrderRequest is an order to submit. Symbol, Side and PositionIntent are left out
+// of the payload when empty, e.g. for multi-leg orders, which set them on the legs instead.
 type PlaceOrderRequest struct {
-	Symbol         string           `json:"symbol"`
+	Symbol         string           `json:"symbol,omitempty"`
 	Qty            *decimal.Decimal `json:"qty"`
 	Notional       *decimal.Decimal `json:"notional"`
-	Side           Side             `json:"side"`
+	Side           Side             `json:"side,omitempty"`
 	Type           OrderType        `json:"type"`
 	TimeInForce    TimeInForce      `json:"time_in_force"`
 	LimitPrice     *decimal.Decimal `json:"limit_price"`
@@ -533,7 +799,18 @@ type PlaceOrderRequest struct {
 	StopLoss       *StopLoss        `json:"stop_loss"`
 	TrailPrice     *decimal.Decimal `json:"trail_price"`
 	TrailPercent   *decimal.Decimal `json:"trail_percent"`
-	PositionIntent PositionIntent   `json:"position_intent"`
+	PositionIntent PositionIntent   `json:"position_intent,omitempty"`
+	// Legs are the legs of a multi-leg (OrderClass MLeg) options order. Qty is the number of
+	// units of the whole strategy, each leg is traded RatioQty times that amount.
+	Legs []OrderLeg `json:"legs,omitempty"`
+}
+
+// OrderLeg is a single leg of a multi-leg options order.
+type OrderLeg struct {
+	Symbol         string          `json:"symbol"`
+	RatioQty       decimal.Decimal `json:"ratio_qty"`
+	Side           Side            `json:"side"`
+	PositionIntent PositionIntent  `json:"position_intent"`
 }
 
 type TakeProfit struct {
@@ -547,12 +824,35 @@ type StopLoss struct {
 
 // PlaceOrder submits an order request to buy or sell an asset.
 func (c *Client) PlaceOrder(req PlaceOrderRequest) (*Order, error) {
+	return c.PlaceOrderWithContext(context.Background(), req)
+}
+
+// PlaceOrderWithContext submits an order request to buy or sell an asset.
+func (c *Client) PlaceOrderWithContext(ctx context.Context, req PlaceOrderRequest) (*Order, error) {
+	if c.opts.ValidateOrders {
+		if err := c.validateOrder(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	if c.opts.IdempotentOrders {
+		return c.placeOrderIdempotent(ctx, req)
+	}
+	return c.placeOrder(ctx, req)
+}
+
+func (c *Client) placeOrder(ctx context.Context, req PlaceOrderRequest) (*Order, error) {
+	if req.OrderClass == MLeg || len(req.Legs) > 0 {
+		if err := validateLegs(req); err != nil {
+			return nil, err
+		}
+	}
+
 	u, err := url.Parse(fmt.Sprintf("%s/%s/orders", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.post(u, req)
+	resp, err := c.post(ctx, u, req)
 	if err != nil {
 		return nil, err
 	}
@@ -566,12 +866,17 @@ func (c *Client) PlaceOrder(req PlaceOrderRequest) (*Order, error) {
 
 // GetOrder submits a request to get an order by the order ID.
 func (c *Client) GetOrder(orderID string) (*Order, error) {
+	return c.GetOrderWithContext(context.Background(), orderID)
+}
+
+// GetOrderWithContext submits a request to get an order by the order ID.
+func (c *Client) GetOrderWithContext(ctx context.Context, orderID string) (*Order, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/orders/%s", c.opts.BaseURL, apiVersion, orderID))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -585,6 +890,11 @@ func (c *Client) GetOrder(orderID string) (*Order, error) {
 
 // GetOrderByClientOrderID submits a request to get an order by the client order ID.
 func (c *Client) GetOrderByClientOrderID(clientOrderID string) (*Order, error) {
+	return c.GetOrderByClientOrderIDWithContext(context.Background(), clientOrderID)
+}
+
+// GetOrderByClientOrderIDWithContext submits a request to get an order by the client order ID.
+func (c *Client) GetOrderByClientOrderIDWithContext(ctx context.Context, clientOrderID string) (*Order, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/orders:by_client_order_id", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
@@ -594,7 +904,7 @@ func (c *Client) GetOrderByClientOrderID(clientOrderID string) (*Order, error) {
 	q.Set("client_order_id", clientOrderID)
 	u.RawQuery = q.Encode()
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -617,12 +927,22 @@ type ReplaceOrderRequest struct {
 
 // ReplaceOrder submits a request to replace an order by id
 func (c *Client) ReplaceOrder(orderID string, req ReplaceOrderRequest) (*Order, error) {
+	return c.ReplaceOrderWithContext(context.Background(), orderID, req)
+}
+
+// ReplaceOrderWithContext submits a request to replace an order by id
+func (c *Client) ReplaceOrderWithContext(ctx context.Context, orderID string, req ReplaceOrderRequest) (*Order, error) {
+	if c.opts.ValidateOrders {
+		if err := c.validateReplace(ctx, orderID, req); err != nil {
+			return nil, err
+		}
+	}
 	u, err := url.Parse(fmt.Sprintf("%s/%s/orders/%s", c.opts.BaseURL, apiVersion, orderID))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.patch(u, req)
+	resp, err := c.patch(ctx, u, req)
 	if err != nil {
 		return nil, err
 	}
@@ -636,31 +956,72 @@ func (c *Client) ReplaceOrder(orderID string, req ReplaceOrderRequest) (*Order,
 
 // CancelOrder submits a request to cancel an open order.
 func (c *Client) CancelOrder(orderID string) error {
+	return c.CancelOrderWithContext(context.Background(), orderID)
+}
+
+// CancelOrderWithContext submits a request to cancel an open order.
+func (c *Client) CancelOrderWithContext(ctx context.Context, orderID string) error {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/orders/%s", c.opts.BaseURL, apiVersion, orderID))
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.delete(u)
+	resp, err := c.delete(ctx, u)
 	if err != nil {
 		return err
 	}
 
-	return verify(resp)
+	return Verify(resp)
 }
 
 // CancelAllOrders submits a request to cancel all orders.
 func (c *Client) CancelAllOrders() error {
+	return c.CancelAllOrdersWithContext(context.Background())
+}
+
+// CancelAllOrdersWithContext submits a request to cancel all orders.
+func (c *Client) CancelAllOrdersWithContext(ctx context.Context) error {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/orders", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.delete(u)
+	resp, err := c.delete(ctx, u)
 	if err != nil {
 		return err
 	}
-	return verify(resp)
+	return Verify(resp)
+}
+
+// CancelAllOrdersResults submits a request to cancel all orders.
+// It returns the outcome for every order. The error is only set if the whole request failed.
+func (c *Client) CancelAllOrdersResults() ([]CancelOrderResult, error) {
+	return c.CancelAllOrdersResultsWithContext(context.Background())
+}
+
+// CancelAllOrdersResultsWithContext submits a request to cancel all orders.
+// It returns the outcome for every order. The error is only set if the whole request failed.
+func (c *Client) CancelAllOrdersResultsWithContext(ctx context.Context) ([]CancelOrderResult, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/orders", c.opts.BaseURL, apiVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.delete(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var cancelAllOrders cancelAllOrdersSlice
+	if err = unmarshal(resp, &cancelAllOrders); err != nil {
+		return nil, err
+	}
+
+	results := make([]CancelOrderResult, 0, len(cancelAllOrders))
+	for _, caor := range cancelAllOrders {
+		results = append(results, NewCancelOrderResult(caor.ID, caor.Status, caor.Body))
+	}
+	return results, nil
 }
 
 type GetAssetsRequest struct {
@@ -671,6 +1032,11 @@ type GetAssetsRequest struct {
 
 // GetAssets returns the list of assets.
 func (c *Client) GetAssets(req GetAssetsRequest) ([]Asset, error) {
+	return c.GetAssetsWithContext(context.Background(), req)
+}
+
+// GetAssetsWithContext returns the list of assets.
+func (c *Client) GetAssetsWithContext(ctx context.Context, req GetAssetsRequest) ([]Asset, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/assets", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
@@ -688,7 +1054,7 @@ func (c *Client) GetAssets(req GetAssetsRequest) ([]Asset, error) {
 	}
 	u.RawQuery = q.Encode()
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -702,12 +1068,17 @@ func (c *Client) GetAssets(req GetAssetsRequest) ([]Asset, error) {
 
 // GetAsset returns an asset for the given symbol.
 func (c *Client) GetAsset(symbol string) (*Asset, error) {
+	return c.GetAssetWithContext(context.Background(), symbol)
+}
+
+// GetAssetWithContext returns an asset for the given symbol.
+func (c *Client) GetAssetWithContext(ctx context.Context, symbol string) (*Asset, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/assets/%v", c.opts.BaseURL, apiVersion, symbol))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -719,6 +1090,164 @@ func (c *Client) GetAsset(symbol string) (*Asset, error) {
 	return &asset, nil
 }
 
+type GetOptionContractsRequest struct {
+	// UnderlyingSymbols filters the contracts by their underlying symbols.
+	UnderlyingSymbols []string
+	// ShowDeliverables tells the server to include the deliverables of the contracts.
+	ShowDeliverables bool
+	// Status filters the contracts by status. The server defaults to active contracts.
+	Status AssetStatus
+	// ExpirationDate filters contracts by the exact expiration date.
+	ExpirationDate civil.Date
+	// ExpirationDateGte filters contracts expiring on or after the given date.
+	ExpirationDateGte civil.Date
+	// ExpirationDateLte filters contracts expiring on or before the given date.
+	ExpirationDateLte civil.Date
+	// RootSymbol filters contracts by the root symbol (e.g. AAPL1).
+	RootSymbol string
+	// Type filters contracts by the type (call or put).
+	Type OptionType
+	// Style filters contracts by the exercise style (american or european).
+	Style OptionStyle
+	// StrikePriceGte filters contracts with strike price greater than or equal to the specified value.
+	StrikePriceGte decimal.Decimal
+	// StrikePriceLte filters contracts with strike price less than or equal to the specified value.
+	StrikePriceLte decimal.Decimal
+	// TotalLimit is the limit of the total number of the returned contracts.
+	// If missing, all contracts matching the filters will be returned.
+	TotalLimit int
+	// PageLimit is the pagination size. If empty, the default page size will be used.
+	PageLimit int
+}
+
+const optionContractsMaxLimit = 10000
+
+// GetOptionContracts returns the option contracts matching the request's filters.
+func (c *Client) GetOptionContracts(req GetOptionContractsRequest) ([]OptionContract, error) {
+	return c.GetOptionContractsWithContext(context.Background(), req)
+}
+
+// GetOptionContractsWithContext returns the option contracts matching the request's filters.
+func (c *Client) GetOptionContractsWithContext(ctx context.Context, req GetOptionContractsRequest) ([]OptionContract, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/options/contracts", c.opts.BaseURL, apiVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+	if len(req.UnderlyingSymbols) > 0 {
+		q.Set("underlying_symbols", strings.Join(req.UnderlyingSymbols, ","))
+	}
+	if req.ShowDeliverables {
+		q.Set("show_deliverables", "true")
+	}
+	if req.Status != "" {
+		q.Set("status", string(req.Status))
+	}
+	if !req.ExpirationDate.IsZero() {
+		q.Set("expiration_date", req.ExpirationDate.String())
+	}
+	if !req.ExpirationDateGte.IsZero() {
+		q.Set("expiration_date_gte", req.ExpirationDateGte.String())
+	}
+	if !req.ExpirationDateLte.IsZero() {
+		q.Set("expiration_date_lte", req.ExpirationDateLte.String())
+	}
+	if req.RootSymbol != "" {
+		q.Set("root_symbol", req.RootSymbol)
+	}
+	if req.Type != "" {
+		q.Set("type", string(req.Type))
+	}
+	if req.Style != "" {
+		q.Set("style", string(req.Style))
+	}
+	if !req.StrikePriceGte.IsZero() {
+		q.Set("strike_price_gte", req.StrikePriceGte.String())
+	}
+	if !req.StrikePriceLte.IsZero() {
+		q.Set("strike_price_lte", req.StrikePriceLte.String())
+	}
+
+	var contracts []OptionContract
+	for req.TotalLimit == 0 || len(contracts) < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		limit := req.PageLimit
+		if req.TotalLimit != 0 {
+			remaining := req.TotalLimit - len(contracts)
+			if (limit == 0 || limit > remaining) && remaining <= optionContractsMaxLimit {
+				limit = remaining
+			}
+		}
+		if limit != 0 {
+			q.Set("limit", strconv.Itoa(limit))
+		}
+		u.RawQuery = q.Encode()
+
+		resp, err := c.get(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+
+		var contractsResp optionContractsResponse
+		if err = unmarshal(resp, &contractsResp); err != nil {
+			return nil, err
+		}
+
+		contracts = append(contracts, contractsResp.OptionContracts...)
+		if contractsResp.NextPageToken == nil || *contractsResp.NextPageToken == "" {
+			break
+		}
+		q.Set("page_token", *contractsResp.NextPageToken)
+	}
+	return contracts, nil
+}
+
+// GetOptionContract returns an option contract by its symbol (e.g. AAPL250620C00200000) or ID.
+func (c *Client) GetOptionContract(symbolOrID string) (*OptionContract, error) {
+	return c.GetOptionContractWithContext(context.Background(), symbolOrID)
+}
+
+// GetOptionContractWithContext returns an option contract by its symbol (e.g. AAPL250620C00200000) or ID.
+func (c *Client) GetOptionContractWithContext(ctx context.Context, symbolOrID string) (*OptionContract, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/options/contracts/%s", c.opts.BaseURL, apiVersion, symbolOrID))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var contract OptionContract
+	if err = unmarshal(resp, &contract); err != nil {
+		return nil, err
+	}
+	return &contract, nil
+}
+
+// ExercisePosition exercises a held long option position, identified by the contract symbol or ID.
+func (c *Client) ExercisePosition(symbolOrContractID string) error {
+	return c.ExercisePositionWithContext(context.Background(), symbolOrContractID)
+}
+
+// ExercisePositionWithContext exercises a held long option position, identified by the contract symbol or ID.
+func (c *Client) ExercisePositionWithContext(ctx context.Context, symbolOrContractID string) error {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/positions/%s/exercise", c.opts.BaseURL, apiVersion, symbolOrContractID))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.post(ctx, u, nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
 type GetAnnouncementsRequest struct {
 	CATypes  []string  `json:"ca_types"`
 	Since    time.Time `json:"since"`
@@ -729,6 +1258,10 @@ type GetAnnouncementsRequest struct {
 }
 
 func (c *Client) GetAnnouncements(req GetAnnouncementsRequest) ([]Announcement, error) {
+	return c.GetAnnouncementsWithContext(context.Background(), req)
+}
+
+func (c *Client) GetAnnouncementsWithContext(ctx context.Context, req GetAnnouncementsRequest) ([]Announcement, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/corporate_actions/announcements", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
@@ -755,7 +1288,7 @@ func (c *Client) GetAnnouncements(req GetAnnouncementsRequest) ([]Announcement,
 	}
 	u.RawQuery = q.Encode()
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -769,13 +1302,17 @@ func (c *Client) GetAnnouncements(req GetAnnouncementsRequest) ([]Announcement,
 }
 
 func (c *Client) GetAnnouncement(announcementID string) (*Announcement, error) {
+	return c.GetAnnouncementWithContext(context.Background(), announcementID)
+}
+
+func (c *Client) GetAnnouncementWithContext(ctx context.Context, announcementID string) (*Announcement, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/corporate_actions/announcements/%s",
 		c.opts.BaseURL, apiVersion, announcementID))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -787,14 +1324,19 @@ func (c *Client) GetAnnouncement(announcementID string) (*Announcement, error) {
 	return &announcement, nil
 }
 
-// GetAccount returns the user's account information.
+// GetWatchlists returns the watchlists of the account.
 func (c *Client) GetWatchlists() ([]Watchlist, error) {
+	return c.GetWatchlistsWithContext(context.Background())
+}
+
+// GetWatchlistsWithContext returns the watchlists of the account.
+func (c *Client) GetWatchlistsWithContext(ctx context.Context) ([]Watchlist, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/watchlists", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -807,12 +1349,16 @@ func (c *Client) GetWatchlists() ([]Watchlist, error) {
 }
 
 func (c *Client) CreateWatchlist(req CreateWatchlistRequest) (*Watchlist, error) {
+	return c.CreateWatchlistWithContext(context.Background(), req)
+}
+
+func (c *Client) CreateWatchlistWithContext(ctx context.Context, req CreateWatchlistRequest) (*Watchlist, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/watchlists", c.opts.BaseURL, apiVersion))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.post(u, req)
+	resp, err := c.post(ctx, u, req)
 	if err != nil {
 		return nil, err
 	}
@@ -825,12 +1371,16 @@ func (c *Client) CreateWatchlist(req CreateWatchlistRequest) (*Watchlist, error)
 }
 
 func (c *Client) GetWatchlist(watchlistID string) (*Watchlist, error) {
+	return c.GetWatchlistWithContext(context.Background(), watchlistID)
+}
+
+func (c *Client) GetWatchlistWithContext(ctx context.Context, watchlistID string) (*Watchlist, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/watchlists/%s", c.opts.BaseURL, apiVersion, watchlistID))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -843,12 +1393,16 @@ func (c *Client) GetWatchlist(watchlistID string) (*Watchlist, error) {
 }
 
 func (c *Client) UpdateWatchlist(watchlistID string, req UpdateWatchlistRequest) (*Watchlist, error) {
+	return c.UpdateWatchlistWithContext(context.Background(), watchlistID, req)
+}
+
+func (c *Client) UpdateWatchlistWithContext(ctx context.Context, watchlistID string, req UpdateWatchlistRequest) (*Watchlist, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/watchlists/%s", c.opts.BaseURL, apiVersion, watchlistID))
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := c.put(u, req)
+	resp, err := c.put(ctx, u, req)
 	if err != nil {
 		return nil, err
 	}
@@ -863,6 +1417,10 @@ func (c *Client) UpdateWatchlist(watchlistID string, req UpdateWatchlistRequest)
 var ErrSymbolMissing = fmt.Errorf("symbol missing from request")
 
 func (c *Client) AddSymbolToWatchlist(watchlistID string, req AddSymbolToWatchlistRequest) (*Watchlist, error) {
+	return c.AddSymbolToWatchlistWithContext(context.Background(), watchlistID, req)
+}
+
+func (c *Client) AddSymbolToWatchlistWithContext(ctx context.Context, watchlistID string, req AddSymbolToWatchlistRequest) (*Watchlist, error) {
 	if req.Symbol == "" {
 		return nil, ErrSymbolMissing
 	}
@@ -872,7 +1430,7 @@ func (c *Client) AddSymbolToWatchlist(watchlistID string, req AddSymbolToWatchli
 		return nil, err
 	}
 
-	resp, err := c.post(u, req)
+	resp, err := c.post(ctx, u, req)
 	if err != nil {
 		return nil, err
 	}
@@ -885,6 +1443,10 @@ func (c *Client) AddSymbolToWatchlist(watchlistID string, req AddSymbolToWatchli
 }
 
 func (c *Client) RemoveSymbolFromWatchlist(watchlistID string, req RemoveSymbolFromWatchlistRequest) error {
+	return c.RemoveSymbolFromWatchlistWithContext(context.Background(), watchlistID, req)
+}
+
+func (c *Client) RemoveSymbolFromWatchlistWithContext(ctx context.Context, watchlistID string, req RemoveSymbolFromWatchlistRequest) error {
 	if req.Symbol == "" {
 		return ErrSymbolMissing
 	}
@@ -894,17 +1456,21 @@ func (c *Client) RemoveSymbolFromWatchlist(watchlistID string, req RemoveSymbolF
 		return err
 	}
 
-	_, err = c.delete(u)
+	_, err = c.delete(ctx, u)
 	return err
 }
 
 func (c *Client) DeleteWatchlist(watchlistID string) error {
+	return c.DeleteWatchlistWithContext(context.Background(), watchlistID)
+}
+
+func (c *Client) DeleteWatchlistWithContext(ctx context.Context, watchlistID string) error {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/watchlists/%s", c.opts.BaseURL, apiVersion, watchlistID))
 	if err != nil {
 		return err
 	}
 
-	_, err = c.delete(u)
+	_, err = c.delete(ctx, u)
 	return err
 }
 
@@ -914,157 +1480,386 @@ func GetAccount() (*Account, error) {
 	return DefaultClient.GetAccount()
 }
 
+// GetAccountWithContext returns the user's account information
+// using the default Alpaca client.
+func GetAccountWithContext(ctx context.Context) (*Account, error) {
+	return DefaultClient.GetAccountWithContext(ctx)
+}
+
 // GetAccountConfigurations returns the current account configurations
 func GetAccountConfigurations() (*AccountConfigurations, error) {
 	return DefaultClient.GetAccountConfigurations()
 }
 
+// GetAccountConfigurationsWithContext returns the current account configurations
+func GetAccountConfigurationsWithContext(ctx context.Context) (*AccountConfigurations, error) {
+	return DefaultClient.GetAccountConfigurationsWithContext(ctx)
+}
+
 // UpdateAccountConfigurations updates the account configs.
 func UpdateAccountConfigurations(req UpdateAccountConfigurationsRequest) (*AccountConfigurations, error) {
 	return DefaultClient.UpdateAccountConfigurations(req)
 }
 
+// UpdateAccountConfigurationsWithContext updates the account configs.
+func UpdateAccountConfigurationsWithContext(ctx context.Context, req UpdateAccountConfigurationsRequest) (*AccountConfigurations, error) {
+	return DefaultClient.UpdateAccountConfigurationsWithContext(ctx, req)
+}
+
 // GetAccountActivities returns the account activities.
 func GetAccountActivities(req GetAccountActivitiesRequest) ([]AccountActivity, error) {
 	return DefaultClient.GetAccountActivities(req)
 }
 
+// GetAccountActivitiesWithContext returns the account activities.
+func GetAccountActivitiesWithContext(ctx context.Context, req GetAccountActivitiesRequest) ([]AccountActivity, error) {
+	return DefaultClient.GetAccountActivitiesWithContext(ctx, req)
+}
+
+// ForEachAccountActivity calls callback for every account activity matching req.
+func ForEachAccountActivity(req GetAccountActivitiesRequest, callback func(activity AccountActivity, err error) (keepGoing bool)) error {
+	return DefaultClient.ForEachAccountActivity(req, callback)
+}
+
+// ForEachAccountActivityWithContext calls callback for every account activity matching req.
+func ForEachAccountActivityWithContext(
+	ctx context.Context, req GetAccountActivitiesRequest, callback func(activity AccountActivity, err error) (keepGoing bool),
+) error {
+	return DefaultClient.ForEachAccountActivityWithContext(ctx, req, callback)
+}
+
 // GetPortfolioHistory returns the portfolio history.
 func GetPortfolioHistory(req GetPortfolioHistoryRequest) (*PortfolioHistory, error) {
 	return DefaultClient.GetPortfolioHistory(req)
 }
 
+// GetPortfolioHistoryWithContext returns the portfolio history.
+func GetPortfolioHistoryWithContext(ctx context.Context, req GetPortfolioHistoryRequest) (*PortfolioHistory, error) {
+	return DefaultClient.GetPortfolioHistoryWithContext(ctx, req)
+}
+
 // GetPositions lists the account's open positions.
 func GetPositions() ([]Position, error) {
 	return DefaultClient.GetPositions()
 }
 
+// GetPositionsWithContext lists the account's open positions.
+func GetPositionsWithContext(ctx context.Context) ([]Position, error) {
+	return DefaultClient.GetPositionsWithContext(ctx)
+}
+
 // GetPosition returns the account's position for the provided symbol.
 func GetPosition(symbol string) (*Position, error) {
 	return DefaultClient.GetPosition(symbol)
 }
 
+// GetPositionWithContext returns the account's position for the provided symbol.
+func GetPositionWithContext(ctx context.Context, symbol string) (*Position, error) {
+	return DefaultClient.GetPositionWithContext(ctx, symbol)
+}
+
 // CloseAllPositions liquidates all open positions at market price.
 func CloseAllPositions(req CloseAllPositionsRequest) ([]Order, error) {
 	return DefaultClient.CloseAllPositions(req)
 }
 
+// CloseAllPositionsWithContext liquidates all open positions at market price.
+func CloseAllPositionsWithContext(ctx context.Context, req CloseAllPositionsRequest) ([]Order, error) {
+	return DefaultClient.CloseAllPositionsWithContext(ctx, req)
+}
+
+// CloseAllPositionsResults liquidates all open positions at market price
+// and returns the outcome for every position.
+func CloseAllPositionsResults(req CloseAllPositionsRequest) ([]ClosePositionResult, error) {
+	return DefaultClient.CloseAllPositionsResults(req)
+}
+
+// CloseAllPositionsResultsWithContext liquidates all open positions at market price
+// and returns the outcome for every position.
+func CloseAllPositionsResultsWithContext(ctx context.Context, req CloseAllPositionsRequest) ([]ClosePositionResult, error) {
+	return DefaultClient.CloseAllPositionsResultsWithContext(ctx, req)
+}
+
 // ClosePosition liquidates the position for the given symbol at market price.
 func ClosePosition(symbol string, req ClosePositionRequest) (*Order, error) {
 	return DefaultClient.ClosePosition(symbol, req)
 }
 
+// ClosePositionWithContext liquidates the position for the given symbol at market price.
+func ClosePositionWithContext(ctx context.Context, symbol string, req ClosePositionRequest) (*Order, error) {
+	return DefaultClient.ClosePositionWithContext(ctx, symbol, req)
+}
+
 // GetClock returns the current market clock.
 func GetClock() (*Clock, error) {
 	return DefaultClient.GetClock()
 }
 
+// GetClockWithContext returns the current market clock.
+func GetClockWithContext(ctx context.Context) (*Clock, error) {
+	return DefaultClient.GetClockWithContext(ctx)
+}
+
 // GetCalendar returns the market calendar.
 func GetCalendar(req GetCalendarRequest) ([]CalendarDay, error) {
 	return DefaultClient.GetCalendar(req)
 }
 
+// GetCalendarWithContext returns the market calendar.
+func GetCalendarWithContext(ctx context.Context, req GetCalendarRequest) ([]CalendarDay, error) {
+	return DefaultClient.GetCalendarWithContext(ctx, req)
+}
+
 // GetOrders returns the list of orders for an account.
 func GetOrders(req GetOrdersRequest) ([]Order, error) {
 	return DefaultClient.GetOrders(req)
 }
 
+// GetOrdersWithContext returns the list of orders for an account.
+func GetOrdersWithContext(ctx context.Context, req GetOrdersRequest) ([]Order, error) {
+	return DefaultClient.GetOrdersWithContext(ctx, req)
+}
+
 // PlaceOrder submits an order request to buy or sell an asset.
 func PlaceOrder(req PlaceOrderRequest) (*Order, error) {
 	return DefaultClient.PlaceOrder(req)
 }
 
+// PlaceOrderWithContext submits an order request to buy or sell an asset.
+func PlaceOrderWithContext(ctx context.Context, req PlaceOrderRequest) (*Order, error) {
+	return DefaultClient.PlaceOrderWithContext(ctx, req)
+}
+
 // GetOrder submits a request to get an order by the order ID.
 func GetOrder(orderID string) (*Order, error) {
 	return DefaultClient.GetOrder(orderID)
 }
 
+// GetOrderWithContext submits a request to get an order by the order ID.
+func GetOrderWithContext(ctx context.Context, orderID string) (*Order, error) {
+	return DefaultClient.GetOrderWithContext(ctx, orderID)
+}
+
 // GetOrderByClientOrderID submits a request to get an order by the client order ID.
 func GetOrderByClientOrderID(clientOrderID string) (*Order, error) {
 	return DefaultClient.GetOrderByClientOrderID(clientOrderID)
 }
 
+// GetOrderByClientOrderIDWithContext submits a request to get an order by the client order ID.
+func GetOrderByClientOrderIDWithContext(ctx context.Context, clientOrderID string) (*Order, error) {
+	return DefaultClient.GetOrderByClientOrderIDWithContext(ctx, clientOrderID)
+}
+
 // ReplaceOrder submits a request to replace an order by id
 func ReplaceOrder(orderID string, req ReplaceOrderRequest) (*Order, error) {
 	return DefaultClient.ReplaceOrder(orderID, req)
 }
 
+// ReplaceOrderWithContext submits a request to replace an order by id
+func ReplaceOrderWithContext(ctx context.Context, orderID string, req ReplaceOrderRequest) (*Order, error) {
+	return DefaultClient.ReplaceOrderWithContext(ctx, orderID, req)
+}
+
 // CancelOrder submits a request to cancel an open order.
 func CancelOrder(orderID string) error {
 	return DefaultClient.CancelOrder(orderID)
 }
 
+// CancelOrderWithContext submits a request to cancel an open order.
+func CancelOrderWithContext(ctx context.Context, orderID string) error {
+	return DefaultClient.CancelOrderWithContext(ctx, orderID)
+}
+
+// Flatten cancels all open orders and liquidates all positions, retrying only the failures.
+func Flatten(req FlattenRequest) (*FlattenResult, error) {
+	return DefaultClient.Flatten(req)
+}
+
+// FlattenWithContext cancels all open orders and liquidates all positions, retrying only the failures.
+func FlattenWithContext(ctx context.Context, req FlattenRequest) (*FlattenResult, error) {
+	return DefaultClient.FlattenWithContext(ctx, req)
+}
+
+// CancelAllOrdersResults submits a request to cancel all orders and returns the outcome for every order.
+func CancelAllOrdersResults() ([]CancelOrderResult, error) {
+	return DefaultClient.CancelAllOrdersResults()
+}
+
+// CancelAllOrdersResultsWithContext submits a request to cancel all orders and returns the outcome for every order.
+func CancelAllOrdersResultsWithContext(ctx context.Context) ([]CancelOrderResult, error) {
+	return DefaultClient.CancelAllOrdersResultsWithContext(ctx)
+}
+
 // CancelAllOrders submits a request to cancel all orders.
 func CancelAllOrders() error {
 	return DefaultClient.CancelAllOrders()
 }
 
+// CancelAllOrdersWithContext submits a request to cancel all orders.
+func CancelAllOrdersWithContext(ctx context.Context) error {
+	return DefaultClient.CancelAllOrdersWithContext(ctx)
+}
+
 // GetAssets returns the list of assets.
 func GetAssets(req GetAssetsRequest) ([]Asset, error) {
 	return DefaultClient.GetAssets(req)
 }
 
+// GetAssetsWithContext returns the list of assets.
+func GetAssetsWithContext(ctx context.Context, req GetAssetsRequest) ([]Asset, error) {
+	return DefaultClient.GetAssetsWithContext(ctx, req)
+}
+
 // GetAsset returns an asset for the given symbol.
 func GetAsset(symbol string) (*Asset, error) {
 	return DefaultClient.GetAsset(symbol)
 }
 
+// GetAssetWithContext returns an asset for the given symbol.
+func GetAssetWithContext(ctx context.Context, symbol string) (*Asset, error) {
+	return DefaultClient.GetAssetWithContext(ctx, symbol)
+}
+
+// GetOptionContracts returns the option contracts matching the request's filters.
+func GetOptionContracts(req GetOptionContractsRequest) ([]OptionContract, error) {
+	return DefaultClient.GetOptionContracts(req)
+}
+
+// GetOptionContractsWithContext returns the option contracts matching the request's filters.
+func GetOptionContractsWithContext(ctx context.Context, req GetOptionContractsRequest) ([]OptionContract, error) {
+	return DefaultClient.GetOptionContractsWithContext(ctx, req)
+}
+
+// GetOptionContract returns an option contract by its symbol or ID.
+func GetOptionContract(symbolOrID string) (*OptionContract, error) {
+	return DefaultClient.GetOptionContract(symbolOrID)
+}
+
+// GetOptionContractWithContext returns an option contract by its symbol or ID.
+func GetOptionContractWithContext(ctx context.Context, symbolOrID string) (*OptionContract, error) {
+	return DefaultClient.GetOptionContractWithContext(ctx, symbolOrID)
+}
+
+// ExercisePosition exercises a held long option position.
+func ExercisePosition(symbolOrContractID string) error {
+	return DefaultClient.ExercisePosition(symbolOrContractID)
+}
+
+// ExercisePositionWithContext exercises a held long option position.
+func ExercisePositionWithContext(ctx context.Context, symbolOrContractID string) error {
+	return DefaultClient.ExercisePositionWithContext(ctx, symbolOrContractID)
+}
+
 // GetAnnouncements returns a list of announcements
 // with the default Alpaca client.
 func GetAnnouncements(req GetAnnouncementsRequest) ([]Announcement, error) {
 	return DefaultClient.GetAnnouncements(req)
 }
 
+// GetAnnouncementsWithContext returns a list of announcements
+// with the default Alpaca client.
+func GetAnnouncementsWithContext(ctx context.Context, req GetAnnouncementsRequest) ([]Announcement, error) {
+	return DefaultClient.GetAnnouncementsWithContext(ctx, req)
+}
+
 // GetAnnouncement returns a single announcement
 // with the default Alpaca client.
 func GetAnnouncement(announcementID string) (*Announcement, error) {
 	return DefaultClient.GetAnnouncement(announcementID)
 }
 
+// GetAnnouncementWithContext returns a single announcement
+// with the default Alpaca client.
+func GetAnnouncementWithContext(ctx context.Context, announcementID string) (*Announcement, error) {
+	return DefaultClient.GetAnnouncementWithContext(ctx, announcementID)
+}
+
 // GetWatchlists returns a list of watchlists
 // with the default Alpaca client.
 func GetWatchlists() ([]Watchlist, error) {
 	return DefaultClient.GetWatchlists()
 }
 
+// GetWatchlistsWithContext returns a list of watchlists
+// with the default Alpaca client.
+func GetWatchlistsWithContext(ctx context.Context) ([]Watchlist, error) {
+	return DefaultClient.GetWatchlistsWithContext(ctx)
+}
+
 // CreateWatchlist creates a new watchlist
 // with the default Alpaca client.
 func CreateWatchlist(req CreateWatchlistRequest) (*Watchlist, error) {
 	return DefaultClient.CreateWatchlist(req)
 }
 
+// CreateWatchlistWithContext creates a new watchlist
+// with the default Alpaca client.
+func CreateWatchlistWithContext(ctx context.Context, req CreateWatchlistRequest) (*Watchlist, error) {
+	return DefaultClient.CreateWatchlistWithContext(ctx, req)
+}
+
 // GetWatchlist returns a single watchlist by getting the watchlist id
 // with the default Alpaca client.
 func GetWatchlist(watchlistID string) (*Watchlist, error) {
 	return DefaultClient.GetWatchlist(watchlistID)
 }
 
+// GetWatchlistWithContext returns a single watchlist by getting the watchlist id
+// with the default Alpaca client.
+func GetWatchlistWithContext(ctx context.Context, watchlistID string) (*Watchlist, error) {
+	return DefaultClient.GetWatchlistWithContext(ctx, watchlistID)
+}
+
 // UpdateWatchlist updates a watchlist by getting the watchlist id
 // with the default Alpaca client.
 func UpdateWatchlist(watchlistID string, req UpdateWatchlistRequest) (*Watchlist, error) {
 	return DefaultClient.UpdateWatchlist(watchlistID, req)
 }
 
+// UpdateWatchlistWithContext updates a watchlist by getting the watchlist id
+// with the default Alpaca client.
+func UpdateWatchlistWithContext(ctx context.Context, watchlistID string, req UpdateWatchlistRequest) (*Watchlist, error) {
+	return DefaultClient.UpdateWatchlistWithContext(ctx, watchlistID, req)
+}
+
 // DeleteWatchlist deletes a watchlist by getting the watchlist id
 // with the default Alpaca client.
 func DeleteWatchlist(watchlistID string) error {
 	return DefaultClient.DeleteWatchlist(watchlistID)
 }
 
+// DeleteWatchlistWithContext deletes a watchlist by getting the watchlist id
+// with the default Alpaca client.
+func DeleteWatchlistWithContext(ctx context.Context, watchlistID string) error {
+	return DefaultClient.DeleteWatchlistWithContext(ctx, watchlistID)
+}
+
 // AddSymbolToWatchlist adds an asset to a watchlist by getting the watchlist id
 // with the default Alpaca client.
 func AddSymbolToWatchlist(watchlistID string, req AddSymbolToWatchlistRequest) (*Watchlist, error) {
 	return DefaultClient.AddSymbolToWatchlist(watchlistID, req)
 }
 
+// AddSymbolToWatchlistWithContext adds an asset to a watchlist by getting the watchlist id
+// with the default Alpaca client.
+func AddSymbolToWatchlistWithContext(ctx context.Context, watchlistID string, req AddSymbolToWatchlistRequest) (*Watchlist, error) {
+	return DefaultClient.AddSymbolToWatchlistWithContext(ctx, watchlistID, req)
+}
+
 // RemoveSymbolFromWatchlist removes an asset from a watchlist by getting the watchlist id
 // with the default Alpaca client.
 func RemoveSymbolFromWatchlist(watchlistID string, req RemoveSymbolFromWatchlistRequest) error {
 	return DefaultClient.RemoveSymbolFromWatchlist(watchlistID, req)
 }
 
-func (c *Client) get(u *url.URL) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+// RemoveSymbolFromWatchlistWithContext removes an asset from a watchlist by getting the watchlist id
+// with the default Alpaca client.
+func RemoveSymbolFromWatchlistWithContext(ctx context.Context, watchlistID string, req RemoveSymbolFromWatchlistRequest) error {
+	return DefaultClient.RemoveSymbolFromWatchlistWithContext(ctx, watchlistID, req)
+}
+
+func (c *Client) get(ctx context.Context, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1072,13 +1867,17 @@ func (c *Client) get(u *url.URL) (*http.Response, error) {
 	return c.do(c, req)
 }
 
-func (c *Client) post(u *url.URL, data interface{}) (*http.Response, error) {
-	buf, err := json.Marshal(data)
-	if err != nil {
-		return nil, err
+func (c *Client) post(ctx context.Context, u *url.URL, data interface{}) (*http.Response, error) {
+	var body io.Reader
+	if data != nil {
+		buf, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(buf)
 	}
 
-	req, err := http.NewRequest(http.MethodPost, u.String(), bytes.NewReader(buf))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
@@ -1086,13 +1885,13 @@ func (c *Client) post(u *url.URL, data interface{}) (*http.Response, error) {
 	return c.do(c, req)
 }
 
-func (c *Client) put(u *url.URL, data interface{}) (*http.Response, error) {
+func (c *Client) put(ctx context.Context, u *url.URL, data interface{}) (*http.Response, error) {
 	buf, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPut, u.String(), bytes.NewReader(buf))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.String(), bytes.NewReader(buf))
 	if err != nil {
 		return nil, err
 	}
@@ -1100,13 +1899,13 @@ func (c *Client) put(u *url.URL, data interface{}) (*http.Response, error) {
 	return c.do(c, req)
 }
 
-func (c *Client) patch(u *url.URL, data interface{}) (*http.Response, error) {
+func (c *Client) patch(ctx context.Context, u *url.URL, data interface{}) (*http.Response, error) {
 	buf, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPatch, u.String(), bytes.NewReader(buf))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, u.String(), bytes.NewReader(buf))
 	if err != nil {
 		return nil, err
 	}
@@ -1114,8 +1913,8 @@ func (c *Client) patch(u *url.URL, data interface{}) (*http.Response, error) {
 	return c.do(c, req)
 }
 
-func (c *Client) delete(u *url.URL) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodDelete, u.String(), nil)
+func (c *Client) delete(ctx context.Context, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1123,7 +1922,21 @@ func (c *Client) delete(u *url.URL) (*http.Response, error) {
 	return c.do(c, req)
 }
 
-func verify(resp *http.Response) error {
+// sleepContext pauses for d or until ctx is done, whichever happens first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// Verify returns nil if resp is successful. Otherwise it closes the body of resp and returns
+// the error read from it with APIErrorFromResponse. It is shared by the clients of this module.
+func Verify(resp *http.Response) error {
 	if resp.StatusCode >= http.StatusMultipleChoices {
 		defer resp.Body.Close()
 		return APIErrorFromResponse(resp)
@@ -1132,10 +1945,6 @@ func verify(resp *http.Response) error {
 }
 
 func unmarshal(resp *http.Response, v easyjson.Unmarshaler) error {
-	defer func() {
-		// The underlying TCP connection can not be reused if the body is not fully read
-		_, _ = io.Copy(io.Discard, resp.Body)
-		resp.Body.Close()
-	}()
+	defer httpbody.Close(resp)
 	return easyjson.UnmarshalFromReader(resp.Body, v)
 }
@@ -2,6 +2,7 @@ package alpaca
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -82,6 +83,24 @@ func TestDefaultDo_TooManyRetries(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestDefaultDo_ContextCancelledDuringRetry(t *testing.T) {
+	called := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called++
+		http.Error(w, "too many requests", http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+	c := NewClient(ClientOpts{
+		BaseURL:    ts.URL,
+		RetryDelay: time.Hour,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.GetAccountWithContext(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, called)
+}
+
 func TestDefaultDo_Error(t *testing.T) {
 	resp := `{"code":1234567,"message":"custom error message","other_field":"x"}`
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -127,6 +146,29 @@ func TestGetAccount(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestGetAccountWithContext(t *testing.T) {
+	c := DefaultClient
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "value", req.Context().Value(ctxKey{}))
+		return &http.Response{
+			Body: genBody(Account{ID: "some_id"}),
+		}, nil
+	}
+
+	acct, err := c.GetAccountWithContext(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "some_id", acct.ID)
+
+	// cancelled
+	c.do = defaultDo
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.GetAccountWithContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestGetPositions(t *testing.T) {
 	c := DefaultClient
 
@@ -197,8 +239,67 @@ func TestCancelAllPositions(t *testing.T) {
 		CancelOrders: true,
 	})
 	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TSLA")
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, 42210000, apiErr.Code)
 	assert.Len(t, gotOrders, 1)
 	assert.Equal(t, "AAPL", gotOrders[0].Symbol)
+
+	results, err := c.CloseAllPositionsResults(CloseAllPositionsRequest{CancelOrders: true})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "AAPL", results[0].Symbol)
+	assert.Equal(t, http.StatusOK, results[0].StatusCode)
+	require.NotNil(t, results[0].Order)
+	assert.Equal(t, "0571ce61-bf65-4f0c-b3de-6f42ce628422", results[0].Order.ID)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "TSLA", results[1].Symbol)
+	assert.Equal(t, http.StatusUnprocessableEntity, results[1].StatusCode)
+	assert.Nil(t, results[1].Order)
+	require.ErrorAs(t, results[1].Err, &apiErr)
+	assert.Equal(t, "error", apiErr.Message)
+}
+
+func TestCloseAllPositionsWithoutOrder(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusMultiStatus,
+			Body:       io.NopCloser(strings.NewReader(`[{"symbol":"AAPL","status":200}]`)),
+		}, nil
+	}
+	orders, err := c.CloseAllPositions(CloseAllPositionsRequest{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "AAPL: missing order in response")
+	assert.Empty(t, orders)
+}
+
+func TestCancelAllOrdersResults(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/v2/orders", req.URL.Path)
+		assert.Equal(t, http.MethodDelete, req.Method)
+		return &http.Response{
+			StatusCode: http.StatusMultiStatus,
+			Body: io.NopCloser(strings.NewReader(`[
+				{"id": "order1", "status": 200, "body": {"id": "order1", "symbol": "AAPL", "status": "pending_cancel"}},
+				{"id": "order2", "status": 500}
+			]`)),
+		}, nil
+	}
+	results, err := c.CancelAllOrdersResults()
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "order1", results[0].OrderID)
+	assert.NoError(t, results[0].Err)
+	require.NotNil(t, results[0].Order)
+	assert.Equal(t, OrderStatusPendingCancel, results[0].Order.Status)
+	assert.Equal(t, "order2", results[1].OrderID)
+	assert.Equal(t, http.StatusInternalServerError, results[1].StatusCode)
+	var apiErr *APIError
+	require.ErrorAs(t, results[1].Err, &apiErr)
+	assert.Equal(t, "Internal Server Error", apiErr.Message)
 }
 
 func TestGetClock(t *testing.T) {
@@ -830,6 +931,120 @@ func TestGetAsset(t *testing.T) {
 	assert.Nil(t, asset)
 }
 
+func TestGetOptionContracts(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	pages := 0
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/v2/options/contracts", req.URL.Path)
+		q := req.URL.Query()
+		assert.Equal(t, "AAPL,SPY", q.Get("underlying_symbols"))
+		assert.Equal(t, "call", q.Get("type"))
+		assert.Equal(t, "2025-06-20", q.Get("expiration_date_lte"))
+		assert.Equal(t, "150", q.Get("strike_price_gte"))
+		assert.Equal(t, "3", q.Get("limit"))
+		pages++
+		if pages == 1 {
+			assert.Empty(t, q.Get("page_token"))
+			return &http.Response{
+				Body: io.NopCloser(strings.NewReader(`{
+					"option_contracts": [
+						{"id": "c1", "symbol": "AAPL250620C00200000", "type": "call", "style": "american",
+						 "expiration_date": "2025-06-20", "strike_price": "200", "multiplier": "100", "size": "100",
+						 "open_interest": "1234", "open_interest_date": "2025-06-01", "close_price": null,
+						 "deliverables": [{"type": "equity", "symbol": "AAPL", "amount": "100",
+						                   "allocation_percentage": "100", "settlement_type": "T+1",
+						                   "settlement_method": "CCC", "delayed_settlement": false}]},
+						{"id": "c2", "symbol": "AAPL250620C00210000", "type": "call"}
+					],
+					"next_page_token": "MTIz"
+				}`)),
+			}, nil
+		}
+		assert.Equal(t, "MTIz", q.Get("page_token"))
+		return &http.Response{
+			Body: io.NopCloser(strings.NewReader(`{
+				"option_contracts": [{"id": "c3", "symbol": "SPY250620C00500000", "type": "call"}],
+				"next_page_token": null
+			}`)),
+		}, nil
+	}
+
+	contracts, err := c.GetOptionContracts(GetOptionContractsRequest{
+		UnderlyingSymbols: []string{"AAPL", "SPY"},
+		Type:              Call,
+		ExpirationDateLte: civil.Date{Year: 2025, Month: 6, Day: 20},
+		StrikePriceGte:    decimal.NewFromInt(150),
+		PageLimit:         3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, pages)
+	require.Len(t, contracts, 3)
+	first := contracts[0]
+	assert.Equal(t, American, first.Style)
+	assert.Equal(t, civil.Date{Year: 2025, Month: 6, Day: 20}, first.ExpirationDate)
+	assert.True(t, decimal.NewFromInt(200).Equal(first.StrikePrice))
+	require.NotNil(t, first.OpenInterest)
+	assert.True(t, decimal.NewFromInt(1234).Equal(*first.OpenInterest))
+	assert.Nil(t, first.ClosePrice)
+	require.Len(t, first.Deliverables, 1)
+	assert.Equal(t, "AAPL", first.Deliverables[0].Symbol)
+	assert.Equal(t, "c3", contracts[2].ID)
+
+	// total limit
+	pages = 0
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		pages++
+		assert.Equal(t, "2", req.URL.Query().Get("limit"))
+		return &http.Response{
+			Body: io.NopCloser(strings.NewReader(`{
+				"option_contracts": [{"id": "c1"}, {"id": "c2"}],
+				"next_page_token": "MTIz"
+			}`)),
+		}, nil
+	}
+	contracts, err = c.GetOptionContracts(GetOptionContractsRequest{TotalLimit: 2})
+	require.NoError(t, err)
+	assert.Len(t, contracts, 2)
+	assert.Equal(t, 1, pages)
+
+	// api failure
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		return &http.Response{}, fmt.Errorf("fail")
+	}
+	_, err = c.GetOptionContracts(GetOptionContractsRequest{})
+	require.Error(t, err)
+}
+
+func TestGetOptionContract(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/v2/options/contracts/AAPL250620C00200000", req.URL.Path)
+		return &http.Response{
+			Body: io.NopCloser(strings.NewReader(`{"id": "c1", "symbol": "AAPL250620C00200000", "type": "put"}`)),
+		}, nil
+	}
+	contract, err := c.GetOptionContract("AAPL250620C00200000")
+	require.NoError(t, err)
+	assert.Equal(t, "c1", contract.ID)
+	assert.Equal(t, Put, contract.Type)
+}
+
+func TestExercisePosition(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/v2/positions/AAPL250620C00200000/exercise", req.URL.Path)
+		assert.Nil(t, req.Body)
+		return &http.Response{Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+	require.NoError(t, c.ExercisePosition("AAPL250620C00200000"))
+
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		return nil, &APIError{StatusCode: http.StatusForbidden, Message: "position is not exercisable"}
+	}
+	require.Error(t, c.ExercisePosition("AAPL250620C00200000"))
+}
+
 func TestGetAssetFromJSON(t *testing.T) {
 	c := DefaultClient
 
@@ -875,7 +1090,7 @@ func TestTestVerify(t *testing.T) {
 		StatusCode: http.StatusOK,
 	}
 
-	assert.Nil(t, verify(resp))
+	assert.Nil(t, Verify(resp))
 
 	// 500
 	resp = &http.Response{
@@ -883,7 +1098,7 @@ func TestTestVerify(t *testing.T) {
 		Body:       genBody(APIError{Code: 1010101, Message: "server is dead"}),
 	}
 
-	assert.NotNil(t, verify(resp))
+	assert.NotNil(t, Verify(resp))
 }
 
 func TestOTOCOOrders(t *testing.T) {
@@ -974,13 +1189,13 @@ func TestGetAccountActivities(t *testing.T) {
 	}
 
 	activities, err := c.GetAccountActivities(GetAccountActivitiesRequest{
-		ActivityTypes: []string{"DIV", "FILL"},
+		ActivityTypes: []ActivityType{ActivityDividend, ActivityFill},
 	})
 	assert.NoError(t, err)
 	assert.Len(t, activities, 3)
 	activity1 := activities[0]
 	assert.Equal(t, civil.Date{Year: 2019, Month: 8, Day: 1}, activity1.Date)
-	assert.Equal(t, "DIV", activity1.ActivityType)
+	assert.Equal(t, ActivityDividend, activity1.ActivityType)
 	assert.Equal(t, "20190801011955195::5f596936-6f23-4cef-bdf1-3806aae57dbf", activity1.ID)
 	assert.True(t, decimal.NewFromFloat(1.02).Equal(activity1.NetAmount))
 	assert.Equal(t, "T", activity1.Symbol)
@@ -989,7 +1204,7 @@ func TestGetAccountActivities(t *testing.T) {
 	assert.Equal(t, "executed", activity1.Status)
 	activity2 := activities[1]
 	assert.Equal(t, civil.Date{Year: 2019, Month: 8, Day: 1}, activity2.Date)
-	assert.Equal(t, "DIV", activity2.ActivityType)
+	assert.Equal(t, ActivityDividend, activity2.ActivityType)
 	assert.Equal(t, "20190801011955195::5f596936-6f23-4cef-bdf1-3806aae57dbd", activity2.ID)
 	assert.True(t, decimal.NewFromInt(5).Equal(activity2.NetAmount))
 	assert.Equal(t, "AAPL", activity2.Symbol)
@@ -997,18 +1212,18 @@ func TestGetAccountActivities(t *testing.T) {
 	assert.Equal(t, decimal.NewFromInt(100), activity2.PerShareAmount)
 	assert.Equal(t, "executed", activity2.Status)
 	activity3 := activities[2]
-	assert.Equal(t, "FILL", activity3.ActivityType)
+	assert.Equal(t, ActivityFill, activity3.ActivityType)
 	assert.Equal(t, "20240624093004214::18a82342-245e-4e8a-9703-87ae38d9b629", activity3.ID)
 	assert.Equal(t, "2024-06-24T13:30:04.214535Z", activity3.TransactionTime.Format("2006-01-02T15:04:05.999999Z"))
-	assert.Equal(t, "partial_fill", activity3.Type)
+	assert.Equal(t, FillTypePartialFill, activity3.Type)
 	assert.True(t, decimal.NewFromFloat32(3.8).Equal(activity3.Price))
 	assert.Equal(t, decimal.NewFromInt(643), activity3.Qty)
-	assert.Equal(t, "sell", activity3.Side)
+	assert.Equal(t, Sell, activity3.Side)
 	assert.Equal(t, "AAPL", activity3.Symbol)
 	assert.Equal(t, decimal.NewFromInt(1457), activity3.LeavesQty)
 	assert.Equal(t, "c0e497c2-a547-48cd-85dc-0f1f0ed1b26c", activity3.OrderID)
 	assert.Equal(t, decimal.NewFromInt(643), activity3.CumQty)
-	assert.Equal(t, "partially_filled", activity3.OrderStatus)
+	assert.Equal(t, OrderStatusPartiallyFilled, activity3.OrderStatus)
 
 	// error was returned
 	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
@@ -1049,7 +1264,7 @@ func TestGetAccountActivities(t *testing.T) {
 	}
 
 	_, err = c.GetAccountActivities(GetAccountActivitiesRequest{
-		ActivityTypes: []string{"DIV"},
+		ActivityTypes: []ActivityType{ActivityDividend},
 		After:         time.Date(2019, 1, 1, 0, 0, 0, 100, time.UTC),
 		PageSize:      10,
 	})
@@ -0,0 +1,207 @@
+package alpaca
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/internal/httpbody"
+)
+
+// RetryPolicy decides whether a request should be retried and how long to wait before the next attempt.
+//
+// It is consulted after every attempt. attempt is the number of attempts made so far (starting at 1)
+// and elapsed is the time since the first attempt was started. Exactly one of resp and err is non-nil.
+type RetryPolicy interface {
+	Retry(attempt int, elapsed time.Duration, req *http.Request, resp *http.Response, err error) (delay time.Duration, retry bool)
+}
+
+// BackoffRetryPolicy is a RetryPolicy that waits an exponentially growing, optionally jittered delay
+// between attempts. Delays requested by the server through the Retry-After or X-RateLimit-Reset
+// headers take precedence over the computed delay.
+//
+// Requests that are not idempotent (POST and PATCH without an Idempotency-Key header) are only retried
+// when the server rejected them with 429 Too Many Requests, unless RetryNonIdempotent is set.
+type BackoffRetryPolicy struct {
+	// MaxRetries is the maximum number of retries. Zero means the request is never retried.
+	MaxRetries int
+	// BaseDelay is the delay before the first retry.
+	BaseDelay time.Duration
+	// MaxDelay caps a single delay, including the delays requested by the server. Zero means no cap.
+	MaxDelay time.Duration
+	// Multiplier is the growth factor of the delay between consecutive retries.
+	// Values less than or equal to 1 result in a constant delay.
+	Multiplier float64
+	// Jitter is the fraction (between 0 and 1) of the delay that is randomized.
+	Jitter float64
+	// MaxElapsedTime is the maximum time spent on a request including all its retries. Zero means no limit.
+	MaxElapsedTime time.Duration
+	// RetryStatusCodes are the HTTP status codes that trigger a retry.
+	RetryStatusCodes []int
+	// RetryNetworkErrors tells whether transport level errors (e.g. connection resets) trigger a retry.
+	RetryNetworkErrors bool
+	// RetryNonIdempotent allows retrying non-idempotent requests on any retryable failure.
+	RetryNonIdempotent bool
+	// IgnoreServerDelay disables honoring the Retry-After and X-RateLimit-Reset response headers.
+	IgnoreServerDelay bool
+}
+
+// DefaultMaxRetryDelay caps the delays of the default retry policy of the clients, including
+// the delays requested by the server, unless their RetryDelay is longer.
+const DefaultMaxRetryDelay = 10 * time.Second
+
+// NewBackoffRetryPolicy returns a BackoffRetryPolicy with exponential backoff and jitter that retries
+// on 429, 502, 503, 504 and on network errors.
+func NewBackoffRetryPolicy(maxRetries int, baseDelay, maxDelay time.Duration) *BackoffRetryPolicy {
+	return &BackoffRetryPolicy{
+		MaxRetries: maxRetries,
+		BaseDelay:  baseDelay,
+		MaxDelay:   maxDelay,
+		Multiplier: 2,
+		Jitter:     0.2,
+		RetryStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+	}
+}
+
+// Retry implements RetryPolicy.
+func (p *BackoffRetryPolicy) Retry(
+	attempt int, elapsed time.Duration, req *http.Request, resp *http.Response, err error,
+) (time.Duration, bool) {
+	if attempt > p.MaxRetries {
+		return 0, false
+	}
+	if !p.retryable(req, resp, err) {
+		return 0, false
+	}
+	delay := p.backoff(attempt)
+	if resp != nil && !p.IgnoreServerDelay {
+		if d, ok := ServerRetryDelay(resp); ok {
+			delay = d
+			if p.MaxDelay > 0 && delay > p.MaxDelay {
+				delay = p.MaxDelay
+			}
+		}
+	}
+	if p.MaxElapsedTime > 0 && elapsed+delay > p.MaxElapsedTime {
+		return 0, false
+	}
+	return delay, true
+}
+
+func (p *BackoffRetryPolicy) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if !p.RetryNetworkErrors || req.Context().Err() != nil {
+			return false
+		}
+		return p.RetryNonIdempotent || isIdempotent(req)
+	}
+	if !containsInt(p.RetryStatusCodes, resp.StatusCode) {
+		return false
+	}
+	// The server did not process a rate limited request, so it is always safe to resend it.
+	return resp.StatusCode == http.StatusTooManyRequests || p.RetryNonIdempotent || isIdempotent(req)
+}
+
+func (p *BackoffRetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.BaseDelay)
+	if p.Multiplier > 1 {
+		delay *= math.Pow(p.Multiplier, float64(attempt-1))
+	}
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay -= delay * jitter * rand.Float64() //nolint:gosec // jitter does not need a secure random source
+	}
+	return time.Duration(delay)
+}
+
+// ServerRetryDelay returns how long the server asked the client to wait before retrying, based on the
+// Retry-After header (either delay-seconds or an HTTP date) or on the X-RateLimit-Reset header (unix seconds).
+func ServerRetryDelay(resp *http.Response) (time.Duration, bool) {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(time.Until(t)), true
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if v := resp.Header.Get("X-RateLimit-Reset"); v != "" {
+			if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return nonNegative(time.Until(time.Unix(secs, 0))), true
+			}
+		}
+	}
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// isIdempotent follows the same rules as net/http: a request is idempotent if its method is
+// idempotent or if it carries an Idempotency-Key or X-Idempotency-Key header.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	if _, ok := req.Header["Idempotency-Key"]; ok {
+		return true
+	}
+	if _, ok := req.Header["X-Idempotency-Key"]; ok {
+		return true
+	}
+	return false
+}
+
+func containsInt(s []int, v int) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// DoWithRetry sends req using client, consulting policy after each attempt. Between attempts it waits for
+// the delay returned by the policy or until the request's context is done. The request body is rewound using
+// req.GetBody before each retry. The last response or error is returned.
+func DoWithRetry(client *http.Client, req *http.Request, policy RetryPolicy) (*http.Response, error) {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		resp, err := client.Do(req)
+		delay, retry := policy.Retry(attempt, time.Since(start), req, resp, err)
+		if !retry {
+			return resp, err
+		}
+		if resp != nil {
+			httpbody.Close(resp)
+		}
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package alpaca
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoffRetryPolicy_Backoff(t *testing.T) {
+	p := &BackoffRetryPolicy{
+		MaxRetries:       10,
+		BaseDelay:        100 * time.Millisecond,
+		MaxDelay:         time.Second,
+		Multiplier:       2,
+		RetryStatusCodes: []int{http.StatusServiceUnavailable},
+	}
+	req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	require.NoError(t, err)
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	for attempt, exp := range []time.Duration{
+		100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second,
+	} {
+		delay, retry := p.Retry(attempt+1, 0, req, resp, nil)
+		assert.True(t, retry)
+		assert.Equal(t, exp, delay)
+	}
+
+	_, retry := p.Retry(11, 0, req, resp, nil)
+	assert.False(t, retry, "max retries exceeded")
+
+	_, retry = p.Retry(1, 0, req, &http.Response{StatusCode: http.StatusBadRequest}, nil)
+	assert.False(t, retry, "non-retryable status")
+
+	p.MaxElapsedTime = time.Second
+	_, retry = p.Retry(3, 700*time.Millisecond, req, resp, nil)
+	assert.False(t, retry, "max elapsed time exceeded")
+}
+
+func TestBackoffRetryPolicy_Jitter(t *testing.T) {
+	p := &BackoffRetryPolicy{
+		MaxRetries:       1,
+		BaseDelay:        time.Second,
+		Jitter:           0.5,
+		RetryStatusCodes: []int{http.StatusTooManyRequests},
+	}
+	req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		delay, retry := p.Retry(1, 0, req, &http.Response{StatusCode: http.StatusTooManyRequests}, nil)
+		require.True(t, retry)
+		assert.GreaterOrEqual(t, delay, 500*time.Millisecond)
+		assert.LessOrEqual(t, delay, time.Second)
+	}
+}
+
+func TestBackoffRetryPolicy_ServerDelay(t *testing.T) {
+	p := NewBackoffRetryPolicy(3, time.Millisecond, 0)
+	req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	require.NoError(t, err)
+
+	resp := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": []string{"7"}},
+	}
+	delay, retry := p.Retry(1, 0, req, resp, nil)
+	assert.True(t, retry)
+	assert.Equal(t, 7*time.Second, delay)
+
+	resp.Header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	delay, retry = p.Retry(1, 0, req, resp, nil)
+	assert.True(t, retry)
+	assert.InDelta(t, time.Minute, delay, float64(2*time.Second))
+
+	resp = &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(time.Now().Add(30*time.Second).Unix(), 10)}},
+	}
+	delay, retry = p.Retry(1, 0, req, resp, nil)
+	assert.True(t, retry)
+	assert.InDelta(t, 30*time.Second, delay, float64(2*time.Second))
+
+	// MaxDelay also caps the delays of the server.
+	p.MaxDelay = 10 * time.Second
+	delay, retry = p.Retry(1, 0, req, resp, nil)
+	assert.True(t, retry)
+	assert.Equal(t, 10*time.Second, delay)
+
+	p.IgnoreServerDelay = true
+	p.Jitter = 0
+	delay, retry = p.Retry(1, 0, req, resp, nil)
+	assert.True(t, retry)
+	assert.Equal(t, time.Millisecond, delay)
+}
+
+func TestBackoffRetryPolicy_NonIdempotent(t *testing.T) {
+	p := NewBackoffRetryPolicy(3, time.Millisecond, 0)
+	req, err := http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("{}"))
+	require.NoError(t, err)
+
+	_, retry := p.Retry(1, 0, req, &http.Response{StatusCode: http.StatusTooManyRequests}, nil)
+	assert.True(t, retry, "rate limited requests were not processed")
+	_, retry = p.Retry(1, 0, req, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+	assert.False(t, retry)
+	_, retry = p.Retry(1, 0, req, nil, errors.New("connection reset"))
+	assert.False(t, retry)
+
+	req.Header.Set("Idempotency-Key", "key")
+	_, retry = p.Retry(1, 0, req, &http.Response{StatusCode: http.StatusServiceUnavailable}, nil)
+	assert.True(t, retry)
+
+	req.Header.Del("Idempotency-Key")
+	p.RetryNonIdempotent = true
+	_, retry = p.Retry(1, 0, req, nil, errors.New("connection reset"))
+	assert.True(t, retry)
+}
+
+func TestDoWithRetry_RewindsBody(t *testing.T) {
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) < 3 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, "success")
+	}))
+	defer ts.Close()
+	req, err := http.NewRequest(http.MethodPost, ts.URL, strings.NewReader(`{"symbol":"AAPL"}`))
+	require.NoError(t, err)
+	resp, err := DoWithRetry(http.DefaultClient, req, NewBackoffRetryPolicy(3, time.Hour, 0))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, []string{`{"symbol":"AAPL"}`, `{"symbol":"AAPL"}`, `{"symbol":"AAPL"}`}, bodies)
+}
+
+func TestDefaultDo_RetryPolicy(t *testing.T) {
+	called := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called++
+		if called < 3 {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"id":"some_id"}`)
+	}))
+	defer ts.Close()
+	c := NewClient(ClientOpts{
+		BaseURL:     ts.URL,
+		RetryPolicy: NewBackoffRetryPolicy(5, time.Nanosecond, time.Nanosecond),
+	})
+	acct, err := c.GetAccount()
+	require.NoError(t, err)
+	assert.Equal(t, "some_id", acct.ID)
+	assert.Equal(t, 3, called)
+
+	// the default policy does not retry 502s
+	called = 0
+	c = NewClient(ClientOpts{
+		BaseURL:    ts.URL,
+		RetryDelay: time.Nanosecond,
+	})
+	_, err = c.GetAccount()
+	require.Error(t, err)
+	assert.Equal(t, 1, called)
+}
+
+func TestDefaultRetryPolicy_MaxDelay(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	require.NoError(t, err)
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"X-Ratelimit-Reset": []string{strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)}},
+	}
+
+	delay, retry := NewClient(ClientOpts{}).opts.RetryPolicy.Retry(1, 0, req, resp, nil)
+	assert.True(t, retry)
+	assert.Equal(t, DefaultMaxRetryDelay, delay)
+
+	// A longer RetryDelay is not shortened.
+	delay, retry = NewClient(ClientOpts{RetryDelay: time.Hour}).opts.RetryPolicy.Retry(1, 0, req, resp, nil)
+	assert.True(t, retry)
+	assert.InDelta(t, time.Minute, delay, float64(2*time.Second))
+}
@@ -0,0 +1,574 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrRiskLimit is matched (with errors.Is) by the errors of orders rejected by a RiskManager.
+	ErrRiskLimit = errors.New("risk limit breached")
+	// ErrKillSwitch is returned by a RiskManager for every order while its kill switch is engaged.
+	ErrKillSwitch = errors.New("kill switch engaged")
+)
+
+// RiskRule is a limit enforced by a RiskManager.
+type RiskRule string
+
+const (
+	RiskRestrictedSymbol  RiskRule = "restricted_symbol"
+	RiskMaxOrderNotional  RiskRule = "max_order_notional"
+	RiskMaxSymbolNotional RiskRule = "max_symbol_notional"
+	RiskMaxGrossExposure  RiskRule = "max_gross_exposure"
+	RiskMaxNetExposure    RiskRule = "max_net_exposure"
+	RiskMaxOpenOrders     RiskRule = "max_open_orders"
+	RiskMaxDailyLoss      RiskRule = "max_daily_loss"
+	// RiskUnknownPrice rejects orders whose notional cannot be computed while a notional limit is set.
+	RiskUnknownPrice RiskRule = "unknown_price"
+	// RiskKillSwitch rejects orders while the kill switch is engaged.
+	RiskKillSwitch RiskRule = "kill_switch"
+	// RiskManualKill is the rule of the kill switches engaged by calling Kill.
+	RiskManualKill RiskRule = "manual_kill"
+)
+
+// RiskLimits are the limits enforced by a RiskManager. Zero values disable the limits.
+type RiskLimits struct {
+	// MaxOrderNotional is the maximum notional of a single order.
+	MaxOrderNotional decimal.Decimal
+	// MaxSymbolNotional is the maximum absolute market value of the position of a symbol
+	// once the order is filled.
+	MaxSymbolNotional decimal.Decimal
+	// MaxGrossExposure is the maximum sum of the absolute market values of all positions.
+	MaxGrossExposure decimal.Decimal
+	// MaxNetExposure is the maximum absolute sum of the market values of all positions,
+	// short positions counting negatively.
+	MaxNetExposure decimal.Decimal
+	// MaxOpenOrders is the maximum number of open orders, including the order being placed.
+	// At most 500 open orders (one page of GetOrders) are counted, so higher values are never reached.
+	MaxOpenOrders int
+	// MaxDailyLoss is the loss since the previous close (LastEquity - Equity of the account)
+	// that engages the kill switch.
+	MaxDailyLoss decimal.Decimal
+	// RestrictedSymbols can not be traded. Crypto pairs match with or without the slash,
+	// e.g. "BTCUSD" also restricts "BTC/USD".
+	RestrictedSymbols []string
+}
+
+// RiskError is the reason a RiskManager rejected an order or engaged its kill switch.
+type RiskError struct {
+	Rule   RiskRule
+	Symbol string
+	Reason string
+}
+
+func (e *RiskError) Error() string {
+	if e.Symbol == "" {
+		return fmt.Sprintf("%v: %s: %s", ErrRiskLimit, e.Rule, e.Reason)
+	}
+	return fmt.Sprintf("%v: %s: %s: %s", ErrRiskLimit, e.Rule, e.Symbol, e.Reason)
+}
+
+func (e *RiskError) Unwrap() error {
+	return ErrRiskLimit
+}
+
+// RiskEventType is the kind of decision a RiskEvent records.
+type RiskEventType string
+
+const (
+	RiskOrderAllowed      RiskEventType = "order_allowed"
+	RiskOrderRejected     RiskEventType = "order_rejected"
+	RiskKillSwitchEngaged RiskEventType = "kill_switch_engaged"
+	RiskKillSwitchReset   RiskEventType = "kill_switch_reset"
+)
+
+// RiskEvent is a decision of a RiskManager, for auditing.
+type RiskEvent struct {
+	Time time.Time
+	Type RiskEventType
+	// Rule is the rule that rejected the order or engaged the kill switch.
+	Rule   RiskRule
+	Symbol string
+	// OrderID is set for replacements, ClientOrderID for new orders.
+	OrderID       string
+	ClientOrderID string
+	// Notional is the notional of the order, if known.
+	Notional *decimal.Decimal
+	Reason   string
+	// Err is the error of liquidating the account when the kill switch is engaged.
+	Err error
+}
+
+// RiskOpts configures a RiskManager.
+type RiskOpts struct {
+	Limits RiskLimits
+	// Price returns the current price of a symbol. It is used to compute the notional of orders
+	// without a limit or stop price on symbols without a position. If nil, such orders are
+	// rejected while a notional or exposure limit is set.
+	Price func(ctx context.Context, symbol string) (decimal.Decimal, error)
+	// Flatten configures the retries of the liquidation done by the kill switch.
+	Flatten FlattenRequest
+	// OnEvent receives every decision of the manager.
+	OnEvent func(RiskEvent)
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// maxOpenOrdersChecked is the number of open orders fetched to check MaxOpenOrders.
+const maxOpenOrdersChecked = 500
+
+// RiskManager places and replaces orders through a Client after checking them against
+// risk limits. Orders breaching a limit are rejected with a *RiskError. A breach of the
+// daily loss limit, or of an exposure limit found by Check, engages the kill switch:
+// all orders are canceled, all positions are closed, and every further order is refused
+// with ErrKillSwitch until Reset is called.
+//
+// Exposures are computed from the current positions, assuming the order is filled
+// completely at its limit or stop price, or else at the current price. Other open orders
+// are not taken into account.
+type RiskManager struct {
+	client     *Client
+	opts       RiskOpts
+	restricted map[string]struct{}
+
+	// submitMu serializes the checks and submissions so that concurrent orders
+	// cannot pass the checks together.
+	submitMu sync.Mutex
+
+	mu         sync.Mutex
+	killed     bool
+	killReason string
+}
+
+// NewRiskManager returns a risk manager submitting the orders with client.
+func NewRiskManager(client *Client, opts RiskOpts) *RiskManager {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	restricted := make(map[string]struct{}, len(opts.Limits.RestrictedSymbols))
+	for _, symbol := range opts.Limits.RestrictedSymbols {
+		restricted[positionSymbol(symbol)] = struct{}{}
+	}
+	return &RiskManager{client: client, opts: opts, restricted: restricted}
+}
+
+// Killed tells whether the kill switch is engaged, and why.
+func (m *RiskManager) Killed() (bool, string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.killed, m.killReason
+}
+
+// Reset disengages the kill switch, allowing orders again.
+func (m *RiskManager) Reset() {
+	m.mu.Lock()
+	wasKilled := m.killed
+	m.killed, m.killReason = false, ""
+	m.mu.Unlock()
+	if wasKilled {
+		m.emit(RiskEvent{Type: RiskKillSwitchReset})
+	}
+}
+
+// Kill engages the kill switch: further orders are refused, all open orders are canceled
+// and all positions are closed.
+func (m *RiskManager) Kill(reason string) error {
+	return m.KillWithContext(context.Background(), reason)
+}
+
+// KillWithContext engages the kill switch: further orders are refused, all open orders are
+// canceled and all positions are closed.
+func (m *RiskManager) KillWithContext(ctx context.Context, reason string) error {
+	return m.kill(ctx, &RiskError{Rule: RiskManualKill, Reason: reason})
+}
+
+// kill engages the kill switch. Only the caller engaging it liquidates the account, the
+// others get the ErrKillSwitch error of the reason it was engaged for.
+func (m *RiskManager) kill(ctx context.Context, cause *RiskError) error {
+	m.mu.Lock()
+	if m.killed {
+		reason := m.killReason
+		m.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrKillSwitch, reason)
+	}
+	m.killed, m.killReason = true, cause.Error()
+	m.mu.Unlock()
+
+	_, err := m.client.FlattenWithContext(ctx, m.opts.Flatten)
+	m.emit(RiskEvent{
+		Type:   RiskKillSwitchEngaged,
+		Rule:   cause.Rule,
+		Symbol: cause.Symbol,
+		Reason: cause.Reason,
+		Err:    err,
+	})
+	if err != nil {
+		return errors.Join(fmt.Errorf("%w: %v", ErrKillSwitch, cause), fmt.Errorf("liquidation failed: %w", err))
+	}
+	return fmt.Errorf("%w: %v", ErrKillSwitch, cause)
+}
+
+// Check checks the account against the daily loss and exposure limits and engages the kill
+// switch if one of them is breached, e.g. because of price moves. Call it periodically to
+// enforce these limits between orders.
+func (m *RiskManager) Check() error {
+	return m.CheckWithContext(context.Background())
+}
+
+// CheckWithContext checks the account against the daily loss and exposure limits and engages
+// the kill switch if one of them is breached.
+func (m *RiskManager) CheckWithContext(ctx context.Context) error {
+	if killed, reason := m.Killed(); killed {
+		return fmt.Errorf("%w: %s", ErrKillSwitch, reason)
+	}
+	cause, err := m.dailyLoss(ctx)
+	if err != nil {
+		return err
+	}
+	if cause != nil {
+		return m.kill(ctx, cause)
+	}
+	limits := m.opts.Limits
+	if limits.MaxSymbolNotional.IsZero() && limits.MaxGrossExposure.IsZero() && limits.MaxNetExposure.IsZero() {
+		return nil
+	}
+	exposure, err := m.exposure(ctx)
+	if err != nil {
+		return err
+	}
+	if breaches := exposure.breaches(limits, ""); len(breaches) > 0 {
+		return m.kill(ctx, breaches[0])
+	}
+	return nil
+}
+
+// PlaceOrder checks req against the risk limits and places it if none is breached.
+func (m *RiskManager) PlaceOrder(req PlaceOrderRequest) (*Order, error) {
+	return m.PlaceOrderWithContext(context.Background(), req)
+}
+
+// PlaceOrderWithContext checks req against the risk limits and places it if none is breached.
+func (m *RiskManager) PlaceOrderWithContext(ctx context.Context, req PlaceOrderRequest) (*Order, error) {
+	m.submitMu.Lock()
+	defer m.submitMu.Unlock()
+
+	event := RiskEvent{Symbol: req.Symbol, ClientOrderID: req.ClientOrderID}
+	symbols := []string{req.Symbol}
+	if len(req.Legs) > 0 {
+		symbols = symbols[:0]
+		for _, leg := range req.Legs {
+			symbols = append(symbols, leg.Symbol)
+		}
+	}
+	order := riskOrder{
+		symbols: symbols, side: req.Side, qty: req.Qty, notional: req.Notional,
+		price: firstPrice(req.LimitPrice, req.StopPrice), multiLeg: len(req.Legs) > 0, new: true,
+	}
+	if err := m.check(ctx, order, &event); err != nil {
+		return nil, err
+	}
+	return m.client.PlaceOrderWithContext(ctx, req)
+}
+
+// ReplaceOrder checks the replaced order against the risk limits and replaces it if none is breached.
+func (m *RiskManager) ReplaceOrder(orderID string, req ReplaceOrderRequest) (*Order, error) {
+	return m.ReplaceOrderWithContext(context.Background(), orderID, req)
+}
+
+// ReplaceOrderWithContext checks the replaced order against the risk limits and replaces it
+// if none is breached.
+func (m *RiskManager) ReplaceOrderWithContext(ctx context.Context, orderID string, req ReplaceOrderRequest) (*Order, error) {
+	m.submitMu.Lock()
+	defer m.submitMu.Unlock()
+
+	event := RiskEvent{OrderID: orderID}
+	if killed, reason := m.Killed(); killed {
+		return nil, m.reject(event, &RiskError{Rule: RiskKillSwitch, Reason: reason})
+	}
+	existing, err := m.client.GetOrderWithContext(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	event.Symbol = existing.Symbol
+	order := riskOrder{
+		symbols: []string{existing.Symbol}, side: existing.Side, qty: req.Qty,
+		price: firstPrice(req.LimitPrice, req.StopPrice, existing.LimitPrice, existing.StopPrice),
+	}
+	if order.qty == nil && existing.Qty != nil {
+		remaining := existing.Qty.Sub(existing.FilledQty)
+		order.qty = &remaining
+	}
+	if order.qty == nil {
+		order.notional = existing.Notional
+	}
+	if err := m.check(ctx, order, &event); err != nil {
+		return nil, err
+	}
+	return m.client.ReplaceOrderWithContext(ctx, orderID, req)
+}
+
+// riskOrder is what the checks need to know about a new or replaced order.
+type riskOrder struct {
+	symbols  []string
+	side     Side
+	qty      *decimal.Decimal
+	notional *decimal.Decimal
+	price    *decimal.Decimal
+	multiLeg bool
+	new      bool
+}
+
+// check runs every check on order and records the decision. The returned error is
+// a *RiskError or an error engaging the kill switch or fetching the account state.
+func (m *RiskManager) check(ctx context.Context, order riskOrder, event *RiskEvent) error {
+	if killed, reason := m.Killed(); killed {
+		return m.reject(*event, &RiskError{Rule: RiskKillSwitch, Reason: reason})
+	}
+	for _, symbol := range order.symbols {
+		if _, ok := m.restricted[positionSymbol(symbol)]; ok {
+			return m.reject(*event, &RiskError{Rule: RiskRestrictedSymbol, Symbol: symbol, Reason: "symbol is restricted"})
+		}
+	}
+	cause, err := m.dailyLoss(ctx)
+	if err != nil {
+		return err
+	}
+	if cause != nil {
+		killErr := m.kill(ctx, cause)
+		_ = m.reject(*event, cause)
+		return killErr
+	}
+
+	limits := m.opts.Limits
+	if order.new && limits.MaxOpenOrders > 0 {
+		open, err := m.client.GetOrdersWithContext(ctx, GetOrdersRequest{Status: "open", Limit: maxOpenOrdersChecked})
+		if err != nil {
+			return err
+		}
+		if len(open) >= limits.MaxOpenOrders {
+			return m.reject(*event, &RiskError{
+				Rule:   RiskMaxOpenOrders,
+				Reason: fmt.Sprintf("%d open orders, the limit is %d", len(open), limits.MaxOpenOrders),
+			})
+		}
+	}
+
+	notionalLimits := !limits.MaxOrderNotional.IsZero() || !limits.MaxSymbolNotional.IsZero() ||
+		!limits.MaxGrossExposure.IsZero() || !limits.MaxNetExposure.IsZero()
+	// The notional of multi-leg orders depends on the prices of all legs, it is not checked.
+	if notionalLimits && !order.multiLeg {
+		symbol := order.symbols[0]
+		exposure, err := m.exposure(ctx)
+		if err != nil {
+			return err
+		}
+		notional, err := m.orderNotional(ctx, order, exposure)
+		if err != nil {
+			return m.reject(*event, &RiskError{Rule: RiskUnknownPrice, Symbol: symbol, Reason: err.Error()})
+		}
+		event.Notional = &notional
+		if max := limits.MaxOrderNotional; !max.IsZero() && notional.GreaterThan(max) {
+			return m.reject(*event, &RiskError{
+				Rule: RiskMaxOrderNotional, Symbol: symbol,
+				Reason: fmt.Sprintf("order notional %s exceeds %s", notional.StringFixed(2), max),
+			})
+		}
+		delta := notional
+		if order.side == Sell {
+			delta = delta.Neg()
+		}
+		// Positions are keyed by their symbol, e.g. "BTCUSD" for the orders of "BTC/USD".
+		key := positionSymbol(symbol)
+		after := exposure.with(key, delta)
+		for _, cause := range after.breaches(limits, key) {
+			if after.worse(exposure, cause.Rule, key) {
+				return m.reject(*event, cause)
+			}
+		}
+	}
+
+	event.Type = RiskOrderAllowed
+	m.emit(*event)
+	return nil
+}
+
+// dailyLoss returns the breach of the daily loss limit, if any.
+func (m *RiskManager) dailyLoss(ctx context.Context) (*RiskError, error) {
+	limit := m.opts.Limits.MaxDailyLoss
+	if limit.IsZero() {
+		return nil, nil
+	}
+	account, err := m.client.GetAccountWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if loss := account.LastEquity.Sub(account.Equity); loss.GreaterThanOrEqual(limit) {
+		return &RiskError{
+			Rule:   RiskMaxDailyLoss,
+			Reason: fmt.Sprintf("daily loss %s reached the limit of %s", loss.StringFixed(2), limit),
+		}, nil
+	}
+	return nil, nil
+}
+
+func (m *RiskManager) reject(event RiskEvent, cause *RiskError) error {
+	event.Type = RiskOrderRejected
+	event.Rule = cause.Rule
+	if cause.Symbol != "" {
+		event.Symbol = cause.Symbol
+	}
+	event.Reason = cause.Reason
+	m.emit(event)
+	if cause.Rule == RiskKillSwitch {
+		return fmt.Errorf("%w: %s", ErrKillSwitch, cause.Reason)
+	}
+	return cause
+}
+
+func (m *RiskManager) emit(event RiskEvent) {
+	if m.opts.OnEvent == nil {
+		return
+	}
+	event.Time = m.opts.Now()
+	m.opts.OnEvent(event)
+}
+
+// orderNotional returns the absolute notional of order.
+func (m *RiskManager) orderNotional(ctx context.Context, order riskOrder, exposure riskExposure) (decimal.Decimal, error) {
+	if order.notional != nil {
+		return order.notional.Abs(), nil
+	}
+	if order.qty == nil {
+		return decimal.Zero, errors.New("order has neither qty nor notional")
+	}
+	symbol := order.symbols[0]
+	price := order.price
+	if price == nil {
+		price = exposure.prices[positionSymbol(symbol)]
+	}
+	if price == nil && m.opts.Price != nil {
+		p, err := m.opts.Price(ctx, symbol)
+		if err != nil {
+			return decimal.Zero, fmt.Errorf("failed to get the price: %w", err)
+		}
+		price = &p
+	}
+	if price == nil {
+		return decimal.Zero, errors.New("the price of the order is unknown")
+	}
+	return order.qty.Mul(*price).Abs(), nil
+}
+
+// riskExposure is the signed market value of the positions, short positions being negative.
+// The maps are keyed by positionSymbol.
+type riskExposure struct {
+	values map[string]decimal.Decimal
+	prices map[string]*decimal.Decimal
+}
+
+func (m *RiskManager) exposure(ctx context.Context) (riskExposure, error) {
+	positions, err := m.client.GetPositionsWithContext(ctx)
+	if err != nil {
+		return riskExposure{}, err
+	}
+	exposure := riskExposure{
+		values: make(map[string]decimal.Decimal, len(positions)),
+		prices: make(map[string]*decimal.Decimal, len(positions)),
+	}
+	for _, p := range positions {
+		var value decimal.Decimal
+		switch {
+		case p.MarketValue != nil:
+			value = p.MarketValue.Abs()
+		case p.CurrentPrice != nil:
+			value = p.Qty.Mul(*p.CurrentPrice).Abs()
+		}
+		if p.Side == "short" || p.Qty.IsNegative() {
+			value = value.Neg()
+		}
+		symbol := positionSymbol(p.Symbol)
+		exposure.values[symbol] = value
+		exposure.prices[symbol] = p.CurrentPrice
+	}
+	return exposure, nil
+}
+
+// with returns the exposure after the position of symbol changed by delta.
+func (e riskExposure) with(symbol string, delta decimal.Decimal) riskExposure {
+	values := make(map[string]decimal.Decimal, len(e.values)+1)
+	for s, v := range e.values {
+		values[s] = v
+	}
+	values[symbol] = values[symbol].Add(delta)
+	return riskExposure{values: values, prices: e.prices}
+}
+
+func (e riskExposure) totals() (gross, net decimal.Decimal) {
+	for _, v := range e.values {
+		gross = gross.Add(v.Abs())
+		net = net.Add(v)
+	}
+	return gross, net.Abs()
+}
+
+// breaches returns the exposure limits breached. Only symbol is checked against the
+// symbol limit, or every symbol if symbol is empty.
+func (e riskExposure) breaches(limits RiskLimits, symbol string) []*RiskError {
+	var breaches []*RiskError
+	if max := limits.MaxSymbolNotional; !max.IsZero() {
+		for s, v := range e.values {
+			if (symbol == "" || s == symbol) && v.Abs().GreaterThan(max) {
+				breaches = append(breaches, &RiskError{
+					Rule: RiskMaxSymbolNotional, Symbol: s,
+					Reason: fmt.Sprintf("position value %s exceeds %s", v.Abs().StringFixed(2), max),
+				})
+			}
+		}
+	}
+	gross, net := e.totals()
+	if max := limits.MaxGrossExposure; !max.IsZero() && gross.GreaterThan(max) {
+		breaches = append(breaches, &RiskError{
+			Rule:   RiskMaxGrossExposure,
+			Reason: fmt.Sprintf("gross exposure %s exceeds %s", gross.StringFixed(2), max),
+		})
+	}
+	if max := limits.MaxNetExposure; !max.IsZero() && net.GreaterThan(max) {
+		breaches = append(breaches, &RiskError{
+			Rule:   RiskMaxNetExposure,
+			Reason: fmt.Sprintf("net exposure %s exceeds %s", net.StringFixed(2), max),
+		})
+	}
+	return breaches
+}
+
+// worse tells whether the exposure measured by rule is higher than before. Orders reducing
+// an exposure that is already above its limit are allowed.
+func (e riskExposure) worse(before riskExposure, rule RiskRule, symbol string) bool {
+	switch rule {
+	case RiskMaxSymbolNotional:
+		return e.values[symbol].Abs().GreaterThan(before.values[symbol].Abs())
+	case RiskMaxGrossExposure:
+		gross, _ := e.totals()
+		grossBefore, _ := before.totals()
+		return gross.GreaterThan(grossBefore)
+	case RiskMaxNetExposure:
+		_, net := e.totals()
+		_, netBefore := before.totals()
+		return net.GreaterThan(netBefore)
+	}
+	return true
+}
+
+func firstPrice(prices ...*decimal.Decimal) *decimal.Decimal {
+	for _, p := range prices {
+		if p != nil {
+			return p
+		}
+	}
+	return nil
+}
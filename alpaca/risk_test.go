@@ -0,0 +1,338 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// riskAccount fakes the trading endpoints used by the risk manager.
+type riskAccount struct {
+	equity, lastEquity string
+	positions          string
+	openOrders         string
+	placed, replaced   int
+	flattened          bool
+	liquidations       int
+}
+
+func newRiskAccount() *riskAccount {
+	return &riskAccount{
+		equity:     "100000",
+		lastEquity: "100000",
+		positions: `[
+			{"symbol":"AAPL","qty":"50","side":"long","market_value":"10000","current_price":"200"},
+			{"symbol":"TSLA","qty":"-20","side":"short","market_value":"-5000","current_price":"250"}
+		]`,
+		openOrders: `[]`,
+	}
+}
+
+func (a *riskAccount) client(t *testing.T) *Client {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		body := ""
+		switch req.Method + " " + req.URL.Path {
+		case "GET /v2/account":
+			body = fmt.Sprintf(`{"equity":%q,"last_equity":%q}`, a.equity, a.lastEquity)
+		case "GET /v2/positions":
+			body = a.positions
+		case "GET /v2/orders":
+			assert.Equal(t, "open", req.URL.Query().Get("status"))
+			body = a.openOrders
+		case "POST /v2/orders":
+			a.placed++
+			body = `{"id":"new"}`
+		case "GET /v2/orders/existing":
+			body = `{"id":"existing","symbol":"AAPL","side":"buy","qty":"10","filled_qty":"4","limit_price":"100"}`
+		case "PATCH /v2/orders/existing":
+			a.replaced++
+			body = `{"id":"replacement"}`
+		case "DELETE /v2/orders", "DELETE /v2/positions":
+			a.flattened = true
+			if req.URL.Path == "/v2/positions" {
+				a.liquidations++
+			}
+			return &http.Response{StatusCode: http.StatusMultiStatus, Body: io.NopCloser(strings.NewReader(`[]`))}, nil
+		default:
+			return nil, fmt.Errorf("unexpected request %s %s", req.Method, req.URL)
+		}
+		return &http.Response{Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+	return c
+}
+
+func TestRiskManagerPlaceOrder(t *testing.T) {
+	d := decimal.RequireFromString
+	dp := func(s string) *decimal.Decimal {
+		v := d(s)
+		return &v
+	}
+	limits := RiskLimits{
+		MaxOrderNotional:  d("20000"),
+		MaxSymbolNotional: d("25000"),
+		MaxGrossExposure:  d("32000"),
+		MaxNetExposure:    d("20000"),
+		MaxOpenOrders:     2,
+		RestrictedSymbols: []string{"GME"},
+	}
+
+	tests := []struct {
+		name       string
+		req        PlaceOrderRequest
+		openOrders string
+		rule       RiskRule
+	}{
+		{
+			name: "allowed",
+			req:  PlaceOrderRequest{Symbol: "AAPL", Qty: dp("10"), Side: Buy, Type: Market},
+		},
+		{
+			name: "restricted symbol",
+			req:  PlaceOrderRequest{Symbol: "GME", Qty: dp("1"), Side: Buy, Type: Market},
+			rule: RiskRestrictedSymbol,
+		},
+		{
+			name: "restricted leg",
+			req: PlaceOrderRequest{Qty: dp("1"), OrderClass: MLeg, Legs: []OrderLeg{
+				{Symbol: "AAPL250620C00200000"}, {Symbol: "GME"},
+			}},
+			rule: RiskRestrictedSymbol,
+		},
+		{
+			name: "order notional",
+			req:  PlaceOrderRequest{Symbol: "MSFT", Notional: dp("20000.01"), Side: Buy, Type: Market},
+			rule: RiskMaxOrderNotional,
+		},
+		{
+			name: "symbol notional",
+			req:  PlaceOrderRequest{Symbol: "AAPL", Qty: dp("90"), Side: Buy, Type: Limit, LimitPrice: dp("170")},
+			rule: RiskMaxSymbolNotional,
+		},
+		{
+			name: "net exposure",
+			req:  PlaceOrderRequest{Symbol: "MSFT", Qty: dp("40"), Side: Buy, Type: Limit, LimitPrice: dp("400")},
+			rule: RiskMaxNetExposure,
+		},
+		{
+			name: "gross exposure",
+			req:  PlaceOrderRequest{Symbol: "NVDA", Qty: dp("90"), Side: Sell, Type: Limit, LimitPrice: dp("200")},
+			rule: RiskMaxGrossExposure,
+		},
+		{
+			name: "reducing a position",
+			req:  PlaceOrderRequest{Symbol: "TSLA", Qty: dp("20"), Side: Buy, Type: Market},
+		},
+		{
+			name:       "open orders",
+			req:        PlaceOrderRequest{Symbol: "AAPL", Qty: dp("1"), Side: Buy, Type: Market},
+			openOrders: `[{"id":"1"},{"id":"2"}]`,
+			rule:       RiskMaxOpenOrders,
+		},
+		{
+			name: "unknown price",
+			req:  PlaceOrderRequest{Symbol: "MSFT", Qty: dp("1"), Side: Buy, Type: Market},
+			rule: RiskUnknownPrice,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := newRiskAccount()
+			if tt.openOrders != "" {
+				account.openOrders = tt.openOrders
+			}
+			var events []RiskEvent
+			m := NewRiskManager(account.client(t), RiskOpts{
+				Limits:  limits,
+				OnEvent: func(e RiskEvent) { events = append(events, e) },
+			})
+
+			order, err := m.PlaceOrder(tt.req)
+			require.Len(t, events, 1)
+			if tt.rule == "" {
+				require.NoError(t, err)
+				assert.Equal(t, "new", order.ID)
+				assert.Equal(t, 1, account.placed)
+				assert.Equal(t, RiskOrderAllowed, events[0].Type)
+				return
+			}
+			require.ErrorIs(t, err, ErrRiskLimit)
+			var riskErr *RiskError
+			require.True(t, errors.As(err, &riskErr))
+			assert.Equal(t, tt.rule, riskErr.Rule)
+			assert.Equal(t, 0, account.placed)
+			assert.Equal(t, RiskOrderRejected, events[0].Type)
+			assert.Equal(t, tt.rule, events[0].Rule)
+			assert.False(t, events[0].Time.IsZero())
+		})
+	}
+}
+
+func TestRiskManagerPriceFunc(t *testing.T) {
+	account := newRiskAccount()
+	var events []RiskEvent
+	m := NewRiskManager(account.client(t), RiskOpts{
+		Limits: RiskLimits{MaxOrderNotional: decimal.NewFromInt(1000)},
+		Price: func(ctx context.Context, symbol string) (decimal.Decimal, error) {
+			assert.Equal(t, "MSFT", symbol)
+			return decimal.NewFromInt(400), nil
+		},
+		OnEvent: func(e RiskEvent) { events = append(events, e) },
+	})
+	qty := decimal.NewFromInt(3)
+	_, err := m.PlaceOrder(PlaceOrderRequest{Symbol: "MSFT", Qty: &qty, Side: Buy, Type: Market, ClientOrderID: "abc"})
+	require.ErrorIs(t, err, ErrRiskLimit)
+	require.Len(t, events, 1)
+	assert.Equal(t, "abc", events[0].ClientOrderID)
+	assert.Equal(t, "1200", events[0].Notional.String())
+}
+
+func TestRiskManagerReplaceOrder(t *testing.T) {
+	account := newRiskAccount()
+	m := NewRiskManager(account.client(t), RiskOpts{
+		Limits: RiskLimits{MaxOrderNotional: decimal.NewFromInt(1000)},
+	})
+
+	// The remaining 6 shares at the new limit price of 150.
+	price := decimal.NewFromInt(150)
+	order, err := m.ReplaceOrder("existing", ReplaceOrderRequest{LimitPrice: &price})
+	require.NoError(t, err)
+	assert.Equal(t, "replacement", order.ID)
+
+	qty := decimal.NewFromInt(11)
+	_, err = m.ReplaceOrder("existing", ReplaceOrderRequest{Qty: &qty})
+	var riskErr *RiskError
+	require.True(t, errors.As(err, &riskErr))
+	assert.Equal(t, RiskMaxOrderNotional, riskErr.Rule)
+	assert.Equal(t, "AAPL", riskErr.Symbol)
+	assert.Equal(t, 1, account.replaced)
+}
+
+func TestRiskManagerKillSwitch(t *testing.T) {
+	account := newRiskAccount()
+	var events []RiskEvent
+	m := NewRiskManager(account.client(t), RiskOpts{
+		Limits:  RiskLimits{MaxDailyLoss: decimal.NewFromInt(5000)},
+		OnEvent: func(e RiskEvent) { events = append(events, e) },
+	})
+	qty := decimal.NewFromInt(1)
+	req := PlaceOrderRequest{Symbol: "AAPL", Qty: &qty, Side: Buy, Type: Market}
+
+	_, err := m.PlaceOrder(req)
+	require.NoError(t, err)
+	require.NoError(t, m.Check())
+	assert.False(t, account.flattened)
+
+	// The account lost 5000 since yesterday's close.
+	account.equity = "95000"
+	_, err = m.PlaceOrder(req)
+	require.ErrorIs(t, err, ErrKillSwitch)
+	assert.True(t, account.flattened)
+	killed, reason := m.Killed()
+	assert.True(t, killed)
+	assert.Contains(t, reason, "daily loss 5000.00 reached the limit of 5000")
+
+	account.equity = "100000"
+	_, err = m.PlaceOrder(req)
+	require.ErrorIs(t, err, ErrKillSwitch)
+	_, err = m.ReplaceOrder("existing", ReplaceOrderRequest{Qty: &qty})
+	require.ErrorIs(t, err, ErrKillSwitch)
+	assert.Equal(t, 1, account.placed)
+
+	m.Reset()
+	_, err = m.PlaceOrder(req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, account.placed)
+
+	var types []RiskEventType
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	assert.Equal(t, []RiskEventType{
+		RiskOrderAllowed,
+		RiskKillSwitchEngaged,
+		RiskOrderRejected,
+		RiskOrderRejected,
+		RiskOrderRejected,
+		RiskKillSwitchReset,
+		RiskOrderAllowed,
+	}, types)
+	assert.Equal(t, RiskMaxDailyLoss, events[1].Rule)
+	assert.Equal(t, RiskKillSwitch, events[3].Rule)
+}
+
+func TestRiskManagerCheck(t *testing.T) {
+	account := newRiskAccount()
+	m := NewRiskManager(account.client(t), RiskOpts{
+		Limits: RiskLimits{MaxGrossExposure: decimal.NewFromInt(12000)},
+	})
+	err := m.Check()
+	require.ErrorIs(t, err, ErrKillSwitch)
+	assert.Contains(t, err.Error(), "gross exposure 15000.00 exceeds 12000")
+	assert.True(t, account.flattened)
+
+	m.Reset()
+	account.flattened = false
+	require.ErrorIs(t, m.Kill("manual stop"), ErrKillSwitch)
+	assert.True(t, account.flattened)
+	_, reason := m.Killed()
+	assert.Contains(t, reason, "manual stop")
+}
+
+func TestRiskManagerKillOnce(t *testing.T) {
+	account := newRiskAccount()
+	var engaged int
+	m := NewRiskManager(account.client(t), RiskOpts{
+		OnEvent: func(e RiskEvent) {
+			if e.Type == RiskKillSwitchEngaged {
+				engaged++
+			}
+		},
+	})
+	require.ErrorIs(t, m.Kill("first"), ErrKillSwitch)
+	err := m.Kill("second")
+	require.ErrorIs(t, err, ErrKillSwitch)
+	assert.Contains(t, err.Error(), "first")
+	assert.Equal(t, 1, account.liquidations)
+	assert.Equal(t, 1, engaged)
+	_, reason := m.Killed()
+	assert.Contains(t, reason, "first")
+}
+
+func TestRiskManagerCrypto(t *testing.T) {
+	d := decimal.RequireFromString
+	account := newRiskAccount()
+	account.positions = `[{"symbol":"BTCUSD","asset_class":"crypto","qty":"1","side":"long","market_value":"60000","current_price":"60000"}]`
+	m := NewRiskManager(account.client(t), RiskOpts{
+		Limits: RiskLimits{
+			MaxSymbolNotional: d("70000"),
+			MaxGrossExposure:  d("65000"),
+		},
+	})
+
+	// The order symbol has a slash, the position symbol does not: they are the same holding.
+	qty := d("0.5")
+	_, err := m.PlaceOrder(PlaceOrderRequest{Symbol: "BTC/USD", Qty: &qty, Side: Buy, Type: Market})
+	var riskErr *RiskError
+	require.True(t, errors.As(err, &riskErr))
+	assert.Equal(t, RiskMaxSymbolNotional, riskErr.Rule)
+
+	// Selling the position reduces the exposure instead of opening a short.
+	qty = d("1")
+	_, err = m.PlaceOrder(PlaceOrderRequest{Symbol: "BTC/USD", Qty: &qty, Side: Sell, Type: Market})
+	require.NoError(t, err)
+	assert.Equal(t, 1, account.placed)
+
+	m = NewRiskManager(account.client(t), RiskOpts{Limits: RiskLimits{RestrictedSymbols: []string{"BTCUSD"}}})
+	_, err = m.PlaceOrder(PlaceOrderRequest{Symbol: "BTC/USD", Qty: &qty, Side: Buy, Type: Market})
+	require.True(t, errors.As(err, &riskErr))
+	assert.Equal(t, RiskRestrictedSymbol, riskErr.Rule)
+}
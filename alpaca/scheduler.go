@@ -0,0 +1,377 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SessionEvent is a point of the trading session schedule rules are relative to.
+type SessionEvent string
+
+const (
+	SessionPreMarketOpen   SessionEvent = "pre-market open"
+	SessionOpen            SessionEvent = "open"
+	SessionClose           SessionEvent = "close"
+	SessionAfterHoursClose SessionEvent = "after-hours close"
+)
+
+func (e SessionEvent) time(s *Session) (time.Time, error) {
+	switch e {
+	case SessionPreMarketOpen:
+		return s.PreMarket.Start, nil
+	case SessionOpen:
+		return s.Regular.Start, nil
+	case SessionClose:
+		return s.Regular.End, nil
+	case SessionAfterHoursClose:
+		return s.AfterHours.End, nil
+	}
+	return time.Time{}, fmt.Errorf("unknown session event %q", e)
+}
+
+// ScheduleRule decides when a scheduled job runs.
+type ScheduleRule interface {
+	// Next returns the first time after t the job should run. ctx bounds the calls
+	// fetching the market calendar.
+	Next(ctx context.Context, calendar *MarketCalendar, t time.Time) (time.Time, error)
+}
+
+// At returns a rule firing once per session, offset from event. Use a negative offset
+// to fire before the event, e.g. At(SessionClose, -5*time.Minute).
+func At(event SessionEvent, offset time.Duration) ScheduleRule {
+	return atRule{event: event, offset: offset}
+}
+
+type atRule struct {
+	event  SessionEvent
+	offset time.Duration
+}
+
+func (r atRule) Next(ctx context.Context, calendar *MarketCalendar, t time.Time) (time.Time, error) {
+	if _, err := r.event.time(&Session{}); err != nil {
+		return time.Time{}, err
+	}
+	fireTime := func(s *Session) time.Time {
+		at, _ := r.event.time(s)
+		return at.Add(r.offset)
+	}
+	session, err := calendar.sessionAfter(ctx, t, fireTime)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return fireTime(session), nil
+}
+
+// Every returns a rule firing every interval during the phase of each session, starting
+// when the phase starts. The phase must be MarketPreMarket, MarketRegular or MarketAfterHours.
+func Every(interval time.Duration, phase MarketPhase) ScheduleRule {
+	return everyRule{interval: interval, phase: phase}
+}
+
+type everyRule struct {
+	interval time.Duration
+	phase    MarketPhase
+}
+
+func (r everyRule) window(s *Session) TimeWindow {
+	switch r.phase {
+	case MarketPreMarket:
+		return s.PreMarket
+	case MarketRegular:
+		return s.Regular
+	case MarketAfterHours:
+		return s.AfterHours
+	}
+	return TimeWindow{}
+}
+
+func (r everyRule) Next(ctx context.Context, calendar *MarketCalendar, t time.Time) (time.Time, error) {
+	if r.interval <= 0 {
+		return time.Time{}, fmt.Errorf("invalid interval %s", r.interval)
+	}
+	if r.phase != MarketPreMarket && r.phase != MarketRegular && r.phase != MarketAfterHours {
+		return time.Time{}, fmt.Errorf("invalid market phase %q", r.phase)
+	}
+	for {
+		session, err := calendar.sessionAfter(ctx, t, func(s *Session) time.Time { return r.window(s).End })
+		if err != nil {
+			return time.Time{}, err
+		}
+		window := r.window(session)
+		if t.Before(window.Start) {
+			return window.Start, nil
+		}
+		at := window.Start.Add((t.Sub(window.Start)/r.interval + 1) * r.interval)
+		if at.Before(window.End) {
+			return at, nil
+		}
+		// The rest of the window is shorter than the interval, continue with the next session.
+		t = window.End
+	}
+}
+
+// ParseScheduleRule parses rules written as
+//
+//	at open
+//	at open + 30s
+//	at close - 5m
+//	5m before close
+//	1h after pre-market open
+//	every 1m during regular hours
+//
+// The events are "pre-market open", "open", "close" and "after-hours close", and the
+// phases of "every" rules are "pre-market", "regular" and "after-hours".
+func ParseScheduleRule(rule string) (ScheduleRule, error) {
+	fields := strings.Fields(strings.ToLower(rule))
+	invalid := func(reason string, a ...interface{}) error {
+		return fmt.Errorf("invalid schedule rule %q: %s", rule, fmt.Sprintf(reason, a...))
+	}
+	if len(fields) < 2 {
+		return nil, invalid("too short")
+	}
+
+	switch fields[0] {
+	case "at":
+		eventFields, offset := fields[1:], time.Duration(0)
+		if n := len(fields); n >= 4 && (fields[n-2] == "+" || fields[n-2] == "-") {
+			d, err := time.ParseDuration(fields[n-1])
+			if err != nil {
+				return nil, invalid("%v", err)
+			}
+			if fields[n-2] == "-" {
+				d = -d
+			}
+			eventFields, offset = fields[1:n-2], d
+		}
+		event, err := parseSessionEvent(eventFields)
+		if err != nil {
+			return nil, invalid("%v", err)
+		}
+		return At(event, offset), nil
+	case "every":
+		if len(fields) < 4 || fields[2] != "during" {
+			return nil, invalid(`expected "every <interval> during <phase>"`)
+		}
+		interval, err := time.ParseDuration(fields[1])
+		if err != nil {
+			return nil, invalid("%v", err)
+		}
+		if interval <= 0 {
+			return nil, invalid("interval must be positive")
+		}
+		phaseFields := fields[3:]
+		if last := phaseFields[len(phaseFields)-1]; len(phaseFields) > 1 && (last == "hours" || last == "session") {
+			phaseFields = phaseFields[:len(phaseFields)-1]
+		}
+		var phase MarketPhase
+		switch strings.Join(phaseFields, " ") {
+		case "pre-market":
+			phase = MarketPreMarket
+		case "regular":
+			phase = MarketRegular
+		case "after-hours":
+			phase = MarketAfterHours
+		default:
+			return nil, invalid("unknown phase %q", strings.Join(fields[3:], " "))
+		}
+		return Every(interval, phase), nil
+	default:
+		if len(fields) < 3 || (fields[1] != "before" && fields[1] != "after") {
+			return nil, invalid(`expected "at <event>", "<offset> before|after <event>" or "every <interval> during <phase>"`)
+		}
+		d, err := time.ParseDuration(fields[0])
+		if err != nil {
+			return nil, invalid("%v", err)
+		}
+		if fields[1] == "before" {
+			d = -d
+		}
+		event, err := parseSessionEvent(fields[2:])
+		if err != nil {
+			return nil, invalid("%v", err)
+		}
+		return At(event, d), nil
+	}
+}
+
+func parseSessionEvent(fields []string) (SessionEvent, error) {
+	event := SessionEvent(strings.Join(fields, " "))
+	switch event {
+	case SessionPreMarketOpen, SessionOpen, SessionClose, SessionAfterHoursClose:
+		return event, nil
+	}
+	return "", fmt.Errorf("unknown session event %q", event)
+}
+
+// SchedulerClock is the time source of a Scheduler. Tests can replace it to run a
+// schedule without waiting.
+type SchedulerClock interface {
+	Now() time.Time
+	// After waits for d and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// JobFunc is the callback of a scheduled job. at is the time the job was scheduled for.
+type JobFunc func(ctx context.Context, at time.Time) error
+
+// SchedulerOpts configures a Scheduler.
+type SchedulerOpts struct {
+	// Clock defaults to the system clock.
+	Clock SchedulerClock
+	// OnError is called with the errors returned by the jobs, and with the errors of computing
+	// their fire times (e.g. because the market calendar could not be fetched). By default they are logged.
+	OnError func(job string, err error)
+}
+
+// Scheduler runs jobs at times relative to the market sessions, e.g. 5 minutes before the
+// close or every minute during the regular hours. Holidays and early closes are taken
+// from the market calendar.
+type Scheduler struct {
+	calendar *MarketCalendar
+	opts     SchedulerOpts
+
+	mu      sync.Mutex
+	jobs    []scheduledJob
+	running bool
+}
+
+type scheduledJob struct {
+	name string
+	rule ScheduleRule
+	fn   JobFunc
+}
+
+// NewScheduler returns a scheduler using calendar for the session times.
+func NewScheduler(calendar *MarketCalendar, opts SchedulerOpts) *Scheduler {
+	if opts.Clock == nil {
+		opts.Clock = systemClock{}
+	}
+	if opts.OnError == nil {
+		opts.OnError = func(job string, err error) {
+			log.Printf("alpaca scheduler job %s failed: %v", job, err)
+		}
+	}
+	return &Scheduler{calendar: calendar, opts: opts}
+}
+
+// Add schedules fn to run according to rule. Jobs must be added before Run is called.
+func (s *Scheduler) Add(name string, rule ScheduleRule, fn JobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, scheduledJob{name: name, rule: rule, fn: fn})
+}
+
+// AddRule parses rule with ParseScheduleRule and schedules fn to run according to it.
+func (s *Scheduler) AddRule(name, rule string, fn JobFunc) error {
+	r, err := ParseScheduleRule(rule)
+	if err != nil {
+		return err
+	}
+	s.Add(name, r, fn)
+	return nil
+}
+
+// Run runs the jobs until ctx is done. Jobs run one at a time, in the order of their fire
+// times. Jobs that became due while another job was running run as soon as it returns,
+// once: the runs they missed in the meantime are skipped. A job due exactly when Run is
+// called runs immediately.
+// If the fire time of a job cannot be computed (e.g. because the market calendar could not
+// be fetched), the error is reported to OnError and the computation is retried with an
+// exponential backoff. Run returns ctx.Err() when ctx is done.
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return errors.New("scheduler is already running")
+	}
+	s.running = true
+	jobs := append([]scheduledJob(nil), s.jobs...)
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.running = false
+		s.mu.Unlock()
+	}()
+
+	next := make([]time.Time, len(jobs))
+	// failures counts the consecutive scheduling failures of the jobs. While it is positive,
+	// next is the time of the next scheduling attempt instead of a fire time.
+	failures := make([]int, len(jobs))
+	schedule := func(i int, after time.Time) {
+		at, err := jobs[i].rule.Next(ctx, s.calendar, after)
+		if err != nil {
+			// Run returns once ctx is done, the failure is not worth reporting then.
+			if ctx.Err() == nil {
+				s.opts.OnError(jobs[i].name, fmt.Errorf("failed to schedule %s: %w", jobs[i].name, err))
+			}
+			failures[i]++
+			next[i] = s.opts.Clock.Now().Add(scheduleRetryDelay(failures[i]))
+			return
+		}
+		failures[i], next[i] = 0, at
+	}
+	// Next returns the fire times after its argument, including the current instant.
+	now := s.opts.Clock.Now().Add(-time.Nanosecond)
+	for i := range jobs {
+		schedule(i, now)
+	}
+
+	for {
+		if len(jobs) == 0 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		i := 0
+		for j := range next {
+			if next[j].Before(next[i]) {
+				i = j
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.opts.Clock.After(next[i].Sub(s.opts.Clock.Now())):
+		}
+
+		if failures[i] > 0 {
+			// The runs missed while the fire time was unknown are skipped.
+			schedule(i, s.opts.Clock.Now())
+			continue
+		}
+		job, at := jobs[i], next[i]
+		if err := job.fn(ctx, at); err != nil {
+			s.opts.OnError(job.name, err)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		after := s.opts.Clock.Now()
+		if after.Before(at) {
+			after = at
+		}
+		schedule(i, after)
+	}
+}
+
+// scheduleRetryDelay returns the delay before retrying to compute a fire time after n failures.
+func scheduleRetryDelay(n int) time.Duration {
+	const maxDelay = 5 * time.Minute
+	if n > 9 {
+		return maxDelay
+	}
+	if d := time.Second << (n - 1); d < maxDelay {
+		return d
+	}
+	return maxDelay
+}
@@ -0,0 +1,246 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock jumps forward instead of waiting.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	if d > 0 {
+		c.now = c.now.Add(d)
+	}
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestParseScheduleRule(t *testing.T) {
+	var requests []string
+	mc := NewMarketCalendar(calendarClient(t, &requests))
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	at := func(month time.Month, day, hour, minute, sec int) time.Time {
+		return time.Date(2024, month, day, hour, minute, sec, 0, ny)
+	}
+
+	tests := []struct {
+		rule string
+		from time.Time
+		next time.Time
+	}{
+		{"at open", at(time.November, 27, 9, 0, 0), at(time.November, 27, 9, 30, 0)},
+		{"at open", at(time.November, 27, 9, 30, 0), at(time.November, 29, 9, 30, 0)},
+		{"at open + 30s", at(time.November, 27, 9, 30, 0), at(time.November, 27, 9, 30, 30)},
+		{"At Close - 5m", at(time.November, 27, 16, 0, 0), at(time.November, 29, 12, 55, 0)},
+		{"5m before close", at(time.November, 29, 8, 0, 0), at(time.November, 29, 12, 55, 0)},
+		{"1h after pre-market open", at(time.November, 26, 23, 0, 0), at(time.November, 27, 5, 0, 0)},
+		{"at after-hours close", at(time.November, 29, 12, 0, 0), at(time.November, 29, 17, 0, 0)},
+		{"every 1m during regular hours", at(time.November, 27, 9, 45, 30), at(time.November, 27, 9, 46, 0)},
+		{"every 1m during regular hours", at(time.November, 27, 15, 59, 0), at(time.November, 29, 9, 30, 0)},
+		{"every 7m during regular", at(time.November, 27, 15, 55, 0), at(time.November, 29, 9, 30, 0)},
+		{"every 30m during pre-market", at(time.November, 27, 4, 0, 0), at(time.November, 27, 4, 30, 0)},
+		{"every 2h during after-hours session", at(time.November, 29, 14, 0, 0), at(time.November, 29, 15, 0, 0)},
+		{"every 1m during regular hours", at(time.December, 31, 15, 59, 30), time.Date(2025, 1, 2, 9, 30, 0, 0, ny)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseScheduleRule(tt.rule)
+			require.NoError(t, err)
+			next, err := rule.Next(context.Background(), mc, tt.from)
+			require.NoError(t, err)
+			assert.Equal(t, tt.next, next)
+		})
+	}
+
+	for _, rule := range []string{
+		"", "open", "at lunch", "at open + soon", "5m around close", "every 1m", "every -1m during regular hours",
+		"every 1m during lunch",
+	} {
+		_, err := ParseScheduleRule(rule)
+		assert.Error(t, err, rule)
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	var requests []string
+	mc := NewMarketCalendar(calendarClient(t, &requests))
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Date(2024, 11, 27, 12, 0, 0, 0, ny)}
+
+	var jobErrs []error
+	s := NewScheduler(mc, SchedulerOpts{
+		Clock:   clock,
+		OnError: func(job string, err error) { jobErrs = append(jobErrs, err) },
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var fired []string
+	record := func(name string) JobFunc {
+		return func(ctx context.Context, at time.Time) error {
+			fired = append(fired, at.Format("01-02 15:04 ")+name)
+			if len(fired) == 10 {
+				cancel()
+			}
+			return nil
+		}
+	}
+	s.Add("hourly", Every(time.Hour, MarketRegular), record("hourly"))
+	require.NoError(t, s.AddRule("exit", "5m before close", func(ctx context.Context, at time.Time) error {
+		if err := record("exit")(ctx, at); err != nil {
+			return err
+		}
+		return errors.New("nothing to close")
+	}))
+	require.Error(t, s.AddRule("bad", "at noon", record("bad")))
+
+	err = s.Run(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, []string{
+		"11-27 12:30 hourly",
+		"11-27 13:30 hourly",
+		"11-27 14:30 hourly",
+		"11-27 15:30 hourly",
+		"11-27 15:55 exit",
+		// Thanksgiving is skipped and the market closes early on the next day.
+		"11-29 09:30 hourly",
+		"11-29 10:30 hourly",
+		"11-29 11:30 hourly",
+		"11-29 12:30 hourly",
+		"11-29 12:55 exit",
+	}, fired)
+	assert.Len(t, jobErrs, 2)
+}
+
+func TestSchedulerSkipsMissedRuns(t *testing.T) {
+	var requests []string
+	mc := NewMarketCalendar(calendarClient(t, &requests))
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Date(2024, 11, 27, 9, 0, 0, 0, ny)}
+	s := NewScheduler(mc, SchedulerOpts{Clock: clock})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var fired []time.Time
+	s.Add("slow", Every(time.Minute, MarketRegular), func(ctx context.Context, at time.Time) error {
+		fired = append(fired, at)
+		// The job takes 2.5 minutes.
+		clock.now = clock.now.Add(150 * time.Second)
+		if len(fired) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	require.ErrorIs(t, s.Run(ctx), context.Canceled)
+	require.Len(t, fired, 3)
+	assert.Equal(t, "09:30:00", fired[0].Format("15:04:05"))
+	assert.Equal(t, "09:33:00", fired[1].Format("15:04:05"))
+	assert.Equal(t, "09:36:00", fired[2].Format("15:04:05"))
+}
+
+func TestSchedulerStartsAtOpen(t *testing.T) {
+	var requests []string
+	mc := NewMarketCalendar(calendarClient(t, &requests))
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Date(2024, 11, 27, 9, 30, 0, 0, ny)}
+	s := NewScheduler(mc, SchedulerOpts{Clock: clock})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var fired []string
+	s.Add("minutely", Every(time.Minute, MarketRegular), func(ctx context.Context, at time.Time) error {
+		fired = append(fired, at.Format("15:04:05"))
+		if len(fired) == 2 {
+			cancel()
+		}
+		return nil
+	})
+	require.ErrorIs(t, s.Run(ctx), context.Canceled)
+	assert.Equal(t, []string{"09:30:00", "09:31:00"}, fired)
+}
+
+func TestSchedulerCalendarError(t *testing.T) {
+	var requests []string
+	client := calendarClient(t, &requests)
+	serve, failures := client.do, 0
+	client.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		if failures < 3 {
+			failures++
+			return nil, errors.New("service unavailable")
+		}
+		return serve(c, req)
+	}
+	mc := NewMarketCalendar(client)
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	clock := &fakeClock{now: time.Date(2024, 11, 27, 12, 0, 0, 0, ny)}
+
+	var errs []string
+	s := NewScheduler(mc, SchedulerOpts{
+		Clock:   clock,
+		OnError: func(job string, err error) { errs = append(errs, err.Error()) },
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var fired []time.Time
+	s.Add("open", At(SessionOpen, 0), func(ctx context.Context, at time.Time) error {
+		fired = append(fired, at)
+		cancel()
+		return nil
+	})
+	require.ErrorIs(t, s.Run(ctx), context.Canceled)
+	require.Len(t, errs, 3)
+	assert.Contains(t, errs[0], "failed to schedule open")
+	assert.Equal(t, []time.Time{time.Date(2024, 11, 29, 9, 30, 0, 0, ny)}, fired)
+}
+
+func TestSchedulerCancelDuringCalendarFetch(t *testing.T) {
+	client := NewClient(ClientOpts{})
+	client.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		// A calendar request that never completes on its own.
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+	mc := NewMarketCalendar(client)
+	var errs []error
+	s := NewScheduler(mc, SchedulerOpts{
+		Clock:   &fakeClock{now: time.Date(2024, 11, 27, 12, 0, 0, 0, time.UTC)},
+		OnError: func(job string, err error) { errs = append(errs, err) },
+	})
+	s.Add("open", At(SessionOpen, 0), func(ctx context.Context, at time.Time) error { return nil })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after its context was done")
+	}
+	assert.Empty(t, errs)
+}
+
+func TestScheduleRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Second, scheduleRetryDelay(1))
+	assert.Equal(t, 4*time.Second, scheduleRetryDelay(3))
+	assert.Equal(t, 5*time.Minute, scheduleRetryDelay(10))
+	assert.Equal(t, 5*time.Minute, scheduleRetryDelay(100))
+}
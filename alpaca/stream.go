@@ -1,11 +1,8 @@
 package alpaca
 
 import (
-	"bufio"
-	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -13,6 +10,8 @@ import (
 	"net/http"
 	"net/url"
 	"time"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/internal/sse"
 )
 
 type StreamTradeUpdatesRequest struct {
@@ -24,6 +23,11 @@ type StreamTradeUpdatesRequest struct {
 
 // StreamTradeUpdates streams the trade updates of the account.
 func (c *Client) StreamTradeUpdates(ctx context.Context, handler func(TradeUpdate), req StreamTradeUpdatesRequest) error {
+	return c.streamTradeUpdates(ctx, handler, req, nil)
+}
+
+// streamTradeUpdates is StreamTradeUpdates with a callback that is called once the server accepted the request.
+func (c *Client) streamTradeUpdates(ctx context.Context, handler func(TradeUpdate), req StreamTradeUpdatesRequest, onConnect func()) error {
 	transport := http.Transport{
 		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
 			return net.DialTimeout(network, addr, 5*time.Second)
@@ -72,52 +76,135 @@ func (c *Client) StreamTradeUpdates(ctx context.Context, handler func(TradeUpdat
 		body, _ := io.ReadAll(resp.Body)
 		return fmt.Errorf("%s (HTTP %d)", body, resp.StatusCode)
 	}
+	if onConnect != nil {
+		onConnect()
+	}
 
-	reader := bufio.NewReader(resp.Body)
-	for {
-		msg, err := reader.ReadBytes('\n')
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				return nil
-			}
-			return err
-		}
-		const dataPrefix = "data: "
-		if !bytes.HasPrefix(msg, []byte(dataPrefix)) {
-			continue
-		}
-		msg = msg[(len(dataPrefix)):]
+	return sse.Read(resp.Body, func(event sse.Event) error {
 		var tu TradeUpdate
-		if err := json.Unmarshal(msg, &tu); err != nil {
+		if err := json.Unmarshal(event.Data, &tu); err != nil {
 			return err
 		}
+		if tu.EventID == "" {
+			tu.EventID = event.ID
+		}
 		handler(tu)
-	}
+		return nil
+	})
+}
+
+type StreamTradeUpdatesOpts struct {
+	// SinceID is the ID of the last event processed before, e.g. by a previous run of the application.
+	// If set, the stream starts right after it.
+	SinceID string
+	// MinBackoff is the delay before the first reconnect attempt after an error. Defaults to 100ms.
+	MinBackoff time.Duration
+	// MaxBackoff is the maximum delay between reconnect attempts. Defaults to 30s.
+	MaxBackoff time.Duration
+	// OnConnect is called every time the stream is connected.
+	OnConnect func()
+	// OnDisconnect is called every time the connection ends or a connection attempt fails.
+	// The error is nil if the server closed the stream normally. If OnDisconnect is missing,
+	// the errors are logged.
+	OnDisconnect func(err error)
 }
 
 // StreamTradeUpdatesInBackground streams the trade updates of the account.
 // It runs in the background and keeps calling the handler function for each trade update
-// until the context is cancelled. If an error happens it logs it and retries immediately.
+// until the context is cancelled. If an error happens it logs it and reconnects,
+// resuming from the last delivered event.
 func (c *Client) StreamTradeUpdatesInBackground(ctx context.Context, handler func(TradeUpdate)) {
+	c.StreamTradeUpdatesInBackgroundWithOpts(ctx, handler, StreamTradeUpdatesOpts{})
+}
+
+// StreamTradeUpdatesInBackgroundWithOpts streams the trade updates of the account.
+// It runs in the background and keeps calling the handler function for each trade update
+// until the context is cancelled.
+//
+// After a disconnect it reconnects with exponential backoff, asking the server for the events
+// since the ID of the last delivered one, so no events are lost during the outage.
+// Events replayed by the server are delivered only once.
+func (c *Client) StreamTradeUpdatesInBackgroundWithOpts(ctx context.Context, handler func(TradeUpdate), opts StreamTradeUpdatesOpts) {
+	if opts.MinBackoff == 0 {
+		opts.MinBackoff = 100 * time.Millisecond
+	}
+	if opts.MaxBackoff == 0 {
+		opts.MaxBackoff = 30 * time.Second
+	}
 	go func() {
-		var lastMessage time.Time
+		var (
+			lastID      = opts.SinceID
+			lastMessage time.Time
+			seen        = newRecentIDs(1024)
+			backoff     = opts.MinBackoff
+		)
 		for {
 			req := StreamTradeUpdatesRequest{}
-			if !lastMessage.IsZero() {
+			if lastID != "" {
+				req.SinceID = lastID
+			} else if !lastMessage.IsZero() {
 				req.Since = lastMessage.Add(time.Nanosecond)
 			}
-			err := c.StreamTradeUpdates(ctx, func(tu TradeUpdate) {
+			err := c.streamTradeUpdates(ctx, func(tu TradeUpdate) {
+				if tu.EventID != "" {
+					if !seen.add(tu.EventID) {
+						return
+					}
+					lastID = tu.EventID
+				}
 				lastMessage = tu.At
 				handler(tu)
-			}, req)
-			if err == nil || errors.Is(err, context.Canceled) {
+			}, req, func() {
+				backoff = opts.MinBackoff
+				if opts.OnConnect != nil {
+					opts.OnConnect()
+				}
+			})
+			if ctx.Err() != nil {
+				return
+			}
+			if opts.OnDisconnect != nil {
+				opts.OnDisconnect(err)
+			} else if err != nil {
+				log.Printf("alpaca stream trade updates error: %v", err)
+			}
+			if sleepContext(ctx, backoff) != nil {
 				return
 			}
-			log.Printf("alpaca stream trade updates error: %v", err)
+			backoff *= 2
+			if backoff > opts.MaxBackoff {
+				backoff = opts.MaxBackoff
+			}
 		}
 	}()
 }
 
+// recentIDs remembers the last n IDs added to it.
+type recentIDs struct {
+	ids  []string
+	set  map[string]struct{}
+	next int
+}
+
+func newRecentIDs(n int) *recentIDs {
+	return &recentIDs{ids: make([]string, n), set: make(map[string]struct{}, n)}
+}
+
+// add returns false if id is already remembered, otherwise it remembers it,
+// forgetting the oldest ID if needed.
+func (r *recentIDs) add(id string) bool {
+	if _, ok := r.set[id]; ok {
+		return false
+	}
+	if old := r.ids[r.next]; old != "" {
+		delete(r.set, old)
+	}
+	r.ids[r.next] = id
+	r.set[id] = struct{}{}
+	r.next = (r.next + 1) % len(r.ids)
+	return true
+}
+
 // StreamTradeUpdates streams the trade updates of the account. It blocks and keeps calling the handler
 // function for each trade update until the context is cancelled.
 func StreamTradeUpdates(ctx context.Context, handler func(TradeUpdate), req StreamTradeUpdatesRequest) error {
@@ -126,7 +213,14 @@ func StreamTradeUpdates(ctx context.Context, handler func(TradeUpdate), req Stre
 
 // StreamTradeUpdatesInBackground streams the trade updates of the account.
 // It runs in the background and keeps calling the handler function for each trade update
-// until the context is cancelled. If an error happens it logs it and retries immediately.
+// until the context is cancelled. If an error happens it logs it and reconnects,
+// resuming from the last delivered event.
 func StreamTradeUpdatesInBackground(ctx context.Context, handler func(TradeUpdate)) {
 	DefaultClient.StreamTradeUpdatesInBackground(ctx, handler)
 }
+
+// StreamTradeUpdatesInBackgroundWithOpts streams the trade updates of the account in the background,
+// reconnecting with backoff and resuming from the last delivered event after errors.
+func StreamTradeUpdatesInBackgroundWithOpts(ctx context.Context, handler func(TradeUpdate), opts StreamTradeUpdatesOpts) {
+	DefaultClient.StreamTradeUpdatesInBackgroundWithOpts(ctx, handler, opts)
+}
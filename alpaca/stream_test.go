@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -55,3 +56,82 @@ func TestStreamTradeUpdates(t *testing.T) {
 	}))
 	require.NoError(t, ctx.Err())
 }
+
+func TestStreamTradeUpdatesInBackgroundResumes(t *testing.T) {
+	var mu sync.Mutex
+	var sinceIDs []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		sinceIDs = append(sinceIDs, r.URL.Query().Get("since_id"))
+		conn := len(sinceIDs)
+		mu.Unlock()
+		flusher := w.(http.Flusher)
+		switch conn {
+		case 1:
+			fmt.Fprint(w, "data: {\"event_id\":\"01\",\"event\":\"new\"}\n\n")
+			fmt.Fprint(w, ": the id field is used when the payload has no event_id\n")
+			fmt.Fprint(w, "id: 02\ndata: {\"event\":\"partial_fill\"}\n\n")
+			flusher.Flush()
+			// the connection drops
+		case 2:
+			http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+		default:
+			// the server replays the last event
+			fmt.Fprint(w, "data: {\"event_id\":\"02\",\"event\":\"partial_fill\"}\n\n")
+			fmt.Fprint(w, "data: {\"event_id\":\"03\",\"event\":\"fill\"}\n\n")
+			flusher.Flush()
+			<-r.Context().Done()
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(ClientOpts{BaseURL: ts.URL})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan TradeUpdate, 10)
+	var connects, disconnects int
+	var disconnectErrs []error
+	c.StreamTradeUpdatesInBackgroundWithOpts(ctx, func(tu TradeUpdate) {
+		events <- tu
+	}, StreamTradeUpdatesOpts{
+		SinceID:    "00",
+		MinBackoff: time.Millisecond,
+		OnConnect: func() {
+			mu.Lock()
+			connects++
+			mu.Unlock()
+		},
+		OnDisconnect: func(err error) {
+			mu.Lock()
+			disconnects++
+			disconnectErrs = append(disconnectErrs, err)
+			mu.Unlock()
+		},
+	})
+
+	var got []string
+	for len(got) < 3 {
+		select {
+		case tu := <-events:
+			got = append(got, tu.EventID)
+		case <-time.After(3 * time.Second):
+			require.Fail(t, "timeout", "received %v", got)
+		}
+	}
+	assert.Equal(t, []string{"01", "02", "03"}, got)
+	select {
+	case tu := <-events:
+		assert.Fail(t, "unexpected event", tu.EventID)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"00", "02", "02"}, sinceIDs)
+	assert.Equal(t, 2, connects)
+	assert.Equal(t, 2, disconnects)
+	require.Len(t, disconnectErrs, 2)
+	assert.NoError(t, disconnectErrs[0])
+	require.Error(t, disconnectErrs[1])
+	assert.Contains(t, disconnectErrs[1].Error(), "HTTP 503")
+}
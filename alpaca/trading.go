@@ -14,10 +14,7 @@ import (
 // https://docs.alpaca.markets/docs/orders-at-alpaca#sub-penny-increments-for-limit-orders
 func RoundLimitPrice(price decimal.Decimal, side Side) *decimal.Decimal {
 	limitPrice := price.Copy()
-	maxDecimals := int32(2)
-	if price.LessThan(decimal.NewFromInt(1)) {
-		maxDecimals = 4
-	}
+	maxDecimals := maxPriceDecimals(price)
 	switch side {
 	case Buy:
 		limitPrice = price.RoundCeil(maxDecimals)
@@ -26,3 +23,11 @@ func RoundLimitPrice(price decimal.Decimal, side Side) *decimal.Decimal {
 	}
 	return &limitPrice
 }
+
+// maxPriceDecimals returns the number of decimals allowed by the minimum price variance rule.
+func maxPriceDecimals(price decimal.Decimal) int32 {
+	if price.LessThan(decimal.NewFromInt(1)) {
+		return 4
+	}
+	return 2
+}
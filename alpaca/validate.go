@@ -0,0 +1,403 @@
+package alpaca
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidOrder is matched (with errors.Is) by the errors returned by Validate.
+var ErrInvalidOrder = errors.New("invalid order")
+
+// FieldError is a problem with a single field of an order request.
+type FieldError struct {
+	// Field is the JSON name of the field, e.g. "limit_price" or "stop_loss.stop_price".
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError lists every problem Validate found in an order request.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		msgs[i] = fe.Error()
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidOrder, strings.Join(msgs, "; "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidOrder
+}
+
+// HasField tells whether the request was rejected because of field.
+func (e *ValidationError) HasField(field string) bool {
+	for _, fe := range e.Errors {
+		if fe.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+type fieldErrors []FieldError
+
+func (errs *fieldErrors) add(field, format string, a ...interface{}) {
+	*errs = append(*errs, FieldError{Field: field, Message: fmt.Sprintf(format, a...)})
+}
+
+func (errs fieldErrors) err() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: errs}
+}
+
+// Validate checks the request against the rules the server would reject it for, so that
+// mistakes are caught before the order is submitted. It returns a *ValidationError listing
+// every invalid field, or nil.
+//
+// If asset is not nil, the request is also checked against it: the asset must be active and
+// tradable, and fractional quantities or notional amounts require a fractionable asset.
+// Without an asset, symbols containing a slash (e.g. BTC/USD) are treated as crypto.
+func (r PlaceOrderRequest) Validate(asset *Asset) error {
+	var errs fieldErrors
+
+	class := orderAssetClass(r.Symbol, asset)
+	if r.OrderClass == MLeg || len(r.Legs) > 0 {
+		if err := checkLegs(r); err != nil {
+			errs.add("legs", "%v", err)
+		}
+	} else {
+		if r.Symbol == "" {
+			errs.add("symbol", "required")
+		}
+		if r.Side != Buy && r.Side != Sell {
+			errs.add("side", "must be %q or %q, got %q", Buy, Sell, r.Side)
+		}
+		switch {
+		case r.Qty == nil && r.Notional == nil:
+			errs.add("qty", "either qty or notional is required")
+		case r.Qty != nil && r.Notional != nil:
+			errs.add("notional", "qty and notional are mutually exclusive")
+		case r.Qty != nil && !r.Qty.IsPositive():
+			errs.add("qty", "must be positive")
+		case r.Notional != nil && !r.Notional.IsPositive():
+			errs.add("notional", "must be positive")
+		}
+	}
+
+	if !r.TimeInForce.valid() {
+		errs.add("time_in_force", "invalid value %q", r.TimeInForce)
+	} else if class == Crypto && r.TimeInForce != GTC && r.TimeInForce != IOC {
+		errs.add("time_in_force", "crypto orders must be %q or %q", GTC, IOC)
+	}
+
+	r.validatePrices(&errs, class)
+	r.validateOrderClass(&errs, class)
+
+	if r.ExtendedHours {
+		if r.Type != Limit {
+			errs.add("extended_hours", "requires a %s order", Limit)
+		}
+		if r.TimeInForce != Day {
+			errs.add("extended_hours", "requires time in force %q", Day)
+		}
+	}
+
+	if asset != nil {
+		if asset.Status != AssetActive {
+			errs.add("symbol", "asset %s is not active", asset.Symbol)
+		} else if !asset.Tradable {
+			errs.add("symbol", "asset %s is not tradable", asset.Symbol)
+		}
+		fractional := r.Notional != nil || (r.Qty != nil && !r.Qty.IsInteger())
+		if fractional && !asset.Fractionable {
+			if r.Notional != nil {
+				errs.add("notional", "asset %s is not fractionable", asset.Symbol)
+			} else {
+				errs.add("qty", "asset %s is not fractionable", asset.Symbol)
+			}
+		}
+	}
+	if class == USEquity && r.Qty != nil && !r.Qty.IsInteger() && r.TimeInForce != Day {
+		errs.add("time_in_force", "fractional orders must be %q", Day)
+	}
+
+	return errs.err()
+}
+
+func (r PlaceOrderRequest) validatePrices(errs *fieldErrors, class AssetClass) {
+	switch r.Type {
+	case Market:
+		if r.LimitPrice != nil {
+			errs.add("limit_price", "not allowed for %s orders", r.Type)
+		}
+		if r.StopPrice != nil {
+			errs.add("stop_price", "not allowed for %s orders", r.Type)
+		}
+	case Limit:
+		// The prices of OCO orders are those of their take profit and stop loss legs.
+		if r.LimitPrice == nil && r.OrderClass != OCO {
+			errs.add("limit_price", "required for %s orders", r.Type)
+		}
+		if r.StopPrice != nil {
+			errs.add("stop_price", "not allowed for %s orders", r.Type)
+		}
+	case Stop:
+		if r.StopPrice == nil {
+			errs.add("stop_price", "required for %s orders", r.Type)
+		}
+		if r.LimitPrice != nil {
+			errs.add("limit_price", "not allowed for %s orders", r.Type)
+		}
+	case StopLimit:
+		if r.LimitPrice == nil {
+			errs.add("limit_price", "required for %s orders", r.Type)
+		}
+		if r.StopPrice == nil {
+			errs.add("stop_price", "required for %s orders", r.Type)
+		}
+	case TrailingStop:
+		if (r.TrailPrice == nil) == (r.TrailPercent == nil) {
+			errs.add("trail_price", "exactly one of trail_price and trail_percent is required")
+		}
+		if r.LimitPrice != nil {
+			errs.add("limit_price", "not allowed for %s orders", r.Type)
+		}
+		if r.StopPrice != nil {
+			errs.add("stop_price", "not allowed for %s orders", r.Type)
+		}
+	default:
+		errs.add("type", "invalid value %q", r.Type)
+	}
+	if r.Type != TrailingStop {
+		if r.TrailPrice != nil {
+			errs.add("trail_price", "only allowed for %s orders", TrailingStop)
+		}
+		if r.TrailPercent != nil {
+			errs.add("trail_percent", "only allowed for %s orders", TrailingStop)
+		}
+	}
+	if r.TrailPrice != nil && !r.TrailPrice.IsPositive() {
+		errs.add("trail_price", "must be positive")
+	}
+	if r.TrailPercent != nil && (!r.TrailPercent.IsPositive() || r.TrailPercent.GreaterThanOrEqual(decimal.NewFromInt(100))) {
+		errs.add("trail_percent", "must be between 0 and 100")
+	}
+
+	validatePrice(errs, "limit_price", r.LimitPrice, class)
+	validatePrice(errs, "stop_price", r.StopPrice, class)
+	if r.TakeProfit != nil {
+		validatePrice(errs, "take_profit.limit_price", r.TakeProfit.LimitPrice, class)
+	}
+	if r.StopLoss != nil {
+		validatePrice(errs, "stop_loss.stop_price", r.StopLoss.StopPrice, class)
+		validatePrice(errs, "stop_loss.limit_price", r.StopLoss.LimitPrice, class)
+	}
+}
+
+func (r PlaceOrderRequest) validateOrderClass(errs *fieldErrors, class AssetClass) {
+	switch r.OrderClass {
+	case "", Simple, MLeg:
+		if r.TakeProfit != nil {
+			errs.add("take_profit", "only allowed for %s, %s and %s orders", Bracket, OTO, OCO)
+		}
+		if r.StopLoss != nil {
+			errs.add("stop_loss", "only allowed for %s, %s and %s orders", Bracket, OTO, OCO)
+		}
+		return
+	case Bracket:
+		if r.TakeProfit == nil || r.TakeProfit.LimitPrice == nil {
+			errs.add("take_profit.limit_price", "required for %s orders", r.OrderClass)
+		}
+		if r.StopLoss == nil || r.StopLoss.StopPrice == nil {
+			errs.add("stop_loss.stop_price", "required for %s orders", r.OrderClass)
+		}
+	case OTO:
+		if r.TakeProfit == nil && r.StopLoss == nil {
+			errs.add("take_profit", "either take_profit or stop_loss is required for %s orders", r.OrderClass)
+		}
+		if r.TakeProfit != nil && r.StopLoss != nil {
+			errs.add("stop_loss", "take_profit and stop_loss are mutually exclusive for %s orders", r.OrderClass)
+		}
+	case OCO:
+		if r.Type != Limit {
+			errs.add("type", "%s orders must be %s orders", r.OrderClass, Limit)
+		}
+		if r.TakeProfit == nil || r.TakeProfit.LimitPrice == nil {
+			errs.add("take_profit.limit_price", "required for %s orders", r.OrderClass)
+		}
+		if r.StopLoss == nil || r.StopLoss.StopPrice == nil {
+			errs.add("stop_loss.stop_price", "required for %s orders", r.OrderClass)
+		}
+	default:
+		errs.add("order_class", "invalid value %q", r.OrderClass)
+		return
+	}
+	if r.TimeInForce != Day && r.TimeInForce != GTC {
+		errs.add("time_in_force", "%s orders must be %q or %q", r.OrderClass, Day, GTC)
+	}
+	if r.Notional != nil {
+		errs.add("notional", "not allowed for %s orders", r.OrderClass)
+	}
+	if class == Crypto {
+		errs.add("order_class", "%s orders are not supported for crypto", r.OrderClass)
+	}
+}
+
+// Validate checks the request against the rules the server would reject it for. It returns
+// a *ValidationError listing every invalid field, or nil. If asset is not nil, fractional
+// quantities are only accepted for fractionable assets and the prices are checked against
+// the price increment of the asset class. Without the asset, only the sign of the prices is checked.
+func (r ReplaceOrderRequest) Validate(asset *Asset) error {
+	var errs fieldErrors
+
+	if r.Qty == nil && r.LimitPrice == nil && r.StopPrice == nil && r.Trail == nil &&
+		r.TimeInForce == "" && r.ClientOrderID == "" {
+		errs.add("qty", "at least one field must be replaced")
+	}
+	if r.Qty != nil {
+		if !r.Qty.IsPositive() {
+			errs.add("qty", "must be positive")
+		} else if asset != nil && !asset.Fractionable && !r.Qty.IsInteger() {
+			errs.add("qty", "asset %s is not fractionable", asset.Symbol)
+		}
+	}
+	if r.TimeInForce != "" && !r.TimeInForce.valid() {
+		errs.add("time_in_force", "invalid value %q", r.TimeInForce)
+	}
+	if r.Trail != nil && !r.Trail.IsPositive() {
+		errs.add("trail", "must be positive")
+	}
+	// An empty class skips the price increment rule.
+	var class AssetClass
+	if asset != nil {
+		class = USEquity
+		if asset.Class != "" {
+			class = asset.Class
+		}
+	}
+	validatePrice(&errs, "limit_price", r.LimitPrice, class)
+	validatePrice(&errs, "stop_price", r.StopPrice, class)
+
+	return errs.err()
+}
+
+// validatePrice checks that price is positive and, except for crypto, options and an unknown (empty)
+// class, that it respects the minimum price variance rule (see RoundLimitPrice).
+func validatePrice(errs *fieldErrors, field string, price *decimal.Decimal, class AssetClass) {
+	if price == nil {
+		return
+	}
+	if !price.IsPositive() {
+		errs.add(field, "must be positive")
+		return
+	}
+	if class == Crypto || class == USOption || class == "" {
+		return
+	}
+	if maxDecimals := maxPriceDecimals(*price); !price.Equal(price.Truncate(maxDecimals)) {
+		errs.add(field, "%s has more than %d decimals", price, maxDecimals)
+	}
+}
+
+// orderAssetClass returns the class of the asset traded, guessing it from the symbol if the asset is unknown.
+func orderAssetClass(symbol string, asset *Asset) AssetClass {
+	switch {
+	case asset != nil:
+		return asset.Class
+	case strings.Contains(symbol, "/"):
+		return Crypto
+	case isOptionSymbol(symbol):
+		return USOption
+	default:
+		return USEquity
+	}
+}
+
+// isOptionSymbol tells whether symbol is an OCC option symbol, e.g. "AAPL250620C00200000":
+// the root symbol, the expiration date (YYMMDD), C or P and the strike price times 1000 on 8 digits.
+func isOptionSymbol(symbol string) bool {
+	const suffixLen = 6 + 1 + 8
+	root := len(symbol) - suffixLen
+	if root < 1 || root > 6 {
+		return false
+	}
+	for i, r := range symbol[root:] {
+		switch {
+		case i == 6:
+			if r != 'C' && r != 'P' {
+				return false
+			}
+		case r < '0' || r > '9':
+			return false
+		}
+	}
+	return true
+}
+
+func (tif TimeInForce) valid() bool {
+	switch tif {
+	case Day, GTC, OPG, IOC, FOK, GTX, GTD, CLS:
+		return true
+	}
+	return false
+}
+
+// validateReplace validates req before it is submitted when ClientOpts.ValidateOrders is set.
+// The order is only fetched, to find the asset traded, if prices are replaced.
+func (c *Client) validateReplace(ctx context.Context, orderID string, req ReplaceOrderRequest) error {
+	var asset *Asset
+	if req.LimitPrice != nil || req.StopPrice != nil {
+		order, err := c.GetOrderWithContext(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get order %s for validation: %w", orderID, err)
+		}
+		if asset, err = c.cachedAsset(ctx, order.Symbol); err != nil {
+			return err
+		}
+	}
+	return req.Validate(asset)
+}
+
+// validateOrder validates req before it is submitted when ClientOpts.ValidateOrders is set.
+func (c *Client) validateOrder(ctx context.Context, req PlaceOrderRequest) error {
+	var asset *Asset
+	if req.Symbol != "" && len(req.Legs) == 0 {
+		var err error
+		if asset, err = c.cachedAsset(ctx, req.Symbol); err != nil {
+			return err
+		}
+	}
+	return req.Validate(asset)
+}
+
+// cachedAsset returns the asset of symbol, fetching it only the first time. It returns nil
+// without an error for symbols the assets endpoint does not know (e.g. option contracts).
+func (c *Client) cachedAsset(ctx context.Context, symbol string) (*Asset, error) {
+	if asset, ok := c.assets.Load(symbol); ok {
+		return asset.(*Asset), nil
+	}
+	asset, err := c.GetAssetWithContext(ctx, symbol)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get asset %s for validation: %w", symbol, err)
+	}
+	c.assets.Store(symbol, asset)
+	return asset, nil
+}
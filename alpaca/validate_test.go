@@ -0,0 +1,278 @@
+package alpaca
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaceOrderRequestValidate(t *testing.T) {
+	dec := func(s string) *decimal.Decimal {
+		d := decimal.RequireFromString(s)
+		return &d
+	}
+	equity := &Asset{Symbol: "AAPL", Class: USEquity, Status: AssetActive, Tradable: true, Fractionable: true}
+
+	tests := []struct {
+		name   string
+		req    PlaceOrderRequest
+		asset  *Asset
+		fields []string
+	}{
+		{
+			name: "valid market order",
+			req:  PlaceOrderRequest{Symbol: "AAPL", Qty: dec("1"), Side: Buy, Type: Market, TimeInForce: Day},
+		},
+		{
+			name: "valid limit order below one dollar",
+			req: PlaceOrderRequest{
+				Symbol: "XYZ", Qty: dec("100"), Side: Sell, Type: Limit, TimeInForce: GTC, LimitPrice: dec("0.1234"),
+			},
+		},
+		{
+			name: "valid bracket order",
+			req: PlaceOrderRequest{
+				Symbol: "AAPL", Qty: dec("10"), Side: Buy, Type: Limit, TimeInForce: GTC, LimitPrice: dec("180"),
+				OrderClass: Bracket,
+				TakeProfit: &TakeProfit{LimitPrice: dec("190")},
+				StopLoss:   &StopLoss{StopPrice: dec("170"), LimitPrice: dec("169.5")},
+			},
+		},
+		{
+			name: "valid crypto order with many decimals",
+			req: PlaceOrderRequest{
+				Symbol: "BTC/USD", Qty: dec("0.0001"), Side: Buy, Type: Limit, TimeInForce: GTC, LimitPrice: dec("65000.123456"),
+			},
+		},
+		{
+			name:   "missing fields",
+			req:    PlaceOrderRequest{},
+			fields: []string{"symbol", "side", "qty", "time_in_force", "type"},
+		},
+		{
+			name: "limit price with too many decimals",
+			req: PlaceOrderRequest{
+				Symbol: "AAPL", Qty: dec("1"), Side: Buy, Type: Limit, TimeInForce: Day, LimitPrice: dec("180.123"),
+			},
+			fields: []string{"limit_price"},
+		},
+		{
+			name: "qty and notional",
+			req: PlaceOrderRequest{
+				Symbol: "AAPL", Qty: dec("1"), Notional: dec("100"), Side: Buy, Type: Market, TimeInForce: Day,
+			},
+			fields: []string{"notional"},
+		},
+		{
+			name: "bracket without legs",
+			req: PlaceOrderRequest{
+				Symbol: "AAPL", Qty: dec("1"), Side: Buy, Type: Market, TimeInForce: Day, OrderClass: Bracket,
+			},
+			fields: []string{"take_profit.limit_price", "stop_loss.stop_price"},
+		},
+		{
+			name: "take profit on a simple order",
+			req: PlaceOrderRequest{
+				Symbol: "AAPL", Qty: dec("1"), Side: Buy, Type: Market, TimeInForce: Day,
+				TakeProfit: &TakeProfit{LimitPrice: dec("190")},
+			},
+			fields: []string{"take_profit"},
+		},
+		{
+			name: "trailing stop with both trail fields",
+			req: PlaceOrderRequest{
+				Symbol: "AAPL", Qty: dec("1"), Side: Sell, Type: TrailingStop, TimeInForce: Day,
+				TrailPrice: dec("1"), TrailPercent: dec("2"),
+			},
+			fields: []string{"trail_price"},
+		},
+		{
+			name: "trail percent on a limit order",
+			req: PlaceOrderRequest{
+				Symbol: "AAPL", Qty: dec("1"), Side: Sell, Type: Limit, TimeInForce: Day, LimitPrice: dec("10"),
+				TrailPercent: dec("2"),
+			},
+			fields: []string{"trail_percent"},
+		},
+		{
+			name: "stop limit without stop price",
+			req: PlaceOrderRequest{
+				Symbol: "AAPL", Qty: dec("1"), Side: Sell, Type: StopLimit, TimeInForce: Day, LimitPrice: dec("10"),
+			},
+			fields: []string{"stop_price"},
+		},
+		{
+			name: "crypto day order",
+			req: PlaceOrderRequest{
+				Symbol: "BTC/USD", Qty: dec("1"), Side: Buy, Type: Market, TimeInForce: Day,
+			},
+			fields: []string{"time_in_force"},
+		},
+		{
+			name: "extended hours market order",
+			req: PlaceOrderRequest{
+				Symbol: "AAPL", Qty: dec("1"), Side: Buy, Type: Market, TimeInForce: GTC, ExtendedHours: true,
+			},
+			fields: []string{"extended_hours"},
+		},
+		{
+			name: "fractional qty on non-fractionable asset",
+			req: PlaceOrderRequest{
+				Symbol: "BRK.A", Qty: dec("0.5"), Side: Buy, Type: Market, TimeInForce: Day,
+			},
+			asset:  &Asset{Symbol: "BRK.A", Class: USEquity, Status: AssetActive, Tradable: true},
+			fields: []string{"qty"},
+		},
+		{
+			name: "fractional gtc order",
+			req: PlaceOrderRequest{
+				Symbol: "AAPL", Qty: dec("0.5"), Side: Buy, Type: Market, TimeInForce: GTC,
+			},
+			asset:  equity,
+			fields: []string{"time_in_force"},
+		},
+		{
+			name: "inactive asset",
+			req: PlaceOrderRequest{
+				Symbol: "AAPL", Qty: dec("1"), Side: Buy, Type: Market, TimeInForce: Day,
+			},
+			asset:  &Asset{Symbol: "AAPL", Class: USEquity, Status: AssetInactive},
+			fields: []string{"symbol"},
+		},
+		{
+			name: "invalid legs",
+			req: PlaceOrderRequest{
+				Qty: dec("1"), Type: Market, TimeInForce: Day, OrderClass: MLeg,
+				Legs: []OrderLeg{{Symbol: "A", RatioQty: decimal.NewFromInt(1), Side: Buy}},
+			},
+			fields: []string{"legs"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate(tt.asset)
+			if len(tt.fields) == 0 {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidOrder)
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			for _, field := range tt.fields {
+				assert.True(t, validationErr.HasField(field), "expected an error for %s, got %v", field, err)
+			}
+		})
+	}
+}
+
+func TestReplaceOrderRequestValidate(t *testing.T) {
+	half := decimal.RequireFromString("0.5")
+	price := decimal.RequireFromString("10.005")
+	zero := decimal.Zero
+
+	assert.ErrorIs(t, ReplaceOrderRequest{}.Validate(nil), ErrInvalidOrder)
+	assert.NoError(t, ReplaceOrderRequest{Qty: &half}.Validate(nil))
+	assert.NoError(t, ReplaceOrderRequest{TimeInForce: GTC}.Validate(nil))
+	assert.NoError(t, ReplaceOrderRequest{LimitPrice: &price}.Validate(nil), "the price increment of an unknown asset is not checked")
+	assert.NoError(t, ReplaceOrderRequest{LimitPrice: &price}.Validate(&Asset{Symbol: "BTC/USD", Class: Crypto}))
+
+	err := ReplaceOrderRequest{Qty: &half, LimitPrice: &price, Trail: &zero, TimeInForce: "forever"}.
+		Validate(&Asset{Symbol: "BRK.A"})
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Len(t, validationErr.Errors, 4)
+	for _, field := range []string{"qty", "limit_price", "trail", "time_in_force"} {
+		assert.True(t, validationErr.HasField(field), field)
+	}
+	assert.Contains(t, err.Error(), "limit_price: 10.005 has more than 2 decimals")
+}
+
+func TestPlaceOrder_ValidateOrders(t *testing.T) {
+	c := NewClient(ClientOpts{ValidateOrders: true})
+	assetRequests, orderRequests := 0, 0
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		switch {
+		case req.URL.Path == "/v2/assets/AAPL":
+			assetRequests++
+			return &http.Response{
+				Body: io.NopCloser(strings.NewReader(
+					`{"symbol":"AAPL","class":"us_equity","status":"active","tradable":true,"fractionable":false}`)),
+			}, nil
+		case strings.HasPrefix(req.URL.Path, "/v2/assets/"):
+			return nil, &APIError{StatusCode: http.StatusNotFound, Message: "asset not found"}
+		case req.URL.Path == "/v2/orders":
+			orderRequests++
+			return &http.Response{Body: io.NopCloser(strings.NewReader(`{"id":"order"}`))}, nil
+		}
+		return nil, fmt.Errorf("unexpected request %s", req.URL)
+	}
+
+	qty := decimal.RequireFromString("0.5")
+	_, err := c.PlaceOrder(PlaceOrderRequest{Symbol: "AAPL", Qty: &qty, Side: Buy, Type: Market, TimeInForce: Day})
+	require.ErrorIs(t, err, ErrInvalidOrder)
+	assert.Contains(t, err.Error(), "not fractionable")
+
+	qty = decimal.NewFromInt(1)
+	order, err := c.PlaceOrder(PlaceOrderRequest{Symbol: "AAPL", Qty: &qty, Side: Buy, Type: Market, TimeInForce: Day})
+	require.NoError(t, err)
+	assert.Equal(t, "order", order.ID)
+	assert.Equal(t, 1, assetRequests, "the asset should be cached")
+
+	// Unknown assets only get the checks that do not need the asset.
+	_, err = c.PlaceOrder(PlaceOrderRequest{Symbol: "UNKNOWN", Qty: &qty, Side: Buy, Type: Market, TimeInForce: Day})
+	require.NoError(t, err)
+
+	_, err = c.ReplaceOrder("order", ReplaceOrderRequest{})
+	require.ErrorIs(t, err, ErrInvalidOrder)
+	assert.Equal(t, 2, orderRequests)
+}
+
+func TestReplaceOrder_ValidateOrders(t *testing.T) {
+	c := NewClient(ClientOpts{ValidateOrders: true})
+	replaced := 0
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/v2/orders/crypto":
+			return &http.Response{Body: io.NopCloser(strings.NewReader(`{"id":"crypto","symbol":"BTC/USD"}`))}, nil
+		case req.Method == http.MethodGet && req.URL.Path == "/v2/orders/equity":
+			return &http.Response{Body: io.NopCloser(strings.NewReader(`{"id":"equity","symbol":"AAPL"}`))}, nil
+		case req.Method == http.MethodGet && req.URL.Path == "/v2/orders/option":
+			return &http.Response{Body: io.NopCloser(strings.NewReader(`{"id":"option","symbol":"AAPL250620C00200000"}`))}, nil
+		case req.URL.Path == "/v2/assets/BTC/USD":
+			return &http.Response{Body: io.NopCloser(strings.NewReader(`{"symbol":"BTC/USD","class":"crypto"}`))}, nil
+		case req.URL.Path == "/v2/assets/AAPL":
+			return &http.Response{Body: io.NopCloser(strings.NewReader(`{"symbol":"AAPL","class":"us_equity"}`))}, nil
+		case strings.HasPrefix(req.URL.Path, "/v2/assets/"):
+			return nil, &APIError{StatusCode: http.StatusNotFound, Message: "asset not found"}
+		case req.Method == http.MethodPatch:
+			replaced++
+			return &http.Response{Body: io.NopCloser(strings.NewReader(`{"id":"replacement"}`))}, nil
+		}
+		return nil, fmt.Errorf("unexpected request %s %s", req.Method, req.URL)
+	}
+
+	price := decimal.RequireFromString("65000.123")
+	_, err := c.ReplaceOrder("crypto", ReplaceOrderRequest{LimitPrice: &price})
+	require.NoError(t, err)
+	_, err = c.ReplaceOrder("option", ReplaceOrderRequest{LimitPrice: &price})
+	require.NoError(t, err)
+	_, err = c.ReplaceOrder("equity", ReplaceOrderRequest{LimitPrice: &price})
+	require.ErrorIs(t, err, ErrInvalidOrder)
+	assert.Equal(t, 2, replaced)
+}
+
+func TestIsOptionSymbol(t *testing.T) {
+	assert.True(t, isOptionSymbol("AAPL250620C00200000"))
+	assert.True(t, isOptionSymbol("F250620P00012500"))
+	assert.False(t, isOptionSymbol("AAPL"))
+	assert.False(t, isOptionSymbol("BTC/USD"))
+	assert.False(t, isOptionSymbol("AAPL250620X00200000"))
+	assert.False(t, isOptionSymbol("TOOLONG250620C00200000"))
+}
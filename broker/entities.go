@@ -0,0 +1,236 @@
+package broker
+
+import (
+	json "encoding/json"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/shopspring/decimal"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+
+	// Required for easyjson generation
+	_ "github.com/mailru/easyjson/gen"
+)
+
+//go:generate go install github.com/mailru/easyjson/...@v0.7.7
+//go:generate easyjson -all -snake_case $GOFILE
+
+// Account is a brokerage account opened on behalf of an end user.
+// The trading details of the account (cash, buying power, etc.) are returned by GetTradingAccount.
+type Account struct {
+	ID             string          `json:"id"`
+	AccountNumber  string          `json:"account_number"`
+	Status         string          `json:"status"`
+	CryptoStatus   string          `json:"crypto_status"`
+	Currency       string          `json:"currency"`
+	LastEquity     decimal.Decimal `json:"last_equity"`
+	CreatedAt      time.Time       `json:"created_at"`
+	AccountType    string          `json:"account_type"`
+	EnabledAssets  []string        `json:"enabled_assets"`
+	Contact        *Contact        `json:"contact"`
+	Identity       *Identity       `json:"identity"`
+	Disclosures    *Disclosures    `json:"disclosures"`
+	Agreements     []Agreement     `json:"agreements"`
+	TrustedContact *TrustedContact `json:"trusted_contact"`
+}
+
+//easyjson:json
+type accountSlice []Account
+
+type Contact struct {
+	EmailAddress  string   `json:"email_address,omitempty"`
+	PhoneNumber   string   `json:"phone_number,omitempty"`
+	StreetAddress []string `json:"street_address,omitempty"`
+	Unit          string   `json:"unit,omitempty"`
+	City          string   `json:"city,omitempty"`
+	State         string   `json:"state,omitempty"`
+	PostalCode    string   `json:"postal_code,omitempty"`
+	Country       string   `json:"country,omitempty"`
+}
+
+type Identity struct {
+	GivenName             string           `json:"given_name,omitempty"`
+	MiddleName            string           `json:"middle_name,omitempty"`
+	FamilyName            string           `json:"family_name,omitempty"`
+	DateOfBirth           *civil.Date      `json:"date_of_birth,omitempty"`
+	TaxID                 string           `json:"tax_id,omitempty"`
+	TaxIDType             string           `json:"tax_id_type,omitempty"`
+	CountryOfCitizenship  string           `json:"country_of_citizenship,omitempty"`
+	CountryOfBirth        string           `json:"country_of_birth,omitempty"`
+	CountryOfTaxResidence string           `json:"country_of_tax_residence,omitempty"`
+	FundingSource         []string         `json:"funding_source,omitempty"`
+	AnnualIncomeMin       *decimal.Decimal `json:"annual_income_min,omitempty"`
+	AnnualIncomeMax       *decimal.Decimal `json:"annual_income_max,omitempty"`
+	LiquidNetWorthMin     *decimal.Decimal `json:"liquid_net_worth_min,omitempty"`
+	LiquidNetWorthMax     *decimal.Decimal `json:"liquid_net_worth_max,omitempty"`
+	TotalNetWorthMin      *decimal.Decimal `json:"total_net_worth_min,omitempty"`
+	TotalNetWorthMax      *decimal.Decimal `json:"total_net_worth_max,omitempty"`
+}
+
+type Disclosures struct {
+	IsControlPerson             bool   `json:"is_control_person"`
+	IsAffiliatedExchangeOrFinra bool   `json:"is_affiliated_exchange_or_finra"`
+	IsPoliticallyExposed        bool   `json:"is_politically_exposed"`
+	ImmediateFamilyExposed      bool   `json:"immediate_family_exposed"`
+	EmploymentStatus            string `json:"employment_status,omitempty"`
+	EmployerName                string `json:"employer_name,omitempty"`
+	EmployerAddress             string `json:"employer_address,omitempty"`
+	EmploymentPosition          string `json:"employment_position,omitempty"`
+}
+
+type Agreement struct {
+	// Agreement is one of margin_agreement, account_agreement, customer_agreement or crypto_agreement.
+	Agreement string    `json:"agreement"`
+	SignedAt  time.Time `json:"signed_at"`
+	IPAddress string    `json:"ip_address"`
+	Revision  string    `json:"revision,omitempty"`
+}
+
+type TrustedContact struct {
+	GivenName    string `json:"given_name,omitempty"`
+	FamilyName   string `json:"family_name,omitempty"`
+	EmailAddress string `json:"email_address,omitempty"`
+	PhoneNumber  string `json:"phone_number,omitempty"`
+}
+
+type BankAccountType string
+
+const (
+	Checking BankAccountType = "CHECKING"
+	Savings  BankAccountType = "SAVINGS"
+)
+
+// ACHRelationship links a bank account to a brokerage account for ACH transfers.
+type ACHRelationship struct {
+	ID                string          `json:"id"`
+	AccountID         string          `json:"account_id"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+	Status            string          `json:"status"`
+	AccountOwnerName  string          `json:"account_owner_name"`
+	BankAccountType   BankAccountType `json:"bank_account_type"`
+	BankAccountNumber string          `json:"bank_account_number"`
+	BankRoutingNumber string          `json:"bank_routing_number"`
+	Nickname          string          `json:"nickname"`
+}
+
+//easyjson:json
+type achRelationshipSlice []ACHRelationship
+
+type TransferType string
+
+const (
+	ACH  TransferType = "ach"
+	Wire TransferType = "wire"
+)
+
+type TransferDirection string
+
+const (
+	Incoming TransferDirection = "INCOMING"
+	Outgoing TransferDirection = "OUTGOING"
+)
+
+type Transfer struct {
+	ID             string            `json:"id"`
+	RelationshipID string            `json:"relationship_id"`
+	AccountID      string            `json:"account_id"`
+	Type           TransferType      `json:"type"`
+	Status         string            `json:"status"`
+	Reason         *string           `json:"reason"`
+	Amount         decimal.Decimal   `json:"amount"`
+	Direction      TransferDirection `json:"direction"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	ExpiresAt      *time.Time        `json:"expires_at"`
+}
+
+//easyjson:json
+type transferSlice []Transfer
+
+type JournalEntryType string
+
+const (
+	// JournalCash moves cash between two accounts.
+	JournalCash JournalEntryType = "JNLC"
+	// JournalSecurities moves shares between two accounts.
+	JournalSecurities JournalEntryType = "JNLS"
+)
+
+type Journal struct {
+	ID          string           `json:"id"`
+	EntryType   JournalEntryType `json:"entry_type"`
+	FromAccount string           `json:"from_account"`
+	ToAccount   string           `json:"to_account"`
+	Status      string           `json:"status"`
+	NetAmount   decimal.Decimal  `json:"net_amount"`
+	Symbol      string           `json:"symbol"`
+	Qty         *decimal.Decimal `json:"qty"`
+	Price       *decimal.Decimal `json:"price"`
+	Description string           `json:"description"`
+	SettleDate  *civil.Date      `json:"settle_date"`
+	SystemDate  *civil.Date      `json:"system_date"`
+}
+
+//easyjson:json
+type journalSlice []Journal
+
+//easyjson:json
+type orderSlice []alpaca.Order
+
+//easyjson:json
+type positionSlice []alpaca.Position
+
+// AccountStatusEvent is a status change of an account, sent by StreamAccountStatusEvents.
+type AccountStatusEvent struct {
+	EventID          int64     `json:"event_id"`
+	EventULID        string    `json:"event_ulid"`
+	AccountID        string    `json:"account_id"`
+	AccountNumber    string    `json:"account_number"`
+	At               time.Time `json:"at"`
+	StatusFrom       string    `json:"status_from"`
+	StatusTo         string    `json:"status_to"`
+	CryptoStatusFrom string    `json:"crypto_status_from"`
+	CryptoStatusTo   string    `json:"crypto_status_to"`
+	Reason           string    `json:"reason"`
+}
+
+// TransferStatusEvent is a status change of a transfer, sent by StreamTransferStatusEvents.
+type TransferStatusEvent struct {
+	EventID    int64     `json:"event_id"`
+	EventULID  string    `json:"event_ulid"`
+	TransferID string    `json:"transfer_id"`
+	AccountID  string    `json:"account_id"`
+	At         time.Time `json:"at"`
+	StatusFrom string    `json:"status_from"`
+	StatusTo   string    `json:"status_to"`
+}
+
+// JournalStatusEvent is a status change of a journal, sent by StreamJournalStatusEvents.
+type JournalStatusEvent struct {
+	EventID    int64            `json:"event_id"`
+	EventULID  string           `json:"event_ulid"`
+	JournalID  string           `json:"journal_id"`
+	EntryType  JournalEntryType `json:"entry_type"`
+	At         time.Time        `json:"at"`
+	StatusFrom string           `json:"status_from"`
+	StatusTo   string           `json:"status_to"`
+}
+
+// TradeEvent is a trade update of one of the accounts, sent by StreamTradeEvents.
+type TradeEvent struct {
+	AccountID string `json:"account_id"`
+	alpaca.TradeUpdate
+}
+
+//easyjson:json
+type multiStatusSlice []multiStatusResponse
+
+// multiStatusResponse is an entry of the 207 responses of the bulk cancel and close endpoints.
+type multiStatusResponse struct {
+	ID     string          `json:"id"`
+	Symbol string          `json:"symbol"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
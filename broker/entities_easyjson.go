@@ -0,0 +1,2722 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package broker
+
+import (
+	civil "cloud.google.com/go/civil"
+	json "encoding/json"
+	alpaca "github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+	decimal "github.com/shopspring/decimal"
+	time "time"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker(in *jlexer.Lexer, out *transferSlice) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(transferSlice, 0, 0)
+			} else {
+				*out = transferSlice{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 Transfer
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker(out *jwriter.Writer, in transferSlice) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v transferSlice) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v transferSlice) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *transferSlice) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *transferSlice) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker1(in *jlexer.Lexer, out *positionSlice) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(positionSlice, 0, 0)
+			} else {
+				*out = positionSlice{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 alpaca.Position
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker1(out *jwriter.Writer, in positionSlice) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v positionSlice) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v positionSlice) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *positionSlice) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *positionSlice) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker1(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker2(in *jlexer.Lexer, out *orderSlice) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(orderSlice, 0, 0)
+			} else {
+				*out = orderSlice{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v7 alpaca.Order
+			(v7).UnmarshalEasyJSON(in)
+			*out = append(*out, v7)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker2(out *jwriter.Writer, in orderSlice) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v8, v9 := range in {
+			if v8 > 0 {
+				out.RawByte(',')
+			}
+			(v9).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v orderSlice) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v orderSlice) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *orderSlice) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *orderSlice) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker2(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker3(in *jlexer.Lexer, out *multiStatusSlice) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(multiStatusSlice, 0, 1)
+			} else {
+				*out = multiStatusSlice{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v10 multiStatusResponse
+			(v10).UnmarshalEasyJSON(in)
+			*out = append(*out, v10)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker3(out *jwriter.Writer, in multiStatusSlice) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v11, v12 := range in {
+			if v11 > 0 {
+				out.RawByte(',')
+			}
+			(v12).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v multiStatusSlice) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v multiStatusSlice) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *multiStatusSlice) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *multiStatusSlice) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker3(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker4(in *jlexer.Lexer, out *multiStatusResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "symbol":
+			out.Symbol = string(in.String())
+		case "status":
+			out.Status = int(in.Int())
+		case "body":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Body).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker4(out *jwriter.Writer, in multiStatusResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"symbol\":"
+		out.RawString(prefix)
+		out.String(string(in.Symbol))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.Int(int(in.Status))
+	}
+	if len(in.Body) != 0 {
+		const prefix string = ",\"body\":"
+		out.RawString(prefix)
+		out.Raw((in.Body).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v multiStatusResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v multiStatusResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *multiStatusResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *multiStatusResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker4(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker5(in *jlexer.Lexer, out *journalSlice) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(journalSlice, 0, 0)
+			} else {
+				*out = journalSlice{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v13 Journal
+			(v13).UnmarshalEasyJSON(in)
+			*out = append(*out, v13)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker5(out *jwriter.Writer, in journalSlice) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v14, v15 := range in {
+			if v14 > 0 {
+				out.RawByte(',')
+			}
+			(v15).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v journalSlice) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v journalSlice) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *journalSlice) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *journalSlice) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker5(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker6(in *jlexer.Lexer, out *achRelationshipSlice) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(achRelationshipSlice, 0, 0)
+			} else {
+				*out = achRelationshipSlice{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v16 ACHRelationship
+			(v16).UnmarshalEasyJSON(in)
+			*out = append(*out, v16)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker6(out *jwriter.Writer, in achRelationshipSlice) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v17, v18 := range in {
+			if v17 > 0 {
+				out.RawByte(',')
+			}
+			(v18).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v achRelationshipSlice) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v achRelationshipSlice) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *achRelationshipSlice) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *achRelationshipSlice) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker6(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker7(in *jlexer.Lexer, out *accountSlice) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(accountSlice, 0, 0)
+			} else {
+				*out = accountSlice{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v19 Account
+			(v19).UnmarshalEasyJSON(in)
+			*out = append(*out, v19)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker7(out *jwriter.Writer, in accountSlice) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v20, v21 := range in {
+			if v20 > 0 {
+				out.RawByte(',')
+			}
+			(v21).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v accountSlice) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v accountSlice) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *accountSlice) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *accountSlice) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker7(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker8(in *jlexer.Lexer, out *TrustedContact) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "given_name":
+			out.GivenName = string(in.String())
+		case "family_name":
+			out.FamilyName = string(in.String())
+		case "email_address":
+			out.EmailAddress = string(in.String())
+		case "phone_number":
+			out.PhoneNumber = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker8(out *jwriter.Writer, in TrustedContact) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.GivenName != "" {
+		const prefix string = ",\"given_name\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.GivenName))
+	}
+	if in.FamilyName != "" {
+		const prefix string = ",\"family_name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.FamilyName))
+	}
+	if in.EmailAddress != "" {
+		const prefix string = ",\"email_address\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.EmailAddress))
+	}
+	if in.PhoneNumber != "" {
+		const prefix string = ",\"phone_number\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.PhoneNumber))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TrustedContact) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TrustedContact) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TrustedContact) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TrustedContact) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker8(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker9(in *jlexer.Lexer, out *TransferStatusEvent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "event_id":
+			out.EventID = int64(in.Int64())
+		case "event_ulid":
+			out.EventULID = string(in.String())
+		case "transfer_id":
+			out.TransferID = string(in.String())
+		case "account_id":
+			out.AccountID = string(in.String())
+		case "at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.At).UnmarshalJSON(data))
+			}
+		case "status_from":
+			out.StatusFrom = string(in.String())
+		case "status_to":
+			out.StatusTo = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker9(out *jwriter.Writer, in TransferStatusEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"event_id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.EventID))
+	}
+	{
+		const prefix string = ",\"event_ulid\":"
+		out.RawString(prefix)
+		out.String(string(in.EventULID))
+	}
+	{
+		const prefix string = ",\"transfer_id\":"
+		out.RawString(prefix)
+		out.String(string(in.TransferID))
+	}
+	{
+		const prefix string = ",\"account_id\":"
+		out.RawString(prefix)
+		out.String(string(in.AccountID))
+	}
+	{
+		const prefix string = ",\"at\":"
+		out.RawString(prefix)
+		out.Raw((in.At).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"status_from\":"
+		out.RawString(prefix)
+		out.String(string(in.StatusFrom))
+	}
+	{
+		const prefix string = ",\"status_to\":"
+		out.RawString(prefix)
+		out.String(string(in.StatusTo))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TransferStatusEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TransferStatusEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TransferStatusEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TransferStatusEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker9(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker10(in *jlexer.Lexer, out *Transfer) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "relationship_id":
+			out.RelationshipID = string(in.String())
+		case "account_id":
+			out.AccountID = string(in.String())
+		case "type":
+			out.Type = TransferType(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "reason":
+			if in.IsNull() {
+				in.Skip()
+				out.Reason = nil
+			} else {
+				if out.Reason == nil {
+					out.Reason = new(string)
+				}
+				*out.Reason = string(in.String())
+			}
+		case "amount":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Amount).UnmarshalJSON(data))
+			}
+		case "direction":
+			out.Direction = TransferDirection(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		case "updated_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.UpdatedAt).UnmarshalJSON(data))
+			}
+		case "expires_at":
+			if in.IsNull() {
+				in.Skip()
+				out.ExpiresAt = nil
+			} else {
+				if out.ExpiresAt == nil {
+					out.ExpiresAt = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker10(out *jwriter.Writer, in Transfer) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"relationship_id\":"
+		out.RawString(prefix)
+		out.String(string(in.RelationshipID))
+	}
+	{
+		const prefix string = ",\"account_id\":"
+		out.RawString(prefix)
+		out.String(string(in.AccountID))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		if in.Reason == nil {
+			out.RawString("null")
+		} else {
+			out.String(string(*in.Reason))
+		}
+	}
+	{
+		const prefix string = ",\"amount\":"
+		out.RawString(prefix)
+		out.Raw((in.Amount).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"direction\":"
+		out.RawString(prefix)
+		out.String(string(in.Direction))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"updated_at\":"
+		out.RawString(prefix)
+		out.Raw((in.UpdatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		if in.ExpiresAt == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.ExpiresAt).MarshalJSON())
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Transfer) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Transfer) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Transfer) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Transfer) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker10(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker11(in *jlexer.Lexer, out *TradeEvent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "account_id":
+			out.AccountID = string(in.String())
+		case "at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.At).UnmarshalJSON(data))
+			}
+		case "event":
+			out.Event = alpaca.TradeEvent(in.String())
+		case "event_id":
+			out.EventID = string(in.String())
+		case "execution_id":
+			out.ExecutionID = string(in.String())
+		case "order":
+			(out.Order).UnmarshalEasyJSON(in)
+		case "position_qty":
+			if in.IsNull() {
+				in.Skip()
+				out.PositionQty = nil
+			} else {
+				if out.PositionQty == nil {
+					out.PositionQty = new(decimal.Decimal)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.PositionQty).UnmarshalJSON(data))
+				}
+			}
+		case "price":
+			if in.IsNull() {
+				in.Skip()
+				out.Price = nil
+			} else {
+				if out.Price == nil {
+					out.Price = new(decimal.Decimal)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Price).UnmarshalJSON(data))
+				}
+			}
+		case "qty":
+			if in.IsNull() {
+				in.Skip()
+				out.Qty = nil
+			} else {
+				if out.Qty == nil {
+					out.Qty = new(decimal.Decimal)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Qty).UnmarshalJSON(data))
+				}
+			}
+		case "timestamp":
+			if in.IsNull() {
+				in.Skip()
+				out.Timestamp = nil
+			} else {
+				if out.Timestamp == nil {
+					out.Timestamp = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Timestamp).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker11(out *jwriter.Writer, in TradeEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"account_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.AccountID))
+	}
+	{
+		const prefix string = ",\"at\":"
+		out.RawString(prefix)
+		out.Raw((in.At).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"event\":"
+		out.RawString(prefix)
+		out.String(string(in.Event))
+	}
+	{
+		const prefix string = ",\"event_id\":"
+		out.RawString(prefix)
+		out.String(string(in.EventID))
+	}
+	{
+		const prefix string = ",\"execution_id\":"
+		out.RawString(prefix)
+		out.String(string(in.ExecutionID))
+	}
+	{
+		const prefix string = ",\"order\":"
+		out.RawString(prefix)
+		(in.Order).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"position_qty\":"
+		out.RawString(prefix)
+		if in.PositionQty == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.PositionQty).MarshalJSON())
+		}
+	}
+	{
+		const prefix string = ",\"price\":"
+		out.RawString(prefix)
+		if in.Price == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.Price).MarshalJSON())
+		}
+	}
+	{
+		const prefix string = ",\"qty\":"
+		out.RawString(prefix)
+		if in.Qty == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.Qty).MarshalJSON())
+		}
+	}
+	{
+		const prefix string = ",\"timestamp\":"
+		out.RawString(prefix)
+		if in.Timestamp == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.Timestamp).MarshalJSON())
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TradeEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TradeEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TradeEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TradeEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker11(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker12(in *jlexer.Lexer, out *JournalStatusEvent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "event_id":
+			out.EventID = int64(in.Int64())
+		case "event_ulid":
+			out.EventULID = string(in.String())
+		case "journal_id":
+			out.JournalID = string(in.String())
+		case "entry_type":
+			out.EntryType = JournalEntryType(in.String())
+		case "at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.At).UnmarshalJSON(data))
+			}
+		case "status_from":
+			out.StatusFrom = string(in.String())
+		case "status_to":
+			out.StatusTo = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker12(out *jwriter.Writer, in JournalStatusEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"event_id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.EventID))
+	}
+	{
+		const prefix string = ",\"event_ulid\":"
+		out.RawString(prefix)
+		out.String(string(in.EventULID))
+	}
+	{
+		const prefix string = ",\"journal_id\":"
+		out.RawString(prefix)
+		out.String(string(in.JournalID))
+	}
+	{
+		const prefix string = ",\"entry_type\":"
+		out.RawString(prefix)
+		out.String(string(in.EntryType))
+	}
+	{
+		const prefix string = ",\"at\":"
+		out.RawString(prefix)
+		out.Raw((in.At).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"status_from\":"
+		out.RawString(prefix)
+		out.String(string(in.StatusFrom))
+	}
+	{
+		const prefix string = ",\"status_to\":"
+		out.RawString(prefix)
+		out.String(string(in.StatusTo))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v JournalStatusEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v JournalStatusEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *JournalStatusEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *JournalStatusEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker12(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker13(in *jlexer.Lexer, out *Journal) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "entry_type":
+			out.EntryType = JournalEntryType(in.String())
+		case "from_account":
+			out.FromAccount = string(in.String())
+		case "to_account":
+			out.ToAccount = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "net_amount":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.NetAmount).UnmarshalJSON(data))
+			}
+		case "symbol":
+			out.Symbol = string(in.String())
+		case "qty":
+			if in.IsNull() {
+				in.Skip()
+				out.Qty = nil
+			} else {
+				if out.Qty == nil {
+					out.Qty = new(decimal.Decimal)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Qty).UnmarshalJSON(data))
+				}
+			}
+		case "price":
+			if in.IsNull() {
+				in.Skip()
+				out.Price = nil
+			} else {
+				if out.Price == nil {
+					out.Price = new(decimal.Decimal)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Price).UnmarshalJSON(data))
+				}
+			}
+		case "description":
+			out.Description = string(in.String())
+		case "settle_date":
+			if in.IsNull() {
+				in.Skip()
+				out.SettleDate = nil
+			} else {
+				if out.SettleDate == nil {
+					out.SettleDate = new(civil.Date)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.SettleDate).UnmarshalText(data))
+				}
+			}
+		case "system_date":
+			if in.IsNull() {
+				in.Skip()
+				out.SystemDate = nil
+			} else {
+				if out.SystemDate == nil {
+					out.SystemDate = new(civil.Date)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.SystemDate).UnmarshalText(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker13(out *jwriter.Writer, in Journal) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"entry_type\":"
+		out.RawString(prefix)
+		out.String(string(in.EntryType))
+	}
+	{
+		const prefix string = ",\"from_account\":"
+		out.RawString(prefix)
+		out.String(string(in.FromAccount))
+	}
+	{
+		const prefix string = ",\"to_account\":"
+		out.RawString(prefix)
+		out.String(string(in.ToAccount))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"net_amount\":"
+		out.RawString(prefix)
+		out.Raw((in.NetAmount).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"symbol\":"
+		out.RawString(prefix)
+		out.String(string(in.Symbol))
+	}
+	{
+		const prefix string = ",\"qty\":"
+		out.RawString(prefix)
+		if in.Qty == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.Qty).MarshalJSON())
+		}
+	}
+	{
+		const prefix string = ",\"price\":"
+		out.RawString(prefix)
+		if in.Price == nil {
+			out.RawString("null")
+		} else {
+			out.Raw((*in.Price).MarshalJSON())
+		}
+	}
+	{
+		const prefix string = ",\"description\":"
+		out.RawString(prefix)
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"settle_date\":"
+		out.RawString(prefix)
+		if in.SettleDate == nil {
+			out.RawString("null")
+		} else {
+			out.RawText((*in.SettleDate).MarshalText())
+		}
+	}
+	{
+		const prefix string = ",\"system_date\":"
+		out.RawString(prefix)
+		if in.SystemDate == nil {
+			out.RawString("null")
+		} else {
+			out.RawText((*in.SystemDate).MarshalText())
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Journal) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Journal) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Journal) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Journal) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker13(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker14(in *jlexer.Lexer, out *Identity) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "given_name":
+			out.GivenName = string(in.String())
+		case "middle_name":
+			out.MiddleName = string(in.String())
+		case "family_name":
+			out.FamilyName = string(in.String())
+		case "date_of_birth":
+			if in.IsNull() {
+				in.Skip()
+				out.DateOfBirth = nil
+			} else {
+				if out.DateOfBirth == nil {
+					out.DateOfBirth = new(civil.Date)
+				}
+				if data := in.UnsafeBytes(); in.Ok() {
+					in.AddError((*out.DateOfBirth).UnmarshalText(data))
+				}
+			}
+		case "tax_id":
+			out.TaxID = string(in.String())
+		case "tax_id_type":
+			out.TaxIDType = string(in.String())
+		case "country_of_citizenship":
+			out.CountryOfCitizenship = string(in.String())
+		case "country_of_birth":
+			out.CountryOfBirth = string(in.String())
+		case "country_of_tax_residence":
+			out.CountryOfTaxResidence = string(in.String())
+		case "funding_source":
+			if in.IsNull() {
+				in.Skip()
+				out.FundingSource = nil
+			} else {
+				in.Delim('[')
+				if out.FundingSource == nil {
+					if !in.IsDelim(']') {
+						out.FundingSource = make([]string, 0, 4)
+					} else {
+						out.FundingSource = []string{}
+					}
+				} else {
+					out.FundingSource = (out.FundingSource)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 string
+					v22 = string(in.String())
+					out.FundingSource = append(out.FundingSource, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "annual_income_min":
+			if in.IsNull() {
+				in.Skip()
+				out.AnnualIncomeMin = nil
+			} else {
+				if out.AnnualIncomeMin == nil {
+					out.AnnualIncomeMin = new(decimal.Decimal)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.AnnualIncomeMin).UnmarshalJSON(data))
+				}
+			}
+		case "annual_income_max":
+			if in.IsNull() {
+				in.Skip()
+				out.AnnualIncomeMax = nil
+			} else {
+				if out.AnnualIncomeMax == nil {
+					out.AnnualIncomeMax = new(decimal.Decimal)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.AnnualIncomeMax).UnmarshalJSON(data))
+				}
+			}
+		case "liquid_net_worth_min":
+			if in.IsNull() {
+				in.Skip()
+				out.LiquidNetWorthMin = nil
+			} else {
+				if out.LiquidNetWorthMin == nil {
+					out.LiquidNetWorthMin = new(decimal.Decimal)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.LiquidNetWorthMin).UnmarshalJSON(data))
+				}
+			}
+		case "liquid_net_worth_max":
+			if in.IsNull() {
+				in.Skip()
+				out.LiquidNetWorthMax = nil
+			} else {
+				if out.LiquidNetWorthMax == nil {
+					out.LiquidNetWorthMax = new(decimal.Decimal)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.LiquidNetWorthMax).UnmarshalJSON(data))
+				}
+			}
+		case "total_net_worth_min":
+			if in.IsNull() {
+				in.Skip()
+				out.TotalNetWorthMin = nil
+			} else {
+				if out.TotalNetWorthMin == nil {
+					out.TotalNetWorthMin = new(decimal.Decimal)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.TotalNetWorthMin).UnmarshalJSON(data))
+				}
+			}
+		case "total_net_worth_max":
+			if in.IsNull() {
+				in.Skip()
+				out.TotalNetWorthMax = nil
+			} else {
+				if out.TotalNetWorthMax == nil {
+					out.TotalNetWorthMax = new(decimal.Decimal)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.TotalNetWorthMax).UnmarshalJSON(data))
+				}
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker14(out *jwriter.Writer, in Identity) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.GivenName != "" {
+		const prefix string = ",\"given_name\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.GivenName))
+	}
+	if in.MiddleName != "" {
+		const prefix string = ",\"middle_name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.MiddleName))
+	}
+	if in.FamilyName != "" {
+		const prefix string = ",\"family_name\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.FamilyName))
+	}
+	if in.DateOfBirth != nil {
+		const prefix string = ",\"date_of_birth\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.RawText((*in.DateOfBirth).MarshalText())
+	}
+	if in.TaxID != "" {
+		const prefix string = ",\"tax_id\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.TaxID))
+	}
+	if in.TaxIDType != "" {
+		const prefix string = ",\"tax_id_type\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.TaxIDType))
+	}
+	if in.CountryOfCitizenship != "" {
+		const prefix string = ",\"country_of_citizenship\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CountryOfCitizenship))
+	}
+	if in.CountryOfBirth != "" {
+		const prefix string = ",\"country_of_birth\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CountryOfBirth))
+	}
+	if in.CountryOfTaxResidence != "" {
+		const prefix string = ",\"country_of_tax_residence\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.CountryOfTaxResidence))
+	}
+	if len(in.FundingSource) != 0 {
+		const prefix string = ",\"funding_source\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v23, v24 := range in.FundingSource {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v24))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.AnnualIncomeMin != nil {
+		const prefix string = ",\"annual_income_min\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.AnnualIncomeMin).MarshalJSON())
+	}
+	if in.AnnualIncomeMax != nil {
+		const prefix string = ",\"annual_income_max\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.AnnualIncomeMax).MarshalJSON())
+	}
+	if in.LiquidNetWorthMin != nil {
+		const prefix string = ",\"liquid_net_worth_min\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.LiquidNetWorthMin).MarshalJSON())
+	}
+	if in.LiquidNetWorthMax != nil {
+		const prefix string = ",\"liquid_net_worth_max\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.LiquidNetWorthMax).MarshalJSON())
+	}
+	if in.TotalNetWorthMin != nil {
+		const prefix string = ",\"total_net_worth_min\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.TotalNetWorthMin).MarshalJSON())
+	}
+	if in.TotalNetWorthMax != nil {
+		const prefix string = ",\"total_net_worth_max\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.TotalNetWorthMax).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Identity) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Identity) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Identity) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Identity) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker14(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker15(in *jlexer.Lexer, out *Disclosures) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "is_control_person":
+			out.IsControlPerson = bool(in.Bool())
+		case "is_affiliated_exchange_or_finra":
+			out.IsAffiliatedExchangeOrFinra = bool(in.Bool())
+		case "is_politically_exposed":
+			out.IsPoliticallyExposed = bool(in.Bool())
+		case "immediate_family_exposed":
+			out.ImmediateFamilyExposed = bool(in.Bool())
+		case "employment_status":
+			out.EmploymentStatus = string(in.String())
+		case "employer_name":
+			out.EmployerName = string(in.String())
+		case "employer_address":
+			out.EmployerAddress = string(in.String())
+		case "employment_position":
+			out.EmploymentPosition = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker15(out *jwriter.Writer, in Disclosures) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"is_control_person\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.IsControlPerson))
+	}
+	{
+		const prefix string = ",\"is_affiliated_exchange_or_finra\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsAffiliatedExchangeOrFinra))
+	}
+	{
+		const prefix string = ",\"is_politically_exposed\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsPoliticallyExposed))
+	}
+	{
+		const prefix string = ",\"immediate_family_exposed\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.ImmediateFamilyExposed))
+	}
+	if in.EmploymentStatus != "" {
+		const prefix string = ",\"employment_status\":"
+		out.RawString(prefix)
+		out.String(string(in.EmploymentStatus))
+	}
+	if in.EmployerName != "" {
+		const prefix string = ",\"employer_name\":"
+		out.RawString(prefix)
+		out.String(string(in.EmployerName))
+	}
+	if in.EmployerAddress != "" {
+		const prefix string = ",\"employer_address\":"
+		out.RawString(prefix)
+		out.String(string(in.EmployerAddress))
+	}
+	if in.EmploymentPosition != "" {
+		const prefix string = ",\"employment_position\":"
+		out.RawString(prefix)
+		out.String(string(in.EmploymentPosition))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Disclosures) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker15(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Disclosures) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker15(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Disclosures) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker15(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Disclosures) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker15(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker16(in *jlexer.Lexer, out *Contact) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "email_address":
+			out.EmailAddress = string(in.String())
+		case "phone_number":
+			out.PhoneNumber = string(in.String())
+		case "street_address":
+			if in.IsNull() {
+				in.Skip()
+				out.StreetAddress = nil
+			} else {
+				in.Delim('[')
+				if out.StreetAddress == nil {
+					if !in.IsDelim(']') {
+						out.StreetAddress = make([]string, 0, 4)
+					} else {
+						out.StreetAddress = []string{}
+					}
+				} else {
+					out.StreetAddress = (out.StreetAddress)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 string
+					v25 = string(in.String())
+					out.StreetAddress = append(out.StreetAddress, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "unit":
+			out.Unit = string(in.String())
+		case "city":
+			out.City = string(in.String())
+		case "state":
+			out.State = string(in.String())
+		case "postal_code":
+			out.PostalCode = string(in.String())
+		case "country":
+			out.Country = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker16(out *jwriter.Writer, in Contact) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.EmailAddress != "" {
+		const prefix string = ",\"email_address\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.EmailAddress))
+	}
+	if in.PhoneNumber != "" {
+		const prefix string = ",\"phone_number\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.PhoneNumber))
+	}
+	if len(in.StreetAddress) != 0 {
+		const prefix string = ",\"street_address\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v26, v27 := range in.StreetAddress {
+				if v26 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v27))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Unit != "" {
+		const prefix string = ",\"unit\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Unit))
+	}
+	if in.City != "" {
+		const prefix string = ",\"city\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.City))
+	}
+	if in.State != "" {
+		const prefix string = ",\"state\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.State))
+	}
+	if in.PostalCode != "" {
+		const prefix string = ",\"postal_code\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.PostalCode))
+	}
+	if in.Country != "" {
+		const prefix string = ",\"country\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Country))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Contact) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Contact) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Contact) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Contact) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker16(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker17(in *jlexer.Lexer, out *Agreement) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "agreement":
+			out.Agreement = string(in.String())
+		case "signed_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.SignedAt).UnmarshalJSON(data))
+			}
+		case "ip_address":
+			out.IPAddress = string(in.String())
+		case "revision":
+			out.Revision = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker17(out *jwriter.Writer, in Agreement) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"agreement\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Agreement))
+	}
+	{
+		const prefix string = ",\"signed_at\":"
+		out.RawString(prefix)
+		out.Raw((in.SignedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"ip_address\":"
+		out.RawString(prefix)
+		out.String(string(in.IPAddress))
+	}
+	if in.Revision != "" {
+		const prefix string = ",\"revision\":"
+		out.RawString(prefix)
+		out.String(string(in.Revision))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Agreement) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker17(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Agreement) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker17(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Agreement) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker17(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Agreement) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker17(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker18(in *jlexer.Lexer, out *AccountStatusEvent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "event_id":
+			out.EventID = int64(in.Int64())
+		case "event_ulid":
+			out.EventULID = string(in.String())
+		case "account_id":
+			out.AccountID = string(in.String())
+		case "account_number":
+			out.AccountNumber = string(in.String())
+		case "at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.At).UnmarshalJSON(data))
+			}
+		case "status_from":
+			out.StatusFrom = string(in.String())
+		case "status_to":
+			out.StatusTo = string(in.String())
+		case "crypto_status_from":
+			out.CryptoStatusFrom = string(in.String())
+		case "crypto_status_to":
+			out.CryptoStatusTo = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker18(out *jwriter.Writer, in AccountStatusEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"event_id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.EventID))
+	}
+	{
+		const prefix string = ",\"event_ulid\":"
+		out.RawString(prefix)
+		out.String(string(in.EventULID))
+	}
+	{
+		const prefix string = ",\"account_id\":"
+		out.RawString(prefix)
+		out.String(string(in.AccountID))
+	}
+	{
+		const prefix string = ",\"account_number\":"
+		out.RawString(prefix)
+		out.String(string(in.AccountNumber))
+	}
+	{
+		const prefix string = ",\"at\":"
+		out.RawString(prefix)
+		out.Raw((in.At).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"status_from\":"
+		out.RawString(prefix)
+		out.String(string(in.StatusFrom))
+	}
+	{
+		const prefix string = ",\"status_to\":"
+		out.RawString(prefix)
+		out.String(string(in.StatusTo))
+	}
+	{
+		const prefix string = ",\"crypto_status_from\":"
+		out.RawString(prefix)
+		out.String(string(in.CryptoStatusFrom))
+	}
+	{
+		const prefix string = ",\"crypto_status_to\":"
+		out.RawString(prefix)
+		out.String(string(in.CryptoStatusTo))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AccountStatusEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker18(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AccountStatusEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker18(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AccountStatusEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker18(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AccountStatusEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker18(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker19(in *jlexer.Lexer, out *Account) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "account_number":
+			out.AccountNumber = string(in.String())
+		case "status":
+			out.Status = string(in.String())
+		case "crypto_status":
+			out.CryptoStatus = string(in.String())
+		case "currency":
+			out.Currency = string(in.String())
+		case "last_equity":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.LastEquity).UnmarshalJSON(data))
+			}
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		case "account_type":
+			out.AccountType = string(in.String())
+		case "enabled_assets":
+			if in.IsNull() {
+				in.Skip()
+				out.EnabledAssets = nil
+			} else {
+				in.Delim('[')
+				if out.EnabledAssets == nil {
+					if !in.IsDelim(']') {
+						out.EnabledAssets = make([]string, 0, 4)
+					} else {
+						out.EnabledAssets = []string{}
+					}
+				} else {
+					out.EnabledAssets = (out.EnabledAssets)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v28 string
+					v28 = string(in.String())
+					out.EnabledAssets = append(out.EnabledAssets, v28)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "contact":
+			if in.IsNull() {
+				in.Skip()
+				out.Contact = nil
+			} else {
+				if out.Contact == nil {
+					out.Contact = new(Contact)
+				}
+				(*out.Contact).UnmarshalEasyJSON(in)
+			}
+		case "identity":
+			if in.IsNull() {
+				in.Skip()
+				out.Identity = nil
+			} else {
+				if out.Identity == nil {
+					out.Identity = new(Identity)
+				}
+				(*out.Identity).UnmarshalEasyJSON(in)
+			}
+		case "disclosures":
+			if in.IsNull() {
+				in.Skip()
+				out.Disclosures = nil
+			} else {
+				if out.Disclosures == nil {
+					out.Disclosures = new(Disclosures)
+				}
+				(*out.Disclosures).UnmarshalEasyJSON(in)
+			}
+		case "agreements":
+			if in.IsNull() {
+				in.Skip()
+				out.Agreements = nil
+			} else {
+				in.Delim('[')
+				if out.Agreements == nil {
+					if !in.IsDelim(']') {
+						out.Agreements = make([]Agreement, 0, 0)
+					} else {
+						out.Agreements = []Agreement{}
+					}
+				} else {
+					out.Agreements = (out.Agreements)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v29 Agreement
+					(v29).UnmarshalEasyJSON(in)
+					out.Agreements = append(out.Agreements, v29)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "trusted_contact":
+			if in.IsNull() {
+				in.Skip()
+				out.TrustedContact = nil
+			} else {
+				if out.TrustedContact == nil {
+					out.TrustedContact = new(TrustedContact)
+				}
+				(*out.TrustedContact).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker19(out *jwriter.Writer, in Account) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"account_number\":"
+		out.RawString(prefix)
+		out.String(string(in.AccountNumber))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"crypto_status\":"
+		out.RawString(prefix)
+		out.String(string(in.CryptoStatus))
+	}
+	{
+		const prefix string = ",\"currency\":"
+		out.RawString(prefix)
+		out.String(string(in.Currency))
+	}
+	{
+		const prefix string = ",\"last_equity\":"
+		out.RawString(prefix)
+		out.Raw((in.LastEquity).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"account_type\":"
+		out.RawString(prefix)
+		out.String(string(in.AccountType))
+	}
+	{
+		const prefix string = ",\"enabled_assets\":"
+		out.RawString(prefix)
+		if in.EnabledAssets == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v30, v31 := range in.EnabledAssets {
+				if v30 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v31))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"contact\":"
+		out.RawString(prefix)
+		if in.Contact == nil {
+			out.RawString("null")
+		} else {
+			(*in.Contact).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"identity\":"
+		out.RawString(prefix)
+		if in.Identity == nil {
+			out.RawString("null")
+		} else {
+			(*in.Identity).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"disclosures\":"
+		out.RawString(prefix)
+		if in.Disclosures == nil {
+			out.RawString("null")
+		} else {
+			(*in.Disclosures).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"agreements\":"
+		out.RawString(prefix)
+		if in.Agreements == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v32, v33 := range in.Agreements {
+				if v32 > 0 {
+					out.RawByte(',')
+				}
+				(v33).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"trusted_contact\":"
+		out.RawString(prefix)
+		if in.TrustedContact == nil {
+			out.RawString("null")
+		} else {
+			(*in.TrustedContact).MarshalEasyJSON(out)
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Account) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker19(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Account) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker19(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Account) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker19(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Account) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker19(l, v)
+}
+func easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker20(in *jlexer.Lexer, out *ACHRelationship) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = string(in.String())
+		case "account_id":
+			out.AccountID = string(in.String())
+		case "created_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.CreatedAt).UnmarshalJSON(data))
+			}
+		case "updated_at":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.UpdatedAt).UnmarshalJSON(data))
+			}
+		case "status":
+			out.Status = string(in.String())
+		case "account_owner_name":
+			out.AccountOwnerName = string(in.String())
+		case "bank_account_type":
+			out.BankAccountType = BankAccountType(in.String())
+		case "bank_account_number":
+			out.BankAccountNumber = string(in.String())
+		case "bank_routing_number":
+			out.BankRoutingNumber = string(in.String())
+		case "nickname":
+			out.Nickname = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker20(out *jwriter.Writer, in ACHRelationship) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.ID))
+	}
+	{
+		const prefix string = ",\"account_id\":"
+		out.RawString(prefix)
+		out.String(string(in.AccountID))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.Raw((in.CreatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"updated_at\":"
+		out.RawString(prefix)
+		out.Raw((in.UpdatedAt).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"account_owner_name\":"
+		out.RawString(prefix)
+		out.String(string(in.AccountOwnerName))
+	}
+	{
+		const prefix string = ",\"bank_account_type\":"
+		out.RawString(prefix)
+		out.String(string(in.BankAccountType))
+	}
+	{
+		const prefix string = ",\"bank_account_number\":"
+		out.RawString(prefix)
+		out.String(string(in.BankAccountNumber))
+	}
+	{
+		const prefix string = ",\"bank_routing_number\":"
+		out.RawString(prefix)
+		out.String(string(in.BankRoutingNumber))
+	}
+	{
+		const prefix string = ",\"nickname\":"
+		out.RawString(prefix)
+		out.String(string(in.Nickname))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ACHRelationship) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker20(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ACHRelationship) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson3e8ab7adEncodeGithubComAlpacahqAlpacaTradeApiGoV3Broker20(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ACHRelationship) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker20(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ACHRelationship) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson3e8ab7adDecodeGithubComAlpacahqAlpacaTradeApiGoV3Broker20(l, v)
+}
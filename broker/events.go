@@ -0,0 +1,124 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/mailru/easyjson"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+	"github.com/alpacahq/alpaca-trade-api-go/v3/internal/sse"
+)
+
+// StreamEventsRequest selects the events of an event stream. Without any field set the stream
+// starts with the events happening after the connection.
+type StreamEventsRequest struct {
+	Since   time.Time
+	Until   time.Time
+	SinceID string
+	UntilID string
+}
+
+// StreamAccountStatusEvents streams the status changes of all accounts. It blocks and keeps calling
+// the handler for each event until the context is cancelled or the server ends the stream.
+func (c *Client) StreamAccountStatusEvents(ctx context.Context, handler func(AccountStatusEvent), req StreamEventsRequest) error {
+	return c.streamEvents(ctx, "/v1/events/accounts/status", req, func(data []byte) error {
+		var event AccountStatusEvent
+		if err := easyjson.Unmarshal(data, &event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// StreamTransferStatusEvents streams the status changes of all transfers. It blocks and keeps calling
+// the handler for each event until the context is cancelled or the server ends the stream.
+func (c *Client) StreamTransferStatusEvents(ctx context.Context, handler func(TransferStatusEvent), req StreamEventsRequest) error {
+	return c.streamEvents(ctx, "/v1/events/transfers/status", req, func(data []byte) error {
+		var event TransferStatusEvent
+		if err := easyjson.Unmarshal(data, &event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// StreamJournalStatusEvents streams the status changes of all journals. It blocks and keeps calling
+// the handler for each event until the context is cancelled or the server ends the stream.
+func (c *Client) StreamJournalStatusEvents(ctx context.Context, handler func(JournalStatusEvent), req StreamEventsRequest) error {
+	return c.streamEvents(ctx, "/v1/events/journals/status", req, func(data []byte) error {
+		var event JournalStatusEvent
+		if err := easyjson.Unmarshal(data, &event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// StreamTradeEvents streams the trade updates of all accounts. It blocks and keeps calling
+// the handler for each event until the context is cancelled or the server ends the stream.
+func (c *Client) StreamTradeEvents(ctx context.Context, handler func(TradeEvent), req StreamEventsRequest) error {
+	return c.streamEvents(ctx, "/v2beta1/events/trades", req, func(data []byte) error {
+		var event TradeEvent
+		if err := easyjson.Unmarshal(data, &event); err != nil {
+			return err
+		}
+		handler(event)
+		return nil
+	})
+}
+
+// streamEvents connects to the server-sent event stream at path and calls handler with the data of every event.
+func (c *Client) streamEvents(ctx context.Context, path string, req StreamEventsRequest, handler func(data []byte) error) error {
+	u, err := url.Parse(c.opts.BaseURL + path)
+	if err != nil {
+		return err
+	}
+	q := u.Query()
+	if !req.Since.IsZero() {
+		q.Set("since", req.Since.Format(time.RFC3339Nano))
+	}
+	if !req.Until.IsZero() {
+		q.Set("until", req.Until.Format(time.RFC3339Nano))
+	}
+	if req.SinceID != "" {
+		q.Set("since_id", req.SinceID)
+	}
+	if req.UntilID != "" {
+		q.Set("until_id", req.UntilID)
+	}
+	u.RawQuery = q.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("User-Agent", alpaca.Version())
+	request.Header.Set("Accept", "text/event-stream")
+	request.SetBasicAuth(c.opts.APIKey, c.opts.APISecret)
+
+	// The stream is long-lived, so the timeout of the client must not apply to it.
+	client := *c.httpClient
+	client.Timeout = 0
+	resp, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := alpaca.Verify(resp); err != nil {
+		return err
+	}
+
+	return sse.Read(resp.Body, func(event sse.Event) error {
+		if err := handler(event.Data); err != nil {
+			return fmt.Errorf("invalid event %q: %w", event.Data, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,113 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+)
+
+func TestStreamAccountStatusEvents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/events/accounts/status", r.URL.Path)
+		assert.Equal(t, "10", r.URL.Query().Get("since_id"))
+		_, _, ok := r.BasicAuth()
+		assert.True(t, ok)
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": heartbeat\n\n")
+		fmt.Fprint(w, "data: {\"event_id\":11,\"account_id\":\"acct\",\"at\":\"2024-03-01T15:00:00Z\","+
+			"\"status_from\":\"SUBMITTED\",\"status_to\":\"APPROVED\"}\n\n")
+		fmt.Fprint(w, "data: {\"event_id\":12,\"account_id\":\"acct\",\"status_from\":\"APPROVED\",\"status_to\":\"ACTIVE\"}\n\n")
+	}))
+	defer ts.Close()
+
+	c := NewClient(ClientOpts{BaseURL: ts.URL})
+	var events []AccountStatusEvent
+	err := c.StreamAccountStatusEvents(context.Background(), func(e AccountStatusEvent) {
+		events = append(events, e)
+	}, StreamEventsRequest{SinceID: "10"})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.EqualValues(t, 11, events[0].EventID)
+	assert.Equal(t, "APPROVED", events[0].StatusTo)
+	assert.Equal(t, time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC), events[0].At.UTC())
+	assert.Equal(t, "ACTIVE", events[1].StatusTo)
+}
+
+func TestStreamTransferAndJournalStatusEvents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/events/transfers/status":
+			fmt.Fprint(w, "data: {\"event_id\":1,\"transfer_id\":\"tr\",\"status_to\":\"COMPLETE\"}\n\n")
+		case "/v1/events/journals/status":
+			fmt.Fprint(w, "data: {\"event_id\":2,\"journal_id\":\"j\",\"entry_type\":\"JNLC\",\"status_to\":\"executed\"}\n\n")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+	c := NewClient(ClientOpts{BaseURL: ts.URL})
+
+	var transfer TransferStatusEvent
+	require.NoError(t, c.StreamTransferStatusEvents(context.Background(), func(e TransferStatusEvent) {
+		transfer = e
+	}, StreamEventsRequest{}))
+	assert.Equal(t, "tr", transfer.TransferID)
+	assert.Equal(t, "COMPLETE", transfer.StatusTo)
+
+	var journal JournalStatusEvent
+	require.NoError(t, c.StreamJournalStatusEvents(context.Background(), func(e JournalStatusEvent) {
+		journal = e
+	}, StreamEventsRequest{}))
+	assert.Equal(t, "j", journal.JournalID)
+	assert.Equal(t, JournalCash, journal.EntryType)
+}
+
+func TestStreamTradeEvents(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2beta1/events/trades", r.URL.Path)
+		fmt.Fprint(w, "data: {\"account_id\":\"acct\",\"event_id\":\"01HQ\",\"event\":\"fill\","+
+			"\"order\":{\"id\":\"order\",\"symbol\":\"AAPL\",\"status\":\"filled\"},\"price\":\"180.5\"}\n\n")
+	}))
+	defer ts.Close()
+	c := NewClient(ClientOpts{BaseURL: ts.URL})
+
+	var events []TradeEvent
+	require.NoError(t, c.StreamTradeEvents(context.Background(), func(e TradeEvent) {
+		events = append(events, e)
+	}, StreamEventsRequest{}))
+	require.Len(t, events, 1)
+	assert.Equal(t, "acct", events[0].AccountID)
+	assert.Equal(t, "01HQ", events[0].EventID)
+	assert.Equal(t, alpaca.TradeEvent("fill"), events[0].Event)
+	assert.Equal(t, "AAPL", events[0].Order.Symbol)
+	assert.Equal(t, "180.5", events[0].Price.String())
+}
+
+func TestStreamEventsErrors(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/events/accounts/status" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"code":40310000,"message":"forbidden"}`)
+			return
+		}
+		fmt.Fprint(w, "data: not json\n\n")
+	}))
+	defer ts.Close()
+	c := NewClient(ClientOpts{BaseURL: ts.URL})
+
+	err := c.StreamAccountStatusEvents(context.Background(), func(AccountStatusEvent) {}, StreamEventsRequest{})
+	var apiErr *alpaca.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+
+	err = c.StreamTransferStatusEvents(context.Background(), func(TransferStatusEvent) {}, StreamEventsRequest{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,974 @@
+// Package broker is a client of the Alpaca Broker API, which manages the accounts of end users:
+// account opening, funding through ACH relationships and transfers, journals between accounts,
+// per-account trading and the event streams of account, transfer, journal and trade updates.
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"github.com/shopspring/decimal"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+	"github.com/alpacahq/alpaca-trade-api-go/v3/internal/httpbody"
+)
+
+// ClientOpts contains options for the alpaca broker client.
+type ClientOpts struct {
+	// APIKey and APISecret are the broker API credentials, sent with HTTP basic authentication.
+	APIKey    string
+	APISecret string
+	BaseURL   string
+	// RetryLimit is the maximum number of retries of rate limited requests. Ignored if RetryPolicy is set.
+	RetryLimit int
+	// RetryDelay is the fixed delay between retries. Ignored if RetryPolicy is set.
+	RetryDelay time.Duration
+	// RetryPolicy decides which failed requests are retried and when. If nil, rate limited
+	// requests are retried RetryLimit times with RetryDelay between the attempts, or the delay
+	// requested by the server capped at alpaca.DefaultMaxRetryDelay.
+	RetryPolicy alpaca.RetryPolicy
+	// RateLimiter, if set, delays requests to stay within the rate limit. The same limiter
+	// can be shared between multiple clients of the same API, but not across APIs (see RateLimiter).
+	RateLimiter *alpaca.RateLimiter
+	// HTTPClient to be used for each http request.
+	HTTPClient *http.Client
+}
+
+// Client is the alpaca broker client.
+type Client struct {
+	opts       ClientOpts
+	httpClient *http.Client
+
+	do func(c *Client, req *http.Request) (*http.Response, error)
+}
+
+// NewClient creates a new broker client using the given opts.
+func NewClient(opts ClientOpts) *Client {
+	if opts.APIKey == "" {
+		opts.APIKey = os.Getenv("APCA_BROKER_API_KEY_ID")
+	}
+	if opts.APISecret == "" {
+		opts.APISecret = os.Getenv("APCA_BROKER_API_SECRET_KEY")
+	}
+	if opts.BaseURL == "" {
+		if s := os.Getenv("APCA_BROKER_API_BASE_URL"); s != "" {
+			opts.BaseURL = s
+		} else {
+			opts.BaseURL = "https://broker-api.alpaca.markets"
+		}
+	}
+	if opts.RetryLimit == 0 {
+		opts.RetryLimit = 3
+	}
+	if opts.RetryDelay == 0 {
+		opts.RetryDelay = time.Second
+	}
+	if opts.RetryPolicy == nil {
+		maxDelay := alpaca.DefaultMaxRetryDelay
+		if opts.RetryDelay > maxDelay {
+			maxDelay = opts.RetryDelay
+		}
+		opts.RetryPolicy = &alpaca.BackoffRetryPolicy{
+			MaxRetries:       opts.RetryLimit,
+			BaseDelay:        opts.RetryDelay,
+			MaxDelay:         maxDelay,
+			RetryStatusCodes: []int{http.StatusTooManyRequests},
+		}
+	}
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: 10 * time.Second,
+		}
+	}
+	httpClient = alpaca.RateLimitedHTTPClient(httpClient, opts.RateLimiter)
+	return &Client{
+		opts:       opts,
+		httpClient: httpClient,
+
+		do: defaultDo,
+	}
+}
+
+const apiVersion = "v1"
+
+func defaultDo(c *Client, req *http.Request) (*http.Response, error) {
+	req.Header.Set("User-Agent", alpaca.Version())
+	req.SetBasicAuth(c.opts.APIKey, c.opts.APISecret)
+	if req.Body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := alpaca.DoWithRetry(c.httpClient, req, c.opts.RetryPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = alpaca.Verify(resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// CreateAccountRequest contains the KYC information of a new account.
+type CreateAccountRequest struct {
+	Contact        Contact         `json:"contact"`
+	Identity       Identity        `json:"identity"`
+	Disclosures    Disclosures     `json:"disclosures"`
+	Agreements     []Agreement     `json:"agreements"`
+	TrustedContact *TrustedContact `json:"trusted_contact,omitempty"`
+	EnabledAssets  []string        `json:"enabled_assets,omitempty"`
+}
+
+// CreateAccount submits a new account application.
+func (c *Client) CreateAccount(req CreateAccountRequest) (*Account, error) {
+	return c.CreateAccountWithContext(context.Background(), req)
+}
+
+// CreateAccountWithContext submits a new account application.
+func (c *Client) CreateAccountWithContext(ctx context.Context, req CreateAccountRequest) (*Account, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/accounts", c.opts.BaseURL, apiVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.post(ctx, u, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if err = unmarshal(resp, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+type ListAccountsRequest struct {
+	// Query is matched against the account number, names and email addresses.
+	Query         string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Status        []string
+	// Sort is asc or desc. Defaults to desc.
+	Sort string
+	// Entities lists the optional details to include, e.g. contact, identity, disclosures.
+	Entities []string
+}
+
+// ListAccounts returns the accounts matching req.
+func (c *Client) ListAccounts(req ListAccountsRequest) ([]Account, error) {
+	return c.ListAccountsWithContext(context.Background(), req)
+}
+
+// ListAccountsWithContext returns the accounts matching req.
+func (c *Client) ListAccountsWithContext(ctx context.Context, req ListAccountsRequest) ([]Account, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/accounts", c.opts.BaseURL, apiVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+	if req.Query != "" {
+		q.Set("query", req.Query)
+	}
+	if !req.CreatedAfter.IsZero() {
+		q.Set("created_after", req.CreatedAfter.Format(time.RFC3339))
+	}
+	if !req.CreatedBefore.IsZero() {
+		q.Set("created_before", req.CreatedBefore.Format(time.RFC3339))
+	}
+	if len(req.Status) > 0 {
+		q.Set("status", strings.Join(req.Status, ","))
+	}
+	if req.Sort != "" {
+		q.Set("sort", req.Sort)
+	}
+	if len(req.Entities) > 0 {
+		q.Set("entities", strings.Join(req.Entities, ","))
+	}
+	u.RawQuery = q.Encode()
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts accountSlice
+	if err = unmarshal(resp, &accounts); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// GetAccount returns the account with the given ID.
+func (c *Client) GetAccount(accountID string) (*Account, error) {
+	return c.GetAccountWithContext(context.Background(), accountID)
+}
+
+// GetAccountWithContext returns the account with the given ID.
+func (c *Client) GetAccountWithContext(ctx context.Context, accountID string) (*Account, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/accounts/%s", c.opts.BaseURL, apiVersion, accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if err = unmarshal(resp, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// UpdateAccountRequest contains the account details to change. Nil fields are left unchanged.
+type UpdateAccountRequest struct {
+	Contact        *Contact        `json:"contact,omitempty"`
+	Identity       *Identity       `json:"identity,omitempty"`
+	Disclosures    *Disclosures    `json:"disclosures,omitempty"`
+	TrustedContact *TrustedContact `json:"trusted_contact,omitempty"`
+}
+
+// UpdateAccount changes the details of an account.
+func (c *Client) UpdateAccount(accountID string, req UpdateAccountRequest) (*Account, error) {
+	return c.UpdateAccountWithContext(context.Background(), accountID, req)
+}
+
+// UpdateAccountWithContext changes the details of an account.
+func (c *Client) UpdateAccountWithContext(ctx context.Context, accountID string, req UpdateAccountRequest) (*Account, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/accounts/%s", c.opts.BaseURL, apiVersion, accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.patch(ctx, u, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var account Account
+	if err = unmarshal(resp, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// CloseAccount closes an account. The account must not have open positions or cash.
+func (c *Client) CloseAccount(accountID string) error {
+	return c.CloseAccountWithContext(context.Background(), accountID)
+}
+
+// CloseAccountWithContext closes an account. The account must not have open positions or cash.
+func (c *Client) CloseAccountWithContext(ctx context.Context, accountID string) error {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/accounts/%s/actions/close", c.opts.BaseURL, apiVersion, accountID))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.post(ctx, u, nil)
+	if err != nil {
+		return err
+	}
+	httpbody.Close(resp)
+	return nil
+}
+
+// GetTradingAccount returns the trading details (cash, buying power, etc.) of an account.
+func (c *Client) GetTradingAccount(accountID string) (*alpaca.Account, error) {
+	return c.GetTradingAccountWithContext(context.Background(), accountID)
+}
+
+// GetTradingAccountWithContext returns the trading details (cash, buying power, etc.) of an account.
+func (c *Client) GetTradingAccountWithContext(ctx context.Context, accountID string) (*alpaca.Account, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/trading/accounts/%s/account", c.opts.BaseURL, apiVersion, accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var account alpaca.Account
+	if err = unmarshal(resp, &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+type CreateACHRelationshipRequest struct {
+	AccountOwnerName  string          `json:"account_owner_name"`
+	BankAccountType   BankAccountType `json:"bank_account_type"`
+	BankAccountNumber string          `json:"bank_account_number"`
+	BankRoutingNumber string          `json:"bank_routing_number"`
+	Nickname          string          `json:"nickname,omitempty"`
+	// ProcessorToken is a Plaid processor token, used instead of the bank account details.
+	ProcessorToken string `json:"processor_token,omitempty"`
+}
+
+// CreateACHRelationship links a bank account to the account.
+func (c *Client) CreateACHRelationship(accountID string, req CreateACHRelationshipRequest) (*ACHRelationship, error) {
+	return c.CreateACHRelationshipWithContext(context.Background(), accountID, req)
+}
+
+// CreateACHRelationshipWithContext links a bank account to the account.
+func (c *Client) CreateACHRelationshipWithContext(
+	ctx context.Context, accountID string, req CreateACHRelationshipRequest,
+) (*ACHRelationship, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/accounts/%s/ach_relationships", c.opts.BaseURL, apiVersion, accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.post(ctx, u, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var relationship ACHRelationship
+	if err = unmarshal(resp, &relationship); err != nil {
+		return nil, err
+	}
+	return &relationship, nil
+}
+
+// GetACHRelationships returns the ACH relationships of the account, optionally filtered by status.
+func (c *Client) GetACHRelationships(accountID string, statuses ...string) ([]ACHRelationship, error) {
+	return c.GetACHRelationshipsWithContext(context.Background(), accountID, statuses...)
+}
+
+// GetACHRelationshipsWithContext returns the ACH relationships of the account, optionally filtered by status.
+func (c *Client) GetACHRelationshipsWithContext(ctx context.Context, accountID string, statuses ...string) ([]ACHRelationship, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/accounts/%s/ach_relationships", c.opts.BaseURL, apiVersion, accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(statuses) > 0 {
+		q := u.Query()
+		q.Set("statuses", strings.Join(statuses, ","))
+		u.RawQuery = q.Encode()
+	}
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var relationships achRelationshipSlice
+	if err = unmarshal(resp, &relationships); err != nil {
+		return nil, err
+	}
+	return relationships, nil
+}
+
+// DeleteACHRelationship removes an ACH relationship of the account.
+func (c *Client) DeleteACHRelationship(accountID, relationshipID string) error {
+	return c.DeleteACHRelationshipWithContext(context.Background(), accountID, relationshipID)
+}
+
+// DeleteACHRelationshipWithContext removes an ACH relationship of the account.
+func (c *Client) DeleteACHRelationshipWithContext(ctx context.Context, accountID, relationshipID string) error {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/accounts/%s/ach_relationships/%s",
+		c.opts.BaseURL, apiVersion, accountID, relationshipID))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.delete(ctx, u)
+	if err != nil {
+		return err
+	}
+	httpbody.Close(resp)
+	return nil
+}
+
+type CreateTransferRequest struct {
+	TransferType   TransferType      `json:"transfer_type"`
+	RelationshipID string            `json:"relationship_id"`
+	Amount         decimal.Decimal   `json:"amount"`
+	Direction      TransferDirection `json:"direction"`
+	// Timing defaults to immediate.
+	Timing string `json:"timing,omitempty"`
+}
+
+// CreateTransfer moves funds between the account and a linked bank.
+func (c *Client) CreateTransfer(accountID string, req CreateTransferRequest) (*Transfer, error) {
+	return c.CreateTransferWithContext(context.Background(), accountID, req)
+}
+
+// CreateTransferWithContext moves funds between the account and a linked bank.
+func (c *Client) CreateTransferWithContext(ctx context.Context, accountID string, req CreateTransferRequest) (*Transfer, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/accounts/%s/transfers", c.opts.BaseURL, apiVersion, accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.post(ctx, u, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfer Transfer
+	if err = unmarshal(resp, &transfer); err != nil {
+		return nil, err
+	}
+	return &transfer, nil
+}
+
+type GetTransfersRequest struct {
+	Direction TransferDirection
+	Limit     int
+	Offset    int
+}
+
+// GetTransfers returns the transfers of the account.
+func (c *Client) GetTransfers(accountID string, req GetTransfersRequest) ([]Transfer, error) {
+	return c.GetTransfersWithContext(context.Background(), accountID, req)
+}
+
+// GetTransfersWithContext returns the transfers of the account.
+func (c *Client) GetTransfersWithContext(ctx context.Context, accountID string, req GetTransfersRequest) ([]Transfer, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/accounts/%s/transfers", c.opts.BaseURL, apiVersion, accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+	if req.Direction != "" {
+		q.Set("direction", string(req.Direction))
+	}
+	if req.Limit != 0 {
+		q.Set("limit", strconv.Itoa(req.Limit))
+	}
+	if req.Offset != 0 {
+		q.Set("offset", strconv.Itoa(req.Offset))
+	}
+	u.RawQuery = q.Encode()
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var transfers transferSlice
+	if err = unmarshal(resp, &transfers); err != nil {
+		return nil, err
+	}
+	return transfers, nil
+}
+
+// CancelTransfer cancels a queued transfer of the account.
+func (c *Client) CancelTransfer(accountID, transferID string) error {
+	return c.CancelTransferWithContext(context.Background(), accountID, transferID)
+}
+
+// CancelTransferWithContext cancels a queued transfer of the account.
+func (c *Client) CancelTransferWithContext(ctx context.Context, accountID, transferID string) error {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/accounts/%s/transfers/%s", c.opts.BaseURL, apiVersion, accountID, transferID))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.delete(ctx, u)
+	if err != nil {
+		return err
+	}
+	httpbody.Close(resp)
+	return nil
+}
+
+type CreateJournalRequest struct {
+	EntryType   JournalEntryType `json:"entry_type"`
+	FromAccount string           `json:"from_account"`
+	ToAccount   string           `json:"to_account"`
+	// Amount is the cash amount of a JNLC journal.
+	Amount *decimal.Decimal `json:"amount,omitempty"`
+	// Symbol and Qty are the shares moved by a JNLS journal.
+	Symbol      string           `json:"symbol,omitempty"`
+	Qty         *decimal.Decimal `json:"qty,omitempty"`
+	Description string           `json:"description,omitempty"`
+}
+
+// CreateJournal moves cash or shares between two accounts.
+func (c *Client) CreateJournal(req CreateJournalRequest) (*Journal, error) {
+	return c.CreateJournalWithContext(context.Background(), req)
+}
+
+// CreateJournalWithContext moves cash or shares between two accounts.
+func (c *Client) CreateJournalWithContext(ctx context.Context, req CreateJournalRequest) (*Journal, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/journals", c.opts.BaseURL, apiVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.post(ctx, u, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var journal Journal
+	if err = unmarshal(resp, &journal); err != nil {
+		return nil, err
+	}
+	return &journal, nil
+}
+
+type BatchJournalEntry struct {
+	ToAccount string          `json:"to_account"`
+	Amount    decimal.Decimal `json:"amount"`
+}
+
+type CreateBatchJournalRequest struct {
+	// EntryType must be JNLC, batch journals only move cash.
+	EntryType   JournalEntryType    `json:"entry_type"`
+	FromAccount string              `json:"from_account"`
+	Entries     []BatchJournalEntry `json:"entries"`
+	Description string              `json:"description,omitempty"`
+}
+
+// CreateBatchJournal moves cash from one account to many accounts.
+// It returns one journal per entry.
+func (c *Client) CreateBatchJournal(req CreateBatchJournalRequest) ([]Journal, error) {
+	return c.CreateBatchJournalWithContext(context.Background(), req)
+}
+
+// CreateBatchJournalWithContext moves cash from one account to many accounts.
+// It returns one journal per entry.
+func (c *Client) CreateBatchJournalWithContext(ctx context.Context, req CreateBatchJournalRequest) ([]Journal, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/journals/batch", c.opts.BaseURL, apiVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.post(ctx, u, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var journals journalSlice
+	if err = unmarshal(resp, &journals); err != nil {
+		return nil, err
+	}
+	return journals, nil
+}
+
+type GetJournalsRequest struct {
+	After       time.Time
+	Before      time.Time
+	Status      string
+	EntryType   JournalEntryType
+	ToAccount   string
+	FromAccount string
+}
+
+// GetJournals returns the journals matching req.
+func (c *Client) GetJournals(req GetJournalsRequest) ([]Journal, error) {
+	return c.GetJournalsWithContext(context.Background(), req)
+}
+
+// GetJournalsWithContext returns the journals matching req.
+func (c *Client) GetJournalsWithContext(ctx context.Context, req GetJournalsRequest) ([]Journal, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/journals", c.opts.BaseURL, apiVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+	if !req.After.IsZero() {
+		q.Set("after", req.After.Format("2006-01-02"))
+	}
+	if !req.Before.IsZero() {
+		q.Set("before", req.Before.Format("2006-01-02"))
+	}
+	if req.Status != "" {
+		q.Set("status", req.Status)
+	}
+	if req.EntryType != "" {
+		q.Set("entry_type", string(req.EntryType))
+	}
+	if req.ToAccount != "" {
+		q.Set("to_account", req.ToAccount)
+	}
+	if req.FromAccount != "" {
+		q.Set("from_account", req.FromAccount)
+	}
+	u.RawQuery = q.Encode()
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var journals journalSlice
+	if err = unmarshal(resp, &journals); err != nil {
+		return nil, err
+	}
+	return journals, nil
+}
+
+// CancelJournal cancels a pending journal.
+func (c *Client) CancelJournal(journalID string) error {
+	return c.CancelJournalWithContext(context.Background(), journalID)
+}
+
+// CancelJournalWithContext cancels a pending journal.
+func (c *Client) CancelJournalWithContext(ctx context.Context, journalID string) error {
+	u, err := url.Parse(fmt.Sprintf("%s/%s/journals/%s", c.opts.BaseURL, apiVersion, journalID))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.delete(ctx, u)
+	if err != nil {
+		return err
+	}
+	httpbody.Close(resp)
+	return nil
+}
+
+func (c *Client) tradingURL(accountID, format string, a ...interface{}) (*url.URL, error) {
+	return url.Parse(fmt.Sprintf("%s/%s/trading/accounts/%s", c.opts.BaseURL, apiVersion, accountID) + fmt.Sprintf(format, a...))
+}
+
+// PlaceOrder submits an order for the account.
+func (c *Client) PlaceOrder(accountID string, req alpaca.PlaceOrderRequest) (*alpaca.Order, error) {
+	return c.PlaceOrderWithContext(context.Background(), accountID, req)
+}
+
+// PlaceOrderWithContext submits an order for the account.
+func (c *Client) PlaceOrderWithContext(ctx context.Context, accountID string, req alpaca.PlaceOrderRequest) (*alpaca.Order, error) {
+	u, err := c.tradingURL(accountID, "/orders")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.post(ctx, u, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var order alpaca.Order
+	if err = unmarshal(resp, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// GetOrders returns the orders of the account.
+func (c *Client) GetOrders(accountID string, req alpaca.GetOrdersRequest) ([]alpaca.Order, error) {
+	return c.GetOrdersWithContext(context.Background(), accountID, req)
+}
+
+// GetOrdersWithContext returns the orders of the account.
+func (c *Client) GetOrdersWithContext(ctx context.Context, accountID string, req alpaca.GetOrdersRequest) ([]alpaca.Order, error) {
+	u, err := c.tradingURL(accountID, "/orders")
+	if err != nil {
+		return nil, err
+	}
+
+	u.RawQuery = req.Query().Encode()
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var orders orderSlice
+	if err = unmarshal(resp, &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// GetOrder returns an order of the account.
+func (c *Client) GetOrder(accountID, orderID string) (*alpaca.Order, error) {
+	return c.GetOrderWithContext(context.Background(), accountID, orderID)
+}
+
+// GetOrderWithContext returns an order of the account.
+func (c *Client) GetOrderWithContext(ctx context.Context, accountID, orderID string) (*alpaca.Order, error) {
+	u, err := c.tradingURL(accountID, "/orders/%s", orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var order alpaca.Order
+	if err = unmarshal(resp, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// ReplaceOrder replaces an open order of the account.
+func (c *Client) ReplaceOrder(accountID, orderID string, req alpaca.ReplaceOrderRequest) (*alpaca.Order, error) {
+	return c.ReplaceOrderWithContext(context.Background(), accountID, orderID, req)
+}
+
+// ReplaceOrderWithContext replaces an open order of the account.
+func (c *Client) ReplaceOrderWithContext(
+	ctx context.Context, accountID, orderID string, req alpaca.ReplaceOrderRequest,
+) (*alpaca.Order, error) {
+	u, err := c.tradingURL(accountID, "/orders/%s", orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.patch(ctx, u, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var order alpaca.Order
+	if err = unmarshal(resp, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// CancelOrder cancels an open order of the account.
+func (c *Client) CancelOrder(accountID, orderID string) error {
+	return c.CancelOrderWithContext(context.Background(), accountID, orderID)
+}
+
+// CancelOrderWithContext cancels an open order of the account.
+func (c *Client) CancelOrderWithContext(ctx context.Context, accountID, orderID string) error {
+	u, err := c.tradingURL(accountID, "/orders/%s", orderID)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.delete(ctx, u)
+	if err != nil {
+		return err
+	}
+	httpbody.Close(resp)
+	return nil
+}
+
+// CancelAllOrders cancels all open orders of the account.
+// It returns the outcome for every order. The error is only set if the whole request failed.
+func (c *Client) CancelAllOrders(accountID string) ([]alpaca.CancelOrderResult, error) {
+	return c.CancelAllOrdersWithContext(context.Background(), accountID)
+}
+
+// CancelAllOrdersWithContext cancels all open orders of the account.
+// It returns the outcome for every order. The error is only set if the whole request failed.
+func (c *Client) CancelAllOrdersWithContext(ctx context.Context, accountID string) ([]alpaca.CancelOrderResult, error) {
+	u, err := c.tradingURL(accountID, "/orders")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.delete(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses multiStatusSlice
+	if err = unmarshal(resp, &responses); err != nil {
+		return nil, err
+	}
+
+	results := make([]alpaca.CancelOrderResult, 0, len(responses))
+	for _, r := range responses {
+		results = append(results, alpaca.NewCancelOrderResult(r.ID, r.Status, r.Body))
+	}
+	return results, nil
+}
+
+// GetPositions returns the open positions of the account.
+func (c *Client) GetPositions(accountID string) ([]alpaca.Position, error) {
+	return c.GetPositionsWithContext(context.Background(), accountID)
+}
+
+// GetPositionsWithContext returns the open positions of the account.
+func (c *Client) GetPositionsWithContext(ctx context.Context, accountID string) ([]alpaca.Position, error) {
+	u, err := c.tradingURL(accountID, "/positions")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var positions positionSlice
+	if err = unmarshal(resp, &positions); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// GetPosition returns the open position of the account in symbol.
+func (c *Client) GetPosition(accountID, symbol string) (*alpaca.Position, error) {
+	return c.GetPositionWithContext(context.Background(), accountID, symbol)
+}
+
+// GetPositionWithContext returns the open position of the account in symbol.
+func (c *Client) GetPositionWithContext(ctx context.Context, accountID, symbol string) (*alpaca.Position, error) {
+	u, err := c.tradingURL(accountID, "/positions/%s", url.PathEscape(symbol))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.get(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var position alpaca.Position
+	if err = unmarshal(resp, &position); err != nil {
+		return nil, err
+	}
+	return &position, nil
+}
+
+// ClosePosition liquidates the position of the account in symbol, fully or partially.
+func (c *Client) ClosePosition(accountID, symbol string, req alpaca.ClosePositionRequest) (*alpaca.Order, error) {
+	return c.ClosePositionWithContext(context.Background(), accountID, symbol, req)
+}
+
+// ClosePositionWithContext liquidates the position of the account in symbol, fully or partially.
+func (c *Client) ClosePositionWithContext(
+	ctx context.Context, accountID, symbol string, req alpaca.ClosePositionRequest,
+) (*alpaca.Order, error) {
+	u, err := c.tradingURL(accountID, "/positions/%s", url.PathEscape(symbol))
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+	if !req.Qty.IsZero() {
+		q.Set("qty", req.Qty.String())
+	}
+	if !req.Percentage.IsZero() {
+		q.Set("percentage", req.Percentage.String())
+	}
+	u.RawQuery = q.Encode()
+
+	resp, err := c.delete(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var order alpaca.Order
+	if err = unmarshal(resp, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// CloseAllPositions liquidates all open positions of the account at market price.
+// It returns the outcome for every position. The error is only set if the whole request failed.
+func (c *Client) CloseAllPositions(accountID string, req alpaca.CloseAllPositionsRequest) ([]alpaca.ClosePositionResult, error) {
+	return c.CloseAllPositionsWithContext(context.Background(), accountID, req)
+}
+
+// CloseAllPositionsWithContext liquidates all open positions of the account at market price.
+// It returns the outcome for every position. The error is only set if the whole request failed.
+func (c *Client) CloseAllPositionsWithContext(
+	ctx context.Context, accountID string, req alpaca.CloseAllPositionsRequest,
+) ([]alpaca.ClosePositionResult, error) {
+	u, err := c.tradingURL(accountID, "/positions")
+	if err != nil {
+		return nil, err
+	}
+
+	q := u.Query()
+	q.Set("cancel_orders", strconv.FormatBool(req.CancelOrders))
+	u.RawQuery = q.Encode()
+
+	resp, err := c.delete(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+
+	var responses multiStatusSlice
+	if err = unmarshal(resp, &responses); err != nil {
+		return nil, err
+	}
+
+	results := make([]alpaca.ClosePositionResult, 0, len(responses))
+	for _, r := range responses {
+		results = append(results, alpaca.NewClosePositionResult(r.Symbol, r.Status, r.Body))
+	}
+	return results, nil
+}
+
+func (c *Client) get(ctx context.Context, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(c, req)
+}
+
+func (c *Client) post(ctx context.Context, u *url.URL, data interface{}) (*http.Response, error) {
+	var body io.Reader
+	if data != nil {
+		buf, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(buf)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(c, req)
+}
+
+func (c *Client) patch(ctx context.Context, u *url.URL, data interface{}) (*http.Response, error) {
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, u.String(), bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(c, req)
+}
+
+func (c *Client) delete(ctx context.Context, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(c, req)
+}
+
+func unmarshal(resp *http.Response, v easyjson.Unmarshaler) error {
+	defer httpbody.Close(resp)
+	return easyjson.UnmarshalFromReader(resp.Body, v)
+}
@@ -0,0 +1,328 @@
+package broker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+)
+
+func genBody(data interface{}) io.ReadCloser {
+	buf, _ := json.Marshal(data)
+	return io.NopCloser(bytes.NewBuffer(buf))
+}
+
+func readBody(t *testing.T, req *http.Request) map[string]interface{} {
+	t.Helper()
+	var body map[string]interface{}
+	require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+	return body
+}
+
+func TestDefaultDo(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, secret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "key", key)
+		assert.Equal(t, "secret", secret)
+		assert.Equal(t, "/v1/accounts/acct", r.URL.Path)
+		fmt.Fprint(w, `{"id":"acct","account_number":"123","status":"ACTIVE","last_equity":"100.5"}`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ClientOpts{APIKey: "key", APISecret: "secret", BaseURL: ts.URL})
+	account, err := c.GetAccount("acct")
+	require.NoError(t, err)
+	assert.Equal(t, "123", account.AccountNumber)
+	assert.Equal(t, "ACTIVE", account.Status)
+	assert.True(t, decimal.RequireFromString("100.5").Equal(account.LastEquity))
+}
+
+func TestAPIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-1")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"code":42210000,"message":"insufficient funds"}`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(ClientOpts{BaseURL: ts.URL})
+	_, err := c.CreateTransfer("acct", CreateTransferRequest{})
+	var apiErr *alpaca.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	assert.Equal(t, 42210000, apiErr.Code)
+	assert.Equal(t, "insufficient funds", apiErr.Message)
+	assert.Equal(t, "req-1", apiErr.RequestID)
+	assert.Equal(t, http.MethodPost, apiErr.Method)
+	assert.Equal(t, "/v1/accounts/acct/transfers", apiErr.Path)
+}
+
+func TestCreateAccount(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "/v1/accounts", req.URL.Path)
+		body := readBody(t, req)
+		assert.Equal(t, "john@example.com", body["contact"].(map[string]interface{})["email_address"])
+		assert.Equal(t, "1990-01-02", body["identity"].(map[string]interface{})["date_of_birth"])
+		assert.NotContains(t, body, "trusted_contact")
+		return &http.Response{Body: genBody(Account{ID: "acct", Status: "SUBMITTED"})}, nil
+	}
+
+	birthDate := civil.Date{Year: 1990, Month: time.January, Day: 2}
+	signedAt := time.Date(2024, 3, 1, 15, 0, 0, 0, time.UTC)
+	account, err := c.CreateAccount(CreateAccountRequest{
+		Contact:  Contact{EmailAddress: "john@example.com"},
+		Identity: Identity{GivenName: "John", DateOfBirth: &birthDate},
+		Agreements: []Agreement{
+			{Agreement: "customer_agreement", SignedAt: signedAt, IPAddress: "127.0.0.1"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "acct", account.ID)
+	assert.Equal(t, "SUBMITTED", account.Status)
+}
+
+func TestListAccounts(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "/v1/accounts", req.URL.Path)
+		assert.Equal(t, "john", req.URL.Query().Get("query"))
+		assert.Equal(t, "ACTIVE,APPROVED", req.URL.Query().Get("status"))
+		assert.Equal(t, "contact,identity", req.URL.Query().Get("entities"))
+		return &http.Response{Body: genBody([]Account{{ID: "a"}, {ID: "b"}})}, nil
+	}
+
+	accounts, err := c.ListAccounts(ListAccountsRequest{
+		Query:    "john",
+		Status:   []string{"ACTIVE", "APPROVED"},
+		Entities: []string{"contact", "identity"},
+	})
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	assert.Equal(t, "b", accounts[1].ID)
+}
+
+func TestFunding(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "POST /v1/accounts/acct/ach_relationships":
+			body := readBody(t, req)
+			assert.Equal(t, "CHECKING", body["bank_account_type"])
+			assert.NotContains(t, body, "processor_token")
+			return &http.Response{Body: genBody(ACHRelationship{ID: "rel", Status: "QUEUED"})}, nil
+		case "GET /v1/accounts/acct/ach_relationships":
+			assert.Equal(t, "APPROVED", req.URL.Query().Get("statuses"))
+			return &http.Response{Body: genBody([]ACHRelationship{{ID: "rel", Status: "APPROVED"}})}, nil
+		case "POST /v1/accounts/acct/transfers":
+			body := readBody(t, req)
+			assert.Equal(t, "ach", body["transfer_type"])
+			assert.Equal(t, "1000", body["amount"])
+			assert.Equal(t, "INCOMING", body["direction"])
+			return &http.Response{Body: genBody(Transfer{ID: "tr", Amount: decimal.NewFromInt(1000)})}, nil
+		case "GET /v1/accounts/acct/transfers":
+			assert.Equal(t, "OUTGOING", req.URL.Query().Get("direction"))
+			assert.Equal(t, "10", req.URL.Query().Get("limit"))
+			return &http.Response{Body: genBody([]Transfer{{ID: "tr"}})}, nil
+		case "DELETE /v1/accounts/acct/transfers/tr", "DELETE /v1/accounts/acct/ach_relationships/rel":
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+		}
+		t.Errorf("unexpected request %s %s", req.Method, req.URL)
+		return nil, errors.New("unexpected request")
+	}
+
+	rel, err := c.CreateACHRelationship("acct", CreateACHRelationshipRequest{
+		AccountOwnerName: "John", BankAccountType: Checking, BankAccountNumber: "1", BankRoutingNumber: "2",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "rel", rel.ID)
+	rels, err := c.GetACHRelationships("acct", "APPROVED")
+	require.NoError(t, err)
+	assert.Len(t, rels, 1)
+
+	tr, err := c.CreateTransfer("acct", CreateTransferRequest{
+		TransferType: ACH, RelationshipID: rel.ID, Amount: decimal.NewFromInt(1000), Direction: Incoming,
+	})
+	require.NoError(t, err)
+	assert.True(t, tr.Amount.Equal(decimal.NewFromInt(1000)))
+	transfers, err := c.GetTransfers("acct", GetTransfersRequest{Direction: Outgoing, Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, transfers, 1)
+
+	require.NoError(t, c.CancelTransfer("acct", "tr"))
+	require.NoError(t, c.DeleteACHRelationship("acct", "rel"))
+}
+
+func TestJournals(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "POST /v1/journals":
+			body := readBody(t, req)
+			assert.Equal(t, "JNLS", body["entry_type"])
+			assert.Equal(t, "AAPL", body["symbol"])
+			assert.NotContains(t, body, "amount")
+			return &http.Response{Body: genBody(Journal{ID: "j", EntryType: JournalSecurities})}, nil
+		case "POST /v1/journals/batch":
+			body := readBody(t, req)
+			assert.Len(t, body["entries"], 2)
+			return &http.Response{Body: genBody([]Journal{{ID: "j1"}, {ID: "j2"}})}, nil
+		case "GET /v1/journals":
+			assert.Equal(t, "2024-03-01", req.URL.Query().Get("after"))
+			assert.Equal(t, "JNLC", req.URL.Query().Get("entry_type"))
+			return &http.Response{Body: genBody([]Journal{{ID: "j1"}})}, nil
+		case "DELETE /v1/journals/j":
+			return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}, nil
+		}
+		t.Errorf("unexpected request %s %s", req.Method, req.URL)
+		return nil, errors.New("unexpected request")
+	}
+
+	qty := decimal.NewFromInt(5)
+	journal, err := c.CreateJournal(CreateJournalRequest{
+		EntryType: JournalSecurities, FromAccount: "firm", ToAccount: "acct", Symbol: "AAPL", Qty: &qty,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, JournalSecurities, journal.EntryType)
+
+	journals, err := c.CreateBatchJournal(CreateBatchJournalRequest{
+		EntryType: JournalCash, FromAccount: "firm",
+		Entries: []BatchJournalEntry{
+			{ToAccount: "a", Amount: decimal.NewFromInt(10)},
+			{ToAccount: "b", Amount: decimal.NewFromInt(20)},
+		},
+	})
+	require.NoError(t, err)
+	assert.Len(t, journals, 2)
+
+	journals, err = c.GetJournals(GetJournalsRequest{
+		After: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), EntryType: JournalCash,
+	})
+	require.NoError(t, err)
+	assert.Len(t, journals, 1)
+
+	require.NoError(t, c.CancelJournal("j"))
+}
+
+func TestTrading(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		switch req.Method + " " + req.URL.Path {
+		case "GET /v1/trading/accounts/acct/account":
+			return &http.Response{Body: genBody(alpaca.Account{ID: "acct", Cash: decimal.NewFromInt(500)})}, nil
+		case "POST /v1/trading/accounts/acct/orders":
+			body := readBody(t, req)
+			assert.Equal(t, "AAPL", body["symbol"])
+			assert.Equal(t, "market", body["type"])
+			return &http.Response{Body: genBody(alpaca.Order{ID: "order", Symbol: "AAPL"})}, nil
+		case "GET /v1/trading/accounts/acct/orders":
+			assert.Equal(t, "all", req.URL.Query().Get("status"))
+			return &http.Response{Body: genBody([]alpaca.Order{{ID: "order"}})}, nil
+		case "PATCH /v1/trading/accounts/acct/orders/order":
+			assert.Equal(t, "2", readBody(t, req)["qty"])
+			return &http.Response{Body: genBody(alpaca.Order{ID: "replacement"})}, nil
+		case "GET /v1/trading/accounts/acct/positions":
+			return &http.Response{Body: genBody([]alpaca.Position{{Symbol: "AAPL"}})}, nil
+		case "DELETE /v1/trading/accounts/acct/positions/BTC/USD":
+			assert.Equal(t, "50", req.URL.Query().Get("percentage"))
+			return &http.Response{Body: genBody(alpaca.Order{ID: "close"})}, nil
+		}
+		t.Errorf("unexpected request %s %s", req.Method, req.URL)
+		return nil, errors.New("unexpected request")
+	}
+
+	account, err := c.GetTradingAccount("acct")
+	require.NoError(t, err)
+	assert.True(t, account.Cash.Equal(decimal.NewFromInt(500)))
+
+	qty := decimal.NewFromInt(1)
+	order, err := c.PlaceOrder("acct", alpaca.PlaceOrderRequest{
+		Symbol: "AAPL", Qty: &qty, Side: alpaca.Buy, Type: alpaca.Market, TimeInForce: alpaca.Day,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "order", order.ID)
+
+	orders, err := c.GetOrders("acct", alpaca.GetOrdersRequest{Status: "all"})
+	require.NoError(t, err)
+	assert.Len(t, orders, 1)
+
+	newQty := decimal.NewFromInt(2)
+	order, err = c.ReplaceOrder("acct", "order", alpaca.ReplaceOrderRequest{Qty: &newQty})
+	require.NoError(t, err)
+	assert.Equal(t, "replacement", order.ID)
+
+	positions, err := c.GetPositions("acct")
+	require.NoError(t, err)
+	assert.Equal(t, "AAPL", positions[0].Symbol)
+
+	order, err = c.ClosePosition("acct", "BTC/USD", alpaca.ClosePositionRequest{Percentage: decimal.NewFromInt(50)})
+	require.NoError(t, err)
+	assert.Equal(t, "close", order.ID)
+}
+
+func TestBulkResults(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodDelete, req.Method)
+		switch req.URL.Path {
+		case "/v1/trading/accounts/acct/orders":
+			return &http.Response{
+				StatusCode: http.StatusMultiStatus,
+				Body: io.NopCloser(bytes.NewBufferString(`[
+					{"id":"o1","status":200,"body":{"id":"o1","status":"pending_cancel"}},
+					{"id":"o2","status":500,"body":{"code":50010000,"message":"internal error"}}
+				]`)),
+			}, nil
+		case "/v1/trading/accounts/acct/positions":
+			assert.Equal(t, "true", req.URL.Query().Get("cancel_orders"))
+			return &http.Response{
+				StatusCode: http.StatusMultiStatus,
+				Body: io.NopCloser(bytes.NewBufferString(`[
+					{"symbol":"AAPL","status":200,"body":{"id":"o3"}},
+					{"symbol":"TSLA","status":200}
+				]`)),
+			}, nil
+		}
+		return nil, errors.New("unexpected request")
+	}
+
+	cancels, err := c.CancelAllOrders("acct")
+	require.NoError(t, err)
+	require.Len(t, cancels, 2)
+	assert.Equal(t, "o1", cancels[0].OrderID)
+	assert.Equal(t, alpaca.OrderStatus("pending_cancel"), cancels[0].Order.Status)
+	var apiErr *alpaca.APIError
+	require.True(t, errors.As(cancels[1].Err, &apiErr))
+	assert.Equal(t, "internal error", apiErr.Message)
+
+	closes, err := c.CloseAllPositions("acct", alpaca.CloseAllPositionsRequest{CancelOrders: true})
+	require.NoError(t, err)
+	require.Len(t, closes, 2)
+	assert.Equal(t, "AAPL", closes[0].Symbol)
+	assert.Equal(t, "o3", closes[0].Order.ID)
+	assert.Nil(t, closes[1].Order)
+	assert.EqualError(t, closes[1].Err, "missing order in response")
+}
+
+func TestContextCancelled(t *testing.T) {
+	c := NewClient(ClientOpts{BaseURL: "http://127.0.0.1:1"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.GetAccountWithContext(ctx, "acct")
+	require.ErrorIs(t, err, context.Canceled)
+}
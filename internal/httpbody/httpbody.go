@@ -0,0 +1,14 @@
+// Package httpbody contains helpers for HTTP response bodies shared by the clients of this module.
+package httpbody
+
+import (
+	"io"
+	"net/http"
+)
+
+// Close drains and closes the body of resp.
+func Close(resp *http.Response) {
+	// The underlying TCP connection can not be reused if the body is not fully read
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+}
@@ -0,0 +1,72 @@
+// Package sse parses server-sent events (the text/event-stream format) for the streaming endpoints
+// of the trading and broker APIs.
+package sse
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+)
+
+// Event is a single event of a text/event-stream response.
+type Event struct {
+	Event string
+	ID    string
+	Data  []byte
+}
+
+// Read parses the text/event-stream format from r and calls handler for
+// every event with data. Comments and unknown fields are ignored, data fields spanning
+// multiple lines are joined with newlines. It returns nil at the end of the stream.
+func Read(r io.Reader, handler func(Event) error) error {
+	reader := bufio.NewReader(r)
+	var (
+		event   Event
+		data    bytes.Buffer
+		hasData bool
+	)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// an incomplete event at the end of the stream is discarded
+				return nil
+			}
+			return err
+		}
+		line = bytes.TrimSuffix(bytes.TrimSuffix(line, []byte("\n")), []byte("\r"))
+
+		if len(line) == 0 {
+			if hasData {
+				event.Data = append([]byte(nil), data.Bytes()...)
+				if err := handler(event); err != nil {
+					return err
+				}
+			}
+			event, hasData = Event{}, false
+			data.Reset()
+			continue
+		}
+		if line[0] == ':' {
+			continue
+		}
+
+		field, value := line, []byte(nil)
+		if i := bytes.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], bytes.TrimPrefix(line[i+1:], []byte(" "))
+		}
+		switch string(field) {
+		case "event":
+			event.Event = string(value)
+		case "id":
+			event.ID = string(value)
+		case "data":
+			if hasData {
+				data.WriteByte('\n')
+			}
+			data.Write(value)
+			hasData = true
+		}
+	}
+}
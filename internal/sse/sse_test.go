@@ -0,0 +1,35 @@
+package sse
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	stream := ": keep-alive comment\n" +
+		"event: trade_update\r\n" +
+		"id: 01\r\n" +
+		"data: {\"event\":\r\n" +
+		"data:\"fill\"}\r\n" +
+		"\r\n" +
+		"retry: 1000\n" +
+		"\n" +
+		"data: second\n" +
+		"\n" +
+		"data: incomplete"
+	var events []Event
+	err := Read(strings.NewReader(stream), func(e Event) error {
+		events = append(events, e)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "trade_update", events[0].Event)
+	assert.Equal(t, "01", events[0].ID)
+	assert.Equal(t, "{\"event\":\n\"fill\"}", string(events[0].Data))
+	assert.Equal(t, "second", string(events[1].Data))
+	assert.Empty(t, events[1].ID)
+}
@@ -1,6 +1,7 @@
 package marketdata
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -28,7 +29,12 @@ type GetOptionTradesRequest struct {
 
 // GetOptionTrades returns the option trades for the given symbol.
 func (c *Client) GetOptionTrades(symbol string, req GetOptionTradesRequest) ([]OptionTrade, error) {
-	resp, err := c.GetOptionMultiTrades([]string{symbol}, req)
+	return c.GetOptionTradesWithContext(context.Background(), symbol, req)
+}
+
+// GetOptionTradesWithContext returns the option trades for the given symbol.
+func (c *Client) GetOptionTradesWithContext(ctx context.Context, symbol string, req GetOptionTradesRequest) ([]OptionTrade, error) {
+	resp, err := c.GetOptionMultiTradesWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -37,6 +43,11 @@ func (c *Client) GetOptionTrades(symbol string, req GetOptionTradesRequest) ([]O
 
 // GetOptionMultiTrades returns option trades for the given symbols.
 func (c *Client) GetOptionMultiTrades(symbols []string, req GetOptionTradesRequest) (map[string][]OptionTrade, error) {
+	return c.GetOptionMultiTradesWithContext(context.Background(), symbols, req)
+}
+
+// GetOptionMultiTradesWithContext returns option trades for the given symbols.
+func (c *Client) GetOptionMultiTradesWithContext(ctx context.Context, symbols []string, req GetOptionTradesRequest) (map[string][]OptionTrade, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/trades", c.opts.BaseURL, optionPrefix))
 	if err != nil {
 		return nil, err
@@ -53,10 +64,13 @@ func (c *Client) GetOptionMultiTrades(symbols []string, req GetOptionTradesReque
 	trades := make(map[string][]OptionTrade, len(symbols))
 	received := 0
 	for req.TotalLimit == 0 || received < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		setQueryLimit(q, req.TotalLimit, req.PageLimit, received, v2MaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return nil, err
 		}
@@ -97,7 +111,12 @@ type GetOptionBarsRequest struct {
 
 // GetOptionBars returns a slice of bars for the given symbol.
 func (c *Client) GetOptionBars(symbol string, req GetOptionBarsRequest) ([]OptionBar, error) {
-	resp, err := c.GetMultiOptionBars([]string{symbol}, req)
+	return c.GetOptionBarsWithContext(context.Background(), symbol, req)
+}
+
+// GetOptionBarsWithContext returns a slice of bars for the given symbol.
+func (c *Client) GetOptionBarsWithContext(ctx context.Context, symbol string, req GetOptionBarsRequest) ([]OptionBar, error) {
+	resp, err := c.GetMultiOptionBarsWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -106,6 +125,11 @@ func (c *Client) GetOptionBars(symbol string, req GetOptionBarsRequest) ([]Optio
 
 // GetMultiOptionBars returns bars for the given symbols.
 func (c *Client) GetMultiOptionBars(symbols []string, req GetOptionBarsRequest) (map[string][]OptionBar, error) {
+	return c.GetMultiOptionBarsWithContext(context.Background(), symbols, req)
+}
+
+// GetMultiOptionBarsWithContext returns bars for the given symbols.
+func (c *Client) GetMultiOptionBarsWithContext(ctx context.Context, symbols []string, req GetOptionBarsRequest) (map[string][]OptionBar, error) {
 	bars := make(map[string][]OptionBar, len(symbols))
 
 	u, err := url.Parse(fmt.Sprintf("%s/%s/bars", c.opts.BaseURL, optionPrefix))
@@ -128,10 +152,13 @@ func (c *Client) GetMultiOptionBars(symbols []string, req GetOptionBarsRequest)
 
 	received := 0
 	for req.TotalLimit == 0 || received < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		setQueryLimit(q, req.TotalLimit, req.PageLimit, received, v2MaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return nil, err
 		}
@@ -159,7 +186,12 @@ type GetLatestOptionTradeRequest struct {
 
 // GetLatestOptionTrade returns the latest option trade for a given symbol
 func (c *Client) GetLatestOptionTrade(symbol string, req GetLatestOptionTradeRequest) (*OptionTrade, error) {
-	resp, err := c.GetLatestOptionTrades([]string{symbol}, req)
+	return c.GetLatestOptionTradeWithContext(context.Background(), symbol, req)
+}
+
+// GetLatestOptionTradeWithContext returns the latest option trade for a given symbol
+func (c *Client) GetLatestOptionTradeWithContext(ctx context.Context, symbol string, req GetLatestOptionTradeRequest) (*OptionTrade, error) {
+	resp, err := c.GetLatestOptionTradesWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -172,6 +204,11 @@ func (c *Client) GetLatestOptionTrade(symbol string, req GetLatestOptionTradeReq
 
 // GetLatestOptionTrades returns the latest option trades for the given symbols
 func (c *Client) GetLatestOptionTrades(symbols []string, req GetLatestOptionTradeRequest) (map[string]OptionTrade, error) {
+	return c.GetLatestOptionTradesWithContext(context.Background(), symbols, req)
+}
+
+// GetLatestOptionTradesWithContext returns the latest option trades for the given symbols
+func (c *Client) GetLatestOptionTradesWithContext(ctx context.Context, symbols []string, req GetLatestOptionTradeRequest) (map[string]OptionTrade, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/trades/latest", c.opts.BaseURL, optionPrefix))
 	if err != nil {
 		return nil, err
@@ -181,7 +218,7 @@ func (c *Client) GetLatestOptionTrades(symbols []string, req GetLatestOptionTrad
 		Feed:    req.Feed,
 	})
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -199,7 +236,12 @@ type GetLatestOptionQuoteRequest struct {
 
 // GetLatestOptionQuote returns the latest option quote for a given symbol
 func (c *Client) GetLatestOptionQuote(symbol string, req GetLatestOptionQuoteRequest) (*OptionQuote, error) {
-	resp, err := c.GetLatestOptionQuotes([]string{symbol}, req)
+	return c.GetLatestOptionQuoteWithContext(context.Background(), symbol, req)
+}
+
+// GetLatestOptionQuoteWithContext returns the latest option quote for a given symbol
+func (c *Client) GetLatestOptionQuoteWithContext(ctx context.Context, symbol string, req GetLatestOptionQuoteRequest) (*OptionQuote, error) {
+	resp, err := c.GetLatestOptionQuotesWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -212,6 +254,11 @@ func (c *Client) GetLatestOptionQuote(symbol string, req GetLatestOptionQuoteReq
 
 // GetLatestOptionQuotes returns the latest option quotes for the given symbols
 func (c *Client) GetLatestOptionQuotes(symbols []string, req GetLatestOptionQuoteRequest) (map[string]OptionQuote, error) {
+	return c.GetLatestOptionQuotesWithContext(context.Background(), symbols, req)
+}
+
+// GetLatestOptionQuotesWithContext returns the latest option quotes for the given symbols
+func (c *Client) GetLatestOptionQuotesWithContext(ctx context.Context, symbols []string, req GetLatestOptionQuoteRequest) (map[string]OptionQuote, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/quotes/latest", c.opts.BaseURL, optionPrefix))
 	if err != nil {
 		return nil, err
@@ -221,7 +268,7 @@ func (c *Client) GetLatestOptionQuotes(symbols []string, req GetLatestOptionQuot
 		Feed:    req.Feed,
 	})
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -245,7 +292,12 @@ type GetOptionSnapshotRequest struct {
 
 // GetOptionSnapshot returns the snapshot for a given symbol
 func (c *Client) GetOptionSnapshot(symbol string, req GetOptionSnapshotRequest) (*OptionSnapshot, error) {
-	resp, err := c.GetOptionSnapshots([]string{symbol}, req)
+	return c.GetOptionSnapshotWithContext(context.Background(), symbol, req)
+}
+
+// GetOptionSnapshotWithContext returns the snapshot for a given symbol
+func (c *Client) GetOptionSnapshotWithContext(ctx context.Context, symbol string, req GetOptionSnapshotRequest) (*OptionSnapshot, error) {
+	resp, err := c.GetOptionSnapshotsWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -258,6 +310,11 @@ func (c *Client) GetOptionSnapshot(symbol string, req GetOptionSnapshotRequest)
 
 // GetOptionSnapshots returns the snapshots for multiple symbols
 func (c *Client) GetOptionSnapshots(symbols []string, req GetOptionSnapshotRequest) (map[string]OptionSnapshot, error) {
+	return c.GetOptionSnapshotsWithContext(context.Background(), symbols, req)
+}
+
+// GetOptionSnapshotsWithContext returns the snapshots for multiple symbols
+func (c *Client) GetOptionSnapshotsWithContext(ctx context.Context, symbols []string, req GetOptionSnapshotRequest) (map[string]OptionSnapshot, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/snapshots", c.opts.BaseURL, optionPrefix))
 	if err != nil {
 		return nil, err
@@ -272,10 +329,13 @@ func (c *Client) GetOptionSnapshots(symbols []string, req GetOptionSnapshotReque
 	snapshots := make(map[string]OptionSnapshot, len(symbols))
 	received := 0
 	for req.TotalLimit == 0 || received < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		setQueryLimit(q, req.TotalLimit, req.PageLimit, received, v2MaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return nil, err
 		}
@@ -323,6 +383,11 @@ type GetOptionChainRequest struct {
 
 // GetOptionChain returns the snapshot chain for an underlying symbol (e.g. AAPL)
 func (c *Client) GetOptionChain(underlyingSymbol string, req GetOptionChainRequest) (map[string]OptionSnapshot, error) {
+	return c.GetOptionChainWithContext(context.Background(), underlyingSymbol, req)
+}
+
+// GetOptionChainWithContext returns the snapshot chain for an underlying symbol (e.g. AAPL)
+func (c *Client) GetOptionChainWithContext(ctx context.Context, underlyingSymbol string, req GetOptionChainRequest) (map[string]OptionSnapshot, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/snapshots/%s", c.opts.BaseURL, optionPrefix, underlyingSymbol))
 	if err != nil {
 		return nil, err
@@ -356,10 +421,13 @@ func (c *Client) GetOptionChain(underlyingSymbol string, req GetOptionChainReque
 	snapshots := make(map[string]OptionSnapshot)
 	received := 0
 	for req.TotalLimit == 0 || received < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		setQueryLimit(q, req.TotalLimit, req.PageLimit, received, v2MaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return nil, err
 		}
@@ -386,52 +454,107 @@ func GetOptionTrades(symbol string, req GetOptionTradesRequest) ([]OptionTrade,
 	return DefaultClient.GetOptionTrades(symbol, req)
 }
 
+// GetOptionTradesWithContext returns the option trades for the given symbol.
+func GetOptionTradesWithContext(ctx context.Context, symbol string, req GetOptionTradesRequest) ([]OptionTrade, error) {
+	return DefaultClient.GetOptionTradesWithContext(ctx, symbol, req)
+}
+
 // GetOptionMultiTrades returns option trades for the given symbols.
 func GetOptionMultiTrades(symbols []string, req GetOptionTradesRequest) (map[string][]OptionTrade, error) {
 	return DefaultClient.GetOptionMultiTrades(symbols, req)
 }
 
+// GetOptionMultiTradesWithContext returns option trades for the given symbols.
+func GetOptionMultiTradesWithContext(ctx context.Context, symbols []string, req GetOptionTradesRequest) (map[string][]OptionTrade, error) {
+	return DefaultClient.GetOptionMultiTradesWithContext(ctx, symbols, req)
+}
+
 // GetOptionBars returns a slice of bars for the given symbol.
 func GetOptionBars(symbol string, req GetOptionBarsRequest) ([]OptionBar, error) {
 	return DefaultClient.GetOptionBars(symbol, req)
 }
 
+// GetOptionBarsWithContext returns a slice of bars for the given symbol.
+func GetOptionBarsWithContext(ctx context.Context, symbol string, req GetOptionBarsRequest) ([]OptionBar, error) {
+	return DefaultClient.GetOptionBarsWithContext(ctx, symbol, req)
+}
+
 // GetMultiOptionBars returns bars for the given symbols.
 func GetMultiOptionBars(symbols []string, req GetOptionBarsRequest) (map[string][]OptionBar, error) {
 	return DefaultClient.GetMultiOptionBars(symbols, req)
 }
 
+// GetMultiOptionBarsWithContext returns bars for the given symbols.
+func GetMultiOptionBarsWithContext(ctx context.Context, symbols []string, req GetOptionBarsRequest) (map[string][]OptionBar, error) {
+	return DefaultClient.GetMultiOptionBarsWithContext(ctx, symbols, req)
+}
+
 // GetLatestOptionTrade returns the latest option trade for a given symbol
 func GetLatestOptionTrade(symbol string, req GetLatestOptionTradeRequest) (*OptionTrade, error) {
 	return DefaultClient.GetLatestOptionTrade(symbol, req)
 }
 
+// GetLatestOptionTradeWithContext returns the latest option trade for a given symbol
+func GetLatestOptionTradeWithContext(ctx context.Context, symbol string, req GetLatestOptionTradeRequest) (*OptionTrade, error) {
+	return DefaultClient.GetLatestOptionTradeWithContext(ctx, symbol, req)
+}
+
 // GetLatestOptionTrades returns the latest option trades for the given symbols
 func GetLatestOptionTrades(symbols []string, req GetLatestOptionTradeRequest) (map[string]OptionTrade, error) {
 	return DefaultClient.GetLatestOptionTrades(symbols, req)
 }
 
+// GetLatestOptionTradesWithContext returns the latest option trades for the given symbols
+func GetLatestOptionTradesWithContext(ctx context.Context, symbols []string, req GetLatestOptionTradeRequest) (map[string]OptionTrade, error) {
+	return DefaultClient.GetLatestOptionTradesWithContext(ctx, symbols, req)
+}
+
 // GetLatestOptionQuote returns the latest option quote for a given symbol
 func GetLatestOptionQuote(symbol string, req GetLatestOptionQuoteRequest) (*OptionQuote, error) {
 	return DefaultClient.GetLatestOptionQuote(symbol, req)
 }
 
+// GetLatestOptionQuoteWithContext returns the latest option quote for a given symbol
+func GetLatestOptionQuoteWithContext(ctx context.Context, symbol string, req GetLatestOptionQuoteRequest) (*OptionQuote, error) {
+	return DefaultClient.GetLatestOptionQuoteWithContext(ctx, symbol, req)
+}
+
 // GetLatestOptionQuotes returns the latest option quotes for the given symbols
 func GetLatestOptionQuotes(symbols []string, req GetLatestOptionQuoteRequest) (map[string]OptionQuote, error) {
 	return DefaultClient.GetLatestOptionQuotes(symbols, req)
 }
 
+// GetLatestOptionQuotesWithContext returns the latest option quotes for the given symbols
+func GetLatestOptionQuotesWithContext(ctx context.Context, symbols []string, req GetLatestOptionQuoteRequest) (map[string]OptionQuote, error) {
+	return DefaultClient.GetLatestOptionQuotesWithContext(ctx, symbols, req)
+}
+
 // GetOptionSnapshot returns the snapshot for a given symbol
 func GetOptionSnapshot(symbol string, req GetOptionSnapshotRequest) (*OptionSnapshot, error) {
 	return DefaultClient.GetOptionSnapshot(symbol, req)
 }
 
+// GetOptionSnapshotWithContext returns the snapshot for a given symbol
+func GetOptionSnapshotWithContext(ctx context.Context, symbol string, req GetOptionSnapshotRequest) (*OptionSnapshot, error) {
+	return DefaultClient.GetOptionSnapshotWithContext(ctx, symbol, req)
+}
+
 // GetOptionSnapshots returns the snapshots for multiple symbols
 func GetOptionSnapshots(symbols []string, req GetOptionSnapshotRequest) (map[string]OptionSnapshot, error) {
 	return DefaultClient.GetOptionSnapshots(symbols, req)
 }
 
+// GetOptionSnapshotsWithContext returns the snapshots for multiple symbols
+func GetOptionSnapshotsWithContext(ctx context.Context, symbols []string, req GetOptionSnapshotRequest) (map[string]OptionSnapshot, error) {
+	return DefaultClient.GetOptionSnapshotsWithContext(ctx, symbols, req)
+}
+
 // GetOptionChain returns the snapshot chain for an underlying symbol (e.g. AAPL)
 func GetOptionChain(underlyingSymbol string, req GetOptionChainRequest) (map[string]OptionSnapshot, error) {
 	return DefaultClient.GetOptionChain(underlyingSymbol, req)
 }
+
+// GetOptionChainWithContext returns the snapshot chain for an underlying symbol (e.g. AAPL)
+func GetOptionChainWithContext(ctx context.Context, underlyingSymbol string, req GetOptionChainRequest) (map[string]OptionSnapshot, error) {
+	return DefaultClient.GetOptionChainWithContext(ctx, underlyingSymbol, req)
+}
@@ -2,6 +2,7 @@ package marketdata
 
 import (
 	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 	"github.com/mailru/easyjson"
 
 	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+	"github.com/alpacahq/alpaca-trade-api-go/v3/internal/httpbody"
 )
 
 // ClientOpts contains options for the alpaca marketdata client.
@@ -22,12 +24,18 @@ import (
 // Currently it contains the exact same options as the trading alpaca client,
 // but there is no guarantee that this will remain the case.
 type ClientOpts struct {
-	APIKey     string
-	APISecret  string
-	OAuth      string
-	BaseURL    string
+	APIKey    string
+	APISecret string
+	OAuth     string
+	BaseURL   string
+	// RetryLimit is the maximum number of retries of rate limited and failed requests. Ignored if RetryPolicy is set.
 	RetryLimit int
+	// RetryDelay is the fixed delay between retries. Ignored if RetryPolicy is set.
 	RetryDelay time.Duration
+	// RetryPolicy decides which failed requests are retried and when. If nil, requests failing with
+	// 429 or 500 are retried RetryLimit times with RetryDelay between the attempts, or the delay
+	// requested by the server capped at alpaca.DefaultMaxRetryDelay.
+	RetryPolicy alpaca.RetryPolicy
 	// Feed is the default feed to be used by all requests. Can be overridden per request.
 	Feed Feed
 	// CryptoFeed is the default crypto feed to be used by all requests. Can be overridden per request.
@@ -35,6 +43,9 @@ type ClientOpts struct {
 	// Currency is the default currency to be used by all requests. Can be overridden per request.
 	// For the latest endpoints this is the only way to set this parameter.
 	Currency string
+	// RateLimiter, if set, delays requests to stay within the rate limit. The same limiter
+	// can be shared between multiple clients of the same API, but not across APIs (see RateLimiter).
+	RateLimiter *alpaca.RateLimiter
 	// HTTPClient to be used for each http request.
 	HTTPClient *http.Client
 	// Host used to set the http request's host
@@ -73,12 +84,25 @@ func NewClient(opts ClientOpts) *Client {
 	if opts.RetryDelay == 0 {
 		opts.RetryDelay = time.Second
 	}
+	if opts.RetryPolicy == nil {
+		maxDelay := alpaca.DefaultMaxRetryDelay
+		if opts.RetryDelay > maxDelay {
+			maxDelay = opts.RetryDelay
+		}
+		opts.RetryPolicy = &alpaca.BackoffRetryPolicy{
+			MaxRetries:       opts.RetryLimit,
+			BaseDelay:        opts.RetryDelay,
+			MaxDelay:         maxDelay,
+			RetryStatusCodes: []int{http.StatusTooManyRequests, http.StatusInternalServerError},
+		}
+	}
 	httpClient := opts.HTTPClient
 	if httpClient == nil {
 		httpClient = &http.Client{
 			Timeout: 10 * time.Second,
 		}
 	}
+	httpClient = alpaca.RateLimitedHTTPClient(httpClient, opts.RateLimiter)
 	return &Client{
 		opts:       opts,
 		httpClient: httpClient,
@@ -103,29 +127,13 @@ func defaultDo(c *Client, req *http.Request) (*http.Response, error) {
 		req.Header.Set("APCA-API-SECRET-KEY", c.opts.APISecret)
 	}
 
-	var resp *http.Response
-	var err error
-
-RetryLoop:
-	for i := 0; ; i++ {
-		resp, err = c.httpClient.Do(req)
-		if err != nil {
-			return nil, err
-		}
-		switch resp.StatusCode {
-		case http.StatusTooManyRequests, http.StatusInternalServerError:
-		default:
-			break RetryLoop
-		}
-		if i >= c.opts.RetryLimit {
-			break
-		}
-		time.Sleep(c.opts.RetryDelay)
+	resp, err := alpaca.DoWithRetry(c.httpClient, req, c.opts.RetryPolicy)
+	if err != nil {
+		return nil, err
 	}
 
-	if resp.StatusCode >= http.StatusMultipleChoices {
-		defer resp.Body.Close()
-		return nil, alpaca.APIErrorFromResponse(resp)
+	if err = alpaca.Verify(resp); err != nil {
+		return nil, err
 	}
 
 	return resp, nil
@@ -237,7 +245,12 @@ type GetTradesPaginatedRequest struct {
 
 // GetTrades returns the trades for the given symbol.
 func (c *Client) GetTrades(symbol string, req GetTradesRequest) ([]Trade, error) {
-	resp, _, err := c.GetTradesPaginated(symbol, GetTradesPaginatedRequest{GetTradesRequest: req})
+	return c.GetTradesWithContext(context.Background(), symbol, req)
+}
+
+// GetTradesWithContext returns the trades for the given symbol.
+func (c *Client) GetTradesWithContext(ctx context.Context, symbol string, req GetTradesRequest) ([]Trade, error) {
+	resp, _, err := c.GetTradesPaginatedWithContext(ctx, symbol, GetTradesPaginatedRequest{GetTradesRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -246,7 +259,12 @@ func (c *Client) GetTrades(symbol string, req GetTradesRequest) ([]Trade, error)
 
 // GetTradesPaginated returns the trades for the given symbol, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetTradesPaginated(symbol string, req GetTradesPaginatedRequest) ([]Trade, string, error) {
-	resp, nextPageToken, err := c.GetMultiTradesPaginated([]string{symbol}, req)
+	return c.GetTradesPaginatedWithContext(context.Background(), symbol, req)
+}
+
+// GetTradesPaginatedWithContext returns the trades for the given symbol, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetTradesPaginatedWithContext(ctx context.Context, symbol string, req GetTradesPaginatedRequest) ([]Trade, string, error) {
+	resp, nextPageToken, err := c.GetMultiTradesPaginatedWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, nextPageToken, err
 	}
@@ -255,7 +273,12 @@ func (c *Client) GetTradesPaginated(symbol string, req GetTradesPaginatedRequest
 
 // GetMultiTrades returns trades for the given symbols.
 func (c *Client) GetMultiTrades(symbols []string, req GetTradesRequest) (map[string][]Trade, error) {
-	resp, _, err := c.GetMultiTradesPaginated(symbols, GetTradesPaginatedRequest{GetTradesRequest: req})
+	return c.GetMultiTradesWithContext(context.Background(), symbols, req)
+}
+
+// GetMultiTradesWithContext returns trades for the given symbols.
+func (c *Client) GetMultiTradesWithContext(ctx context.Context, symbols []string, req GetTradesRequest) (map[string][]Trade, error) {
+	resp, _, err := c.GetMultiTradesPaginatedWithContext(ctx, symbols, GetTradesPaginatedRequest{GetTradesRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -264,6 +287,11 @@ func (c *Client) GetMultiTrades(symbols []string, req GetTradesRequest) (map[str
 
 // GetMultiTradesPaginated returns trades for the given symbols, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetMultiTradesPaginated(symbols []string, req GetTradesPaginatedRequest) (map[string][]Trade, string, error) {
+	return c.GetMultiTradesPaginatedWithContext(context.Background(), symbols, req)
+}
+
+// GetMultiTradesPaginatedWithContext returns trades for the given symbols, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetMultiTradesPaginatedWithContext(ctx context.Context, symbols []string, req GetTradesPaginatedRequest) (map[string][]Trade, string, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/trades", c.opts.BaseURL, stockPrefix))
 	if err != nil {
 		return nil, "", err
@@ -287,10 +315,13 @@ func (c *Client) GetMultiTradesPaginated(symbols []string, req GetTradesPaginate
 	received := 0
 	nextPageToken := req.PageToken
 	for req.TotalLimit == 0 || received < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
 		setQueryLimit(q, req.TotalLimit, req.PageLimit, received, v2MaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return nil, "", err
 		}
@@ -344,7 +375,12 @@ type GetQuotesPaginatedRequest struct {
 
 // GetQuotes returns the quotes for the given symbol.
 func (c *Client) GetQuotes(symbol string, req GetQuotesRequest) ([]Quote, error) {
-	resp, _, err := c.GetQuotesPaginated(symbol, GetQuotesPaginatedRequest{GetQuotesRequest: req})
+	return c.GetQuotesWithContext(context.Background(), symbol, req)
+}
+
+// GetQuotesWithContext returns the quotes for the given symbol.
+func (c *Client) GetQuotesWithContext(ctx context.Context, symbol string, req GetQuotesRequest) ([]Quote, error) {
+	resp, _, err := c.GetQuotesPaginatedWithContext(ctx, symbol, GetQuotesPaginatedRequest{GetQuotesRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -353,7 +389,12 @@ func (c *Client) GetQuotes(symbol string, req GetQuotesRequest) ([]Quote, error)
 
 // GetQuotesPaginated returns quotes for the given symbol, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetQuotesPaginated(symbol string, req GetQuotesPaginatedRequest) ([]Quote, string, error) {
-	resp, nextPageToken, err := c.GetMultiQuotesPaginated([]string{symbol}, req)
+	return c.GetQuotesPaginatedWithContext(context.Background(), symbol, req)
+}
+
+// GetQuotesPaginatedWithContext returns quotes for the given symbol, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetQuotesPaginatedWithContext(ctx context.Context, symbol string, req GetQuotesPaginatedRequest) ([]Quote, string, error) {
+	resp, nextPageToken, err := c.GetMultiQuotesPaginatedWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, nextPageToken, err
 	}
@@ -362,7 +403,12 @@ func (c *Client) GetQuotesPaginated(symbol string, req GetQuotesPaginatedRequest
 
 // GetMultiQuotes returns quotes for the given symbols.
 func (c *Client) GetMultiQuotes(symbols []string, req GetQuotesRequest) (map[string][]Quote, error) {
-	resp, _, err := c.GetMultiQuotesPaginated(symbols, GetQuotesPaginatedRequest{GetQuotesRequest: req})
+	return c.GetMultiQuotesWithContext(context.Background(), symbols, req)
+}
+
+// GetMultiQuotesWithContext returns quotes for the given symbols.
+func (c *Client) GetMultiQuotesWithContext(ctx context.Context, symbols []string, req GetQuotesRequest) (map[string][]Quote, error) {
+	resp, _, err := c.GetMultiQuotesPaginatedWithContext(ctx, symbols, GetQuotesPaginatedRequest{GetQuotesRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -371,6 +417,11 @@ func (c *Client) GetMultiQuotes(symbols []string, req GetQuotesRequest) (map[str
 
 // GetMultiQuotesPaginated returns quotes for the given symbols, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetMultiQuotesPaginated(symbols []string, req GetQuotesPaginatedRequest) (map[string][]Quote, string, error) {
+	return c.GetMultiQuotesPaginatedWithContext(context.Background(), symbols, req)
+}
+
+// GetMultiQuotesPaginatedWithContext returns quotes for the given symbols, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetMultiQuotesPaginatedWithContext(ctx context.Context, symbols []string, req GetQuotesPaginatedRequest) (map[string][]Quote, string, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/quotes", c.opts.BaseURL, stockPrefix))
 	if err != nil {
 		return nil, "", err
@@ -394,10 +445,13 @@ func (c *Client) GetMultiQuotesPaginated(symbols []string, req GetQuotesPaginate
 	received := 0
 	nextPageToken := req.PageToken
 	for req.TotalLimit == 0 || received < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
 		setQueryLimit(q, req.TotalLimit, req.PageLimit, received, v2MaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return nil, "", err
 		}
@@ -485,7 +539,12 @@ func (c *Client) setQueryBarPaginatedRequest(q url.Values, symbols []string, req
 
 // GetBars returns a slice of bars for the given symbol.
 func (c *Client) GetBars(symbol string, req GetBarsRequest) ([]Bar, error) {
-	resp, _, err := c.GetBarsPaginated(symbol, GetBarsPaginatedRequest{GetBarsRequest: req})
+	return c.GetBarsWithContext(context.Background(), symbol, req)
+}
+
+// GetBarsWithContext returns a slice of bars for the given symbol.
+func (c *Client) GetBarsWithContext(ctx context.Context, symbol string, req GetBarsRequest) ([]Bar, error) {
+	resp, _, err := c.GetBarsPaginatedWithContext(ctx, symbol, GetBarsPaginatedRequest{GetBarsRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -494,7 +553,12 @@ func (c *Client) GetBars(symbol string, req GetBarsRequest) ([]Bar, error) {
 
 // GetBarsPaginated returns bars for the given symbol, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetBarsPaginated(symbol string, req GetBarsPaginatedRequest) ([]Bar, string, error) {
-	resp, nextPageToken, err := c.GetMultiBarsPaginated([]string{symbol}, req)
+	return c.GetBarsPaginatedWithContext(context.Background(), symbol, req)
+}
+
+// GetBarsPaginatedWithContext returns bars for the given symbol, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetBarsPaginatedWithContext(ctx context.Context, symbol string, req GetBarsPaginatedRequest) ([]Bar, string, error) {
+	resp, nextPageToken, err := c.GetMultiBarsPaginatedWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, nextPageToken, err
 	}
@@ -503,7 +567,12 @@ func (c *Client) GetBarsPaginated(symbol string, req GetBarsPaginatedRequest) ([
 
 // GetMultiBars returns bars for the given symbols.
 func (c *Client) GetMultiBars(symbols []string, req GetBarsRequest) (map[string][]Bar, error) {
-	resp, _, err := c.GetMultiBarsPaginated(symbols, GetBarsPaginatedRequest{GetBarsRequest: req})
+	return c.GetMultiBarsWithContext(context.Background(), symbols, req)
+}
+
+// GetMultiBarsWithContext returns bars for the given symbols.
+func (c *Client) GetMultiBarsWithContext(ctx context.Context, symbols []string, req GetBarsRequest) (map[string][]Bar, error) {
+	resp, _, err := c.GetMultiBarsPaginatedWithContext(ctx, symbols, GetBarsPaginatedRequest{GetBarsRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -512,6 +581,11 @@ func (c *Client) GetMultiBars(symbols []string, req GetBarsRequest) (map[string]
 
 // GetMultiBarsPaginated returns bars for the given symbols, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetMultiBarsPaginated(symbols []string, req GetBarsPaginatedRequest) (map[string][]Bar, string, error) {
+	return c.GetMultiBarsPaginatedWithContext(context.Background(), symbols, req)
+}
+
+// GetMultiBarsPaginatedWithContext returns bars for the given symbols, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetMultiBarsPaginatedWithContext(ctx context.Context, symbols []string, req GetBarsPaginatedRequest) (map[string][]Bar, string, error) {
 	bars := make(map[string][]Bar, len(symbols))
 
 	u, err := url.Parse(fmt.Sprintf("%s/%s/bars", c.opts.BaseURL, stockPrefix))
@@ -525,10 +599,13 @@ func (c *Client) GetMultiBarsPaginated(symbols []string, req GetBarsPaginatedReq
 	received := 0
 	nextPageToken := req.PageToken
 	for req.TotalLimit == 0 || received < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
 		setQueryLimit(q, req.TotalLimit, req.PageLimit, received, v2MaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return nil, "", err
 		}
@@ -580,7 +657,12 @@ type GetAuctionsPaginatedRequest struct {
 
 // GetAuctions returns the auctions for the given symbol.
 func (c *Client) GetAuctions(symbol string, req GetAuctionsRequest) ([]DailyAuctions, error) {
-	resp, _, err := c.GetAuctionsPaginated(symbol, GetAuctionsPaginatedRequest{GetAuctionsRequest: req})
+	return c.GetAuctionsWithContext(context.Background(), symbol, req)
+}
+
+// GetAuctionsWithContext returns the auctions for the given symbol.
+func (c *Client) GetAuctionsWithContext(ctx context.Context, symbol string, req GetAuctionsRequest) ([]DailyAuctions, error) {
+	resp, _, err := c.GetAuctionsPaginatedWithContext(ctx, symbol, GetAuctionsPaginatedRequest{GetAuctionsRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -589,7 +671,12 @@ func (c *Client) GetAuctions(symbol string, req GetAuctionsRequest) ([]DailyAuct
 
 // GetAuctionsPaginated returns auctions for the given symbol, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetAuctionsPaginated(symbol string, req GetAuctionsPaginatedRequest) ([]DailyAuctions, string, error) {
-	resp, nextPageToken, err := c.GetMultiAuctionsPaginated([]string{symbol}, req)
+	return c.GetAuctionsPaginatedWithContext(context.Background(), symbol, req)
+}
+
+// GetAuctionsPaginatedWithContext returns auctions for the given symbol, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetAuctionsPaginatedWithContext(ctx context.Context, symbol string, req GetAuctionsPaginatedRequest) ([]DailyAuctions, string, error) {
+	resp, nextPageToken, err := c.GetMultiAuctionsPaginatedWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, nextPageToken, err
 	}
@@ -600,7 +687,14 @@ func (c *Client) GetAuctionsPaginated(symbol string, req GetAuctionsPaginatedReq
 func (c *Client) GetMultiAuctions(
 	symbols []string, req GetAuctionsRequest,
 ) (map[string][]DailyAuctions, error) {
-	resp, _, err := c.GetMultiAuctionsPaginated(symbols, GetAuctionsPaginatedRequest{GetAuctionsRequest: req})
+	return c.GetMultiAuctionsWithContext(context.Background(), symbols, req)
+}
+
+// GetMultiAuctionsWithContext returns auctions for the given symbols.
+func (c *Client) GetMultiAuctionsWithContext(
+	ctx context.Context, symbols []string, req GetAuctionsRequest,
+) (map[string][]DailyAuctions, error) {
+	resp, _, err := c.GetMultiAuctionsPaginatedWithContext(ctx, symbols, GetAuctionsPaginatedRequest{GetAuctionsRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -610,6 +704,13 @@ func (c *Client) GetMultiAuctions(
 // GetMultiAuctionsPaginated returns auctions for the given symbols, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetMultiAuctionsPaginated(
 	symbols []string, req GetAuctionsPaginatedRequest,
+) (map[string][]DailyAuctions, string, error) {
+	return c.GetMultiAuctionsPaginatedWithContext(context.Background(), symbols, req)
+}
+
+// GetMultiAuctionsPaginatedWithContext returns auctions for the given symbols, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetMultiAuctionsPaginatedWithContext(
+	ctx context.Context, symbols []string, req GetAuctionsPaginatedRequest,
 ) (map[string][]DailyAuctions, string, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/auctions", c.opts.BaseURL, stockPrefix))
 	if err != nil {
@@ -634,10 +735,13 @@ func (c *Client) GetMultiAuctionsPaginated(
 	received := 0
 	nextPageToken := req.PageToken
 	for req.TotalLimit == 0 || received < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
 		setQueryLimit(q, req.TotalLimit, req.PageLimit, received, v2MaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return nil, "", err
 		}
@@ -693,7 +797,12 @@ type GetLatestBarRequest struct {
 
 // GetLatestBar returns the latest minute bar for a given symbol
 func (c *Client) GetLatestBar(symbol string, req GetLatestBarRequest) (*Bar, error) {
-	resp, err := c.GetLatestBars([]string{symbol}, req)
+	return c.GetLatestBarWithContext(context.Background(), symbol, req)
+}
+
+// GetLatestBarWithContext returns the latest minute bar for a given symbol
+func (c *Client) GetLatestBarWithContext(ctx context.Context, symbol string, req GetLatestBarRequest) (*Bar, error) {
+	resp, err := c.GetLatestBarsWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -706,6 +815,11 @@ func (c *Client) GetLatestBar(symbol string, req GetLatestBarRequest) (*Bar, err
 
 // GetLatestBars returns the latest minute bars for the given symbols
 func (c *Client) GetLatestBars(symbols []string, req GetLatestBarRequest) (map[string]Bar, error) {
+	return c.GetLatestBarsWithContext(context.Background(), symbols, req)
+}
+
+// GetLatestBarsWithContext returns the latest minute bars for the given symbols
+func (c *Client) GetLatestBarsWithContext(ctx context.Context, symbols []string, req GetLatestBarRequest) (map[string]Bar, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/bars/latest", c.opts.BaseURL, stockPrefix))
 	if err != nil {
 		return nil, err
@@ -716,7 +830,7 @@ func (c *Client) GetLatestBars(symbols []string, req GetLatestBarRequest) (map[s
 		Currency: req.Currency,
 	})
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -735,7 +849,12 @@ type GetLatestTradeRequest struct {
 
 // GetLatestTrade returns the latest trade for a given symbol
 func (c *Client) GetLatestTrade(symbol string, req GetLatestTradeRequest) (*Trade, error) {
-	resp, err := c.GetLatestTrades([]string{symbol}, req)
+	return c.GetLatestTradeWithContext(context.Background(), symbol, req)
+}
+
+// GetLatestTradeWithContext returns the latest trade for a given symbol
+func (c *Client) GetLatestTradeWithContext(ctx context.Context, symbol string, req GetLatestTradeRequest) (*Trade, error) {
+	resp, err := c.GetLatestTradesWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -748,6 +867,11 @@ func (c *Client) GetLatestTrade(symbol string, req GetLatestTradeRequest) (*Trad
 
 // GetLatestTrades returns the latest trades for the given symbols
 func (c *Client) GetLatestTrades(symbols []string, req GetLatestTradeRequest) (map[string]Trade, error) {
+	return c.GetLatestTradesWithContext(context.Background(), symbols, req)
+}
+
+// GetLatestTradesWithContext returns the latest trades for the given symbols
+func (c *Client) GetLatestTradesWithContext(ctx context.Context, symbols []string, req GetLatestTradeRequest) (map[string]Trade, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/trades/latest", c.opts.BaseURL, stockPrefix))
 	if err != nil {
 		return nil, err
@@ -758,7 +882,7 @@ func (c *Client) GetLatestTrades(symbols []string, req GetLatestTradeRequest) (m
 		Currency: req.Currency,
 	})
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -777,7 +901,12 @@ type GetLatestQuoteRequest struct {
 
 // GetLatestQuote returns the latest quote for a given symbol
 func (c *Client) GetLatestQuote(symbol string, req GetLatestQuoteRequest) (*Quote, error) {
-	resp, err := c.GetLatestQuotes([]string{symbol}, req)
+	return c.GetLatestQuoteWithContext(context.Background(), symbol, req)
+}
+
+// GetLatestQuoteWithContext returns the latest quote for a given symbol
+func (c *Client) GetLatestQuoteWithContext(ctx context.Context, symbol string, req GetLatestQuoteRequest) (*Quote, error) {
+	resp, err := c.GetLatestQuotesWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -790,6 +919,11 @@ func (c *Client) GetLatestQuote(symbol string, req GetLatestQuoteRequest) (*Quot
 
 // GetLatestQuotes returns the latest quotes for the given symbols
 func (c *Client) GetLatestQuotes(symbols []string, req GetLatestQuoteRequest) (map[string]Quote, error) {
+	return c.GetLatestQuotesWithContext(context.Background(), symbols, req)
+}
+
+// GetLatestQuotesWithContext returns the latest quotes for the given symbols
+func (c *Client) GetLatestQuotesWithContext(ctx context.Context, symbols []string, req GetLatestQuoteRequest) (map[string]Quote, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/quotes/latest", c.opts.BaseURL, stockPrefix))
 	if err != nil {
 		return nil, err
@@ -800,7 +934,7 @@ func (c *Client) GetLatestQuotes(symbols []string, req GetLatestQuoteRequest) (m
 		Currency: req.Currency,
 	})
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -819,7 +953,12 @@ type GetSnapshotRequest struct {
 
 // GetSnapshot returns the snapshot for a given symbol
 func (c *Client) GetSnapshot(symbol string, req GetSnapshotRequest) (*Snapshot, error) {
-	resp, err := c.GetSnapshots([]string{symbol}, req)
+	return c.GetSnapshotWithContext(context.Background(), symbol, req)
+}
+
+// GetSnapshotWithContext returns the snapshot for a given symbol
+func (c *Client) GetSnapshotWithContext(ctx context.Context, symbol string, req GetSnapshotRequest) (*Snapshot, error) {
+	resp, err := c.GetSnapshotsWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -828,6 +967,11 @@ func (c *Client) GetSnapshot(symbol string, req GetSnapshotRequest) (*Snapshot,
 
 // GetSnapshots returns the snapshots for multiple symbol
 func (c *Client) GetSnapshots(symbols []string, req GetSnapshotRequest) (map[string]*Snapshot, error) {
+	return c.GetSnapshotsWithContext(context.Background(), symbols, req)
+}
+
+// GetSnapshotsWithContext returns the snapshots for multiple symbol
+func (c *Client) GetSnapshotsWithContext(ctx context.Context, symbols []string, req GetSnapshotRequest) (map[string]*Snapshot, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/snapshots", c.opts.BaseURL, stockPrefix))
 	if err != nil {
 		return nil, err
@@ -838,7 +982,7 @@ func (c *Client) GetSnapshots(symbols []string, req GetSnapshotRequest) (map[str
 		Currency: req.Currency,
 	})
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -905,7 +1049,12 @@ type GetCryptoTradesPaginatedRequest struct {
 
 // GetCryptoTrades returns the trades for the given crypto symbol.
 func (c *Client) GetCryptoTrades(symbol string, req GetCryptoTradesRequest) ([]CryptoTrade, error) {
-	resp, _, err := c.GetCryptoTradesPaginated(symbol, GetCryptoTradesPaginatedRequest{GetCryptoTradesRequest: req})
+	return c.GetCryptoTradesWithContext(context.Background(), symbol, req)
+}
+
+// GetCryptoTradesWithContext returns the trades for the given crypto symbol.
+func (c *Client) GetCryptoTradesWithContext(ctx context.Context, symbol string, req GetCryptoTradesRequest) ([]CryptoTrade, error) {
+	resp, _, err := c.GetCryptoTradesPaginatedWithContext(ctx, symbol, GetCryptoTradesPaginatedRequest{GetCryptoTradesRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -914,7 +1063,12 @@ func (c *Client) GetCryptoTrades(symbol string, req GetCryptoTradesRequest) ([]C
 
 // GetCryptoTradesPaginated returns trades for the given crypto symbol, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetCryptoTradesPaginated(symbol string, req GetCryptoTradesPaginatedRequest) ([]CryptoTrade, string, error) {
-	resp, nextPageToken, err := c.GetCryptoMultiTradesPaginated([]string{symbol}, req)
+	return c.GetCryptoTradesPaginatedWithContext(context.Background(), symbol, req)
+}
+
+// GetCryptoTradesPaginatedWithContext returns trades for the given crypto symbol, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetCryptoTradesPaginatedWithContext(ctx context.Context, symbol string, req GetCryptoTradesPaginatedRequest) ([]CryptoTrade, string, error) {
+	resp, nextPageToken, err := c.GetCryptoMultiTradesPaginatedWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, nextPageToken, err
 	}
@@ -923,7 +1077,12 @@ func (c *Client) GetCryptoTradesPaginated(symbol string, req GetCryptoTradesPagi
 
 // GetMultiTrades returns trades for the given crypto symbols.
 func (c *Client) GetCryptoMultiTrades(symbols []string, req GetCryptoTradesRequest) (map[string][]CryptoTrade, error) {
-	resp, _, err := c.GetCryptoMultiTradesPaginated(symbols, GetCryptoTradesPaginatedRequest{GetCryptoTradesRequest: req})
+	return c.GetCryptoMultiTradesWithContext(context.Background(), symbols, req)
+}
+
+// GetMultiTrades returns trades for the given crypto symbols.
+func (c *Client) GetCryptoMultiTradesWithContext(ctx context.Context, symbols []string, req GetCryptoTradesRequest) (map[string][]CryptoTrade, error) {
+	resp, _, err := c.GetCryptoMultiTradesPaginatedWithContext(ctx, symbols, GetCryptoTradesPaginatedRequest{GetCryptoTradesRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -932,6 +1091,11 @@ func (c *Client) GetCryptoMultiTrades(symbols []string, req GetCryptoTradesReque
 
 // GetCryptoMultiTradesPaginated returns trades for the given crypto symbols, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetCryptoMultiTradesPaginated(symbols []string, req GetCryptoTradesPaginatedRequest) (map[string][]CryptoTrade, string, error) {
+	return c.GetCryptoMultiTradesPaginatedWithContext(context.Background(), symbols, req)
+}
+
+// GetCryptoMultiTradesPaginatedWithContext returns trades for the given crypto symbols, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetCryptoMultiTradesPaginatedWithContext(ctx context.Context, symbols []string, req GetCryptoTradesPaginatedRequest) (map[string][]CryptoTrade, string, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/%s/trades", c.opts.BaseURL, cryptoPrefix, c.cryptoFeed(req.CryptoFeed)))
 	if err != nil {
 		return nil, "", err
@@ -949,10 +1113,13 @@ func (c *Client) GetCryptoMultiTradesPaginated(symbols []string, req GetCryptoTr
 	received := 0
 	nextPageToken := req.PageToken
 	for req.TotalLimit == 0 || received < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
 		setQueryLimit(q, req.TotalLimit, req.PageLimit, received, v2MaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return nil, "", err
 		}
@@ -995,7 +1162,12 @@ type GetCryptoQuotesRequest struct {
 
 // GetCryptoQuotes returns the trades for the given crypto symbol.
 func (c *Client) GetCryptoQuotes(symbol string, req GetCryptoQuotesRequest) ([]CryptoQuote, error) {
-	resp, err := c.GetCryptoMultiQuotes([]string{symbol}, req)
+	return c.GetCryptoQuotesWithContext(context.Background(), symbol, req)
+}
+
+// GetCryptoQuotesWithContext returns the trades for the given crypto symbol.
+func (c *Client) GetCryptoQuotesWithContext(ctx context.Context, symbol string, req GetCryptoQuotesRequest) ([]CryptoQuote, error) {
+	resp, err := c.GetCryptoMultiQuotesWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -1004,6 +1176,11 @@ func (c *Client) GetCryptoQuotes(symbol string, req GetCryptoQuotesRequest) ([]C
 
 // GetMultiQuotes returns quotes for the given crypto symbols.
 func (c *Client) GetCryptoMultiQuotes(symbols []string, req GetCryptoQuotesRequest) (map[string][]CryptoQuote, error) {
+	return c.GetCryptoMultiQuotesWithContext(context.Background(), symbols, req)
+}
+
+// GetMultiQuotes returns quotes for the given crypto symbols.
+func (c *Client) GetCryptoMultiQuotesWithContext(ctx context.Context, symbols []string, req GetCryptoQuotesRequest) (map[string][]CryptoQuote, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/%s/quotes", c.opts.BaseURL, cryptoPrefix, c.cryptoFeed(req.CryptoFeed)))
 	if err != nil {
 		return nil, err
@@ -1020,10 +1197,13 @@ func (c *Client) GetCryptoMultiQuotes(symbols []string, req GetCryptoQuotesReque
 	quotes := make(map[string][]CryptoQuote, len(symbols))
 	received := 0
 	for req.TotalLimit == 0 || received < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		setQueryLimit(q, req.TotalLimit, req.PageLimit, received, v2MaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return nil, err
 		}
@@ -1094,7 +1274,12 @@ func setQueryCryptoBarPaginatedRequest(q url.Values, symbols []string, req GetCr
 
 // GetCryptoBars returns a slice of bars for the given crypto symbol.
 func (c *Client) GetCryptoBars(symbol string, req GetCryptoBarsRequest) ([]CryptoBar, error) {
-	resp, _, err := c.GetCryptoBarsPaginated(symbol, GetCryptoBarsPaginatedRequest{GetCryptoBarsRequest: req})
+	return c.GetCryptoBarsWithContext(context.Background(), symbol, req)
+}
+
+// GetCryptoBarsWithContext returns a slice of bars for the given crypto symbol.
+func (c *Client) GetCryptoBarsWithContext(ctx context.Context, symbol string, req GetCryptoBarsRequest) ([]CryptoBar, error) {
+	resp, _, err := c.GetCryptoBarsPaginatedWithContext(ctx, symbol, GetCryptoBarsPaginatedRequest{GetCryptoBarsRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -1103,7 +1288,12 @@ func (c *Client) GetCryptoBars(symbol string, req GetCryptoBarsRequest) ([]Crypt
 
 // GetCryptoBarsPaginated returns bars for the given crypto symbol, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetCryptoBarsPaginated(symbol string, req GetCryptoBarsPaginatedRequest) ([]CryptoBar, string, error) {
-	resp, nextPageToken, err := c.GetCryptoMultiBarsPaginated([]string{symbol}, req)
+	return c.GetCryptoBarsPaginatedWithContext(context.Background(), symbol, req)
+}
+
+// GetCryptoBarsPaginatedWithContext returns bars for the given crypto symbol, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetCryptoBarsPaginatedWithContext(ctx context.Context, symbol string, req GetCryptoBarsPaginatedRequest) ([]CryptoBar, string, error) {
+	resp, nextPageToken, err := c.GetCryptoMultiBarsPaginatedWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, nextPageToken, err
 	}
@@ -1112,7 +1302,12 @@ func (c *Client) GetCryptoBarsPaginated(symbol string, req GetCryptoBarsPaginate
 
 // GetCryptoMultiBars returns bars for the given crypto symbols.
 func (c *Client) GetCryptoMultiBars(symbols []string, req GetCryptoBarsRequest) (map[string][]CryptoBar, error) {
-	resp, _, err := c.GetCryptoMultiBarsPaginated(symbols, GetCryptoBarsPaginatedRequest{GetCryptoBarsRequest: req})
+	return c.GetCryptoMultiBarsWithContext(context.Background(), symbols, req)
+}
+
+// GetCryptoMultiBarsWithContext returns bars for the given crypto symbols.
+func (c *Client) GetCryptoMultiBarsWithContext(ctx context.Context, symbols []string, req GetCryptoBarsRequest) (map[string][]CryptoBar, error) {
+	resp, _, err := c.GetCryptoMultiBarsPaginatedWithContext(ctx, symbols, GetCryptoBarsPaginatedRequest{GetCryptoBarsRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -1121,6 +1316,11 @@ func (c *Client) GetCryptoMultiBars(symbols []string, req GetCryptoBarsRequest)
 
 // GetCryptoMultiBarsPaginated returns bars for the given crypto symbols, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetCryptoMultiBarsPaginated(symbols []string, req GetCryptoBarsPaginatedRequest) (map[string][]CryptoBar, string, error) {
+	return c.GetCryptoMultiBarsPaginatedWithContext(context.Background(), symbols, req)
+}
+
+// GetCryptoMultiBarsPaginatedWithContext returns bars for the given crypto symbols, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetCryptoMultiBarsPaginatedWithContext(ctx context.Context, symbols []string, req GetCryptoBarsPaginatedRequest) (map[string][]CryptoBar, string, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/%s/bars",
 		c.opts.BaseURL, cryptoPrefix, c.cryptoFeed(req.CryptoFeed)))
 	if err != nil {
@@ -1134,10 +1334,13 @@ func (c *Client) GetCryptoMultiBarsPaginated(symbols []string, req GetCryptoBars
 	received := 0
 	nextPageToken := req.PageToken
 	for req.TotalLimit == 0 || received < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
 		setQueryLimit(q, req.TotalLimit, req.PageLimit, received, v2MaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return nil, "", err
 		}
@@ -1187,7 +1390,12 @@ func (c *Client) cryptoFeed(fromReq string) string {
 
 // GetLatestCryptoBar returns the latest bar for a given crypto symbol
 func (c *Client) GetLatestCryptoBar(symbol string, req GetLatestCryptoBarRequest) (*CryptoBar, error) {
-	resp, err := c.GetLatestCryptoBars([]string{symbol}, req)
+	return c.GetLatestCryptoBarWithContext(context.Background(), symbol, req)
+}
+
+// GetLatestCryptoBarWithContext returns the latest bar for a given crypto symbol
+func (c *Client) GetLatestCryptoBarWithContext(ctx context.Context, symbol string, req GetLatestCryptoBarRequest) (*CryptoBar, error) {
+	resp, err := c.GetLatestCryptoBarsWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -1200,6 +1408,11 @@ func (c *Client) GetLatestCryptoBar(symbol string, req GetLatestCryptoBarRequest
 
 // GetLatestCryptoBars returns the latest bars for the given crypto symbols
 func (c *Client) GetLatestCryptoBars(symbols []string, req GetLatestCryptoBarRequest) (map[string]CryptoBar, error) {
+	return c.GetLatestCryptoBarsWithContext(context.Background(), symbols, req)
+}
+
+// GetLatestCryptoBarsWithContext returns the latest bars for the given crypto symbols
+func (c *Client) GetLatestCryptoBarsWithContext(ctx context.Context, symbols []string, req GetLatestCryptoBarRequest) (map[string]CryptoBar, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/%s/latest/bars",
 		c.opts.BaseURL, cryptoPrefix, c.cryptoFeed(req.CryptoFeed)))
 	if err != nil {
@@ -1209,7 +1422,7 @@ func (c *Client) GetLatestCryptoBars(symbols []string, req GetLatestCryptoBarReq
 		Symbols: symbols,
 	})
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -1227,7 +1440,12 @@ type GetLatestCryptoTradeRequest struct {
 
 // GetLatestCryptoTrade returns the latest trade for a given crypto symbol
 func (c *Client) GetLatestCryptoTrade(symbol string, req GetLatestCryptoTradeRequest) (*CryptoTrade, error) {
-	resp, err := c.GetLatestCryptoTrades([]string{symbol}, req)
+	return c.GetLatestCryptoTradeWithContext(context.Background(), symbol, req)
+}
+
+// GetLatestCryptoTradeWithContext returns the latest trade for a given crypto symbol
+func (c *Client) GetLatestCryptoTradeWithContext(ctx context.Context, symbol string, req GetLatestCryptoTradeRequest) (*CryptoTrade, error) {
+	resp, err := c.GetLatestCryptoTradesWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -1240,6 +1458,11 @@ func (c *Client) GetLatestCryptoTrade(symbol string, req GetLatestCryptoTradeReq
 
 // GetLatestCryptoTrades returns the latest trades for the given crypto symbols
 func (c *Client) GetLatestCryptoTrades(symbols []string, req GetLatestCryptoTradeRequest) (map[string]CryptoTrade, error) {
+	return c.GetLatestCryptoTradesWithContext(context.Background(), symbols, req)
+}
+
+// GetLatestCryptoTradesWithContext returns the latest trades for the given crypto symbols
+func (c *Client) GetLatestCryptoTradesWithContext(ctx context.Context, symbols []string, req GetLatestCryptoTradeRequest) (map[string]CryptoTrade, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/%s/latest/trades",
 		c.opts.BaseURL, cryptoPrefix, c.cryptoFeed(req.CryptoFeed)))
 	if err != nil {
@@ -1249,7 +1472,7 @@ func (c *Client) GetLatestCryptoTrades(symbols []string, req GetLatestCryptoTrad
 		Symbols: symbols,
 	})
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -1267,7 +1490,12 @@ type GetLatestCryptoQuoteRequest struct {
 
 // GetLatestCryptoQuote returns the latest quote for a given crypto symbol
 func (c *Client) GetLatestCryptoQuote(symbol string, req GetLatestCryptoQuoteRequest) (*CryptoQuote, error) {
-	resp, err := c.GetLatestCryptoQuotes([]string{symbol}, req)
+	return c.GetLatestCryptoQuoteWithContext(context.Background(), symbol, req)
+}
+
+// GetLatestCryptoQuoteWithContext returns the latest quote for a given crypto symbol
+func (c *Client) GetLatestCryptoQuoteWithContext(ctx context.Context, symbol string, req GetLatestCryptoQuoteRequest) (*CryptoQuote, error) {
+	resp, err := c.GetLatestCryptoQuotesWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -1280,6 +1508,11 @@ func (c *Client) GetLatestCryptoQuote(symbol string, req GetLatestCryptoQuoteReq
 
 // GetLatestCryptoQuotes returns the latest quotes for the given crypto symbols
 func (c *Client) GetLatestCryptoQuotes(symbols []string, req GetLatestCryptoQuoteRequest) (map[string]CryptoQuote, error) {
+	return c.GetLatestCryptoQuotesWithContext(context.Background(), symbols, req)
+}
+
+// GetLatestCryptoQuotesWithContext returns the latest quotes for the given crypto symbols
+func (c *Client) GetLatestCryptoQuotesWithContext(ctx context.Context, symbols []string, req GetLatestCryptoQuoteRequest) (map[string]CryptoQuote, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/%s/latest/quotes",
 		c.opts.BaseURL, cryptoPrefix, c.cryptoFeed(req.CryptoFeed)))
 	if err != nil {
@@ -1289,7 +1522,7 @@ func (c *Client) GetLatestCryptoQuotes(symbols []string, req GetLatestCryptoQuot
 		Symbols: symbols,
 	})
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -1307,7 +1540,12 @@ type GetCryptoSnapshotRequest struct {
 
 // GetCryptoSnapshot returns the snapshot for a given crypto symbol
 func (c *Client) GetCryptoSnapshot(symbol string, req GetCryptoSnapshotRequest) (*CryptoSnapshot, error) {
-	resp, err := c.GetCryptoSnapshots([]string{symbol}, req)
+	return c.GetCryptoSnapshotWithContext(context.Background(), symbol, req)
+}
+
+// GetCryptoSnapshotWithContext returns the snapshot for a given crypto symbol
+func (c *Client) GetCryptoSnapshotWithContext(ctx context.Context, symbol string, req GetCryptoSnapshotRequest) (*CryptoSnapshot, error) {
+	resp, err := c.GetCryptoSnapshotsWithContext(ctx, []string{symbol}, req)
 	if err != nil {
 		return nil, err
 	}
@@ -1320,6 +1558,11 @@ func (c *Client) GetCryptoSnapshot(symbol string, req GetCryptoSnapshotRequest)
 
 // GetCryptoSnapshots returns the snapshots for the given crypto symbols
 func (c *Client) GetCryptoSnapshots(symbols []string, req GetCryptoSnapshotRequest) (map[string]CryptoSnapshot, error) {
+	return c.GetCryptoSnapshotsWithContext(context.Background(), symbols, req)
+}
+
+// GetCryptoSnapshotsWithContext returns the snapshots for the given crypto symbols
+func (c *Client) GetCryptoSnapshotsWithContext(ctx context.Context, symbols []string, req GetCryptoSnapshotRequest) (map[string]CryptoSnapshot, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/%s/%s/snapshots",
 		c.opts.BaseURL, cryptoPrefix, c.cryptoFeed(req.CryptoFeed)))
 	if err != nil {
@@ -1329,7 +1572,7 @@ func (c *Client) GetCryptoSnapshots(symbols []string, req GetCryptoSnapshotReque
 		Symbols: symbols,
 	})
 
-	resp, err := c.get(u)
+	resp, err := c.get(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -1422,7 +1665,12 @@ func (c *Client) setNewsPaginatedQuery(q url.Values, p GetNewsPaginatedRequest)
 
 // GetNews returns the news articles based on the given req.
 func (c *Client) GetNews(req GetNewsRequest) ([]News, error) {
-	news, _, err := c.GetNewsPaginated(GetNewsPaginatedRequest{GetNewsRequest: req})
+	return c.GetNewsWithContext(context.Background(), req)
+}
+
+// GetNewsWithContext returns the news articles based on the given req.
+func (c *Client) GetNewsWithContext(ctx context.Context, req GetNewsRequest) ([]News, error) {
+	news, _, err := c.GetNewsPaginatedWithContext(ctx, GetNewsPaginatedRequest{GetNewsRequest: req})
 	if err != nil {
 		return nil, err
 	}
@@ -1431,6 +1679,11 @@ func (c *Client) GetNews(req GetNewsRequest) ([]News, error) {
 
 // GetNewsPaginated returns the news articles based on the given req, and returns the nextPageToken to allow for manual pagination.
 func (c *Client) GetNewsPaginated(req GetNewsPaginatedRequest) ([]News, string, error) {
+	return c.GetNewsPaginatedWithContext(context.Background(), req)
+}
+
+// GetNewsPaginatedWithContext returns the news articles based on the given req, and returns the nextPageToken to allow for manual pagination.
+func (c *Client) GetNewsPaginatedWithContext(ctx context.Context, req GetNewsPaginatedRequest) ([]News, string, error) {
 	if req.TotalLimit < 0 {
 		return nil, "", fmt.Errorf("negative total limit")
 	}
@@ -1456,10 +1709,13 @@ func (c *Client) GetNewsPaginated(req GetNewsPaginatedRequest) ([]News, string,
 	nextPageToken := req.PageToken
 	news := make([]News, 0, totalLimit)
 	for totalLimit == 0 || received < totalLimit {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
 		setQueryLimit(q, totalLimit, req.PageLimit, received, newsMaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return nil, "", fmt.Errorf("failed to get news: %w", err)
 		}
@@ -1516,6 +1772,11 @@ type GetCorporateActionsRequest struct {
 
 // GetCorporateActions returns the corporate actions based on the given req.
 func (c *Client) GetCorporateActions(req GetCorporateActionsRequest) (CorporateActions, error) {
+	return c.GetCorporateActionsWithContext(context.Background(), req)
+}
+
+// GetCorporateActionsWithContext returns the corporate actions based on the given req.
+func (c *Client) GetCorporateActionsWithContext(ctx context.Context, req GetCorporateActionsRequest) (CorporateActions, error) {
 	u, err := url.Parse(fmt.Sprintf("%s/v1beta1/corporate-actions", c.opts.BaseURL))
 	if err != nil {
 		return CorporateActions{}, err
@@ -1541,10 +1802,13 @@ func (c *Client) GetCorporateActions(req GetCorporateActionsRequest) (CorporateA
 	cas := CorporateActions{}
 	received := 0
 	for req.TotalLimit == 0 || received < req.TotalLimit {
+		if err := ctx.Err(); err != nil {
+			return cas, err
+		}
 		setQueryLimit(q, req.TotalLimit, req.PageLimit, received, v2MaxLimit)
 		u.RawQuery = q.Encode()
 
-		resp, err := c.get(u)
+		resp, err := c.get(ctx, u)
 		if err != nil {
 			return cas, err
 		}
@@ -1585,163 +1849,323 @@ func GetTrades(symbol string, req GetTradesRequest) ([]Trade, error) {
 	return DefaultClient.GetTrades(symbol, req)
 }
 
+// GetTradesWithContext returns the trades for the given symbol.
+func GetTradesWithContext(ctx context.Context, symbol string, req GetTradesRequest) ([]Trade, error) {
+	return DefaultClient.GetTradesWithContext(ctx, symbol, req)
+}
+
 // GetMultiTrades returns the trades for the given symbols.
 func GetMultiTrades(symbols []string, req GetTradesRequest) (map[string][]Trade, error) {
 	return DefaultClient.GetMultiTrades(symbols, req)
 }
 
+// GetMultiTradesWithContext returns the trades for the given symbols.
+func GetMultiTradesWithContext(ctx context.Context, symbols []string, req GetTradesRequest) (map[string][]Trade, error) {
+	return DefaultClient.GetMultiTradesWithContext(ctx, symbols, req)
+}
+
 // GetQuotes returns the quotes for the given symbol.
 func GetQuotes(symbol string, req GetQuotesRequest) ([]Quote, error) {
 	return DefaultClient.GetQuotes(symbol, req)
 }
 
+// GetQuotesWithContext returns the quotes for the given symbol.
+func GetQuotesWithContext(ctx context.Context, symbol string, req GetQuotesRequest) ([]Quote, error) {
+	return DefaultClient.GetQuotesWithContext(ctx, symbol, req)
+}
+
 // GetMultiQuotes returns the quotes for the given symbols.
 func GetMultiQuotes(symbols []string, req GetQuotesRequest) (map[string][]Quote, error) {
 	return DefaultClient.GetMultiQuotes(symbols, req)
 }
 
+// GetMultiQuotesWithContext returns the quotes for the given symbols.
+func GetMultiQuotesWithContext(ctx context.Context, symbols []string, req GetQuotesRequest) (map[string][]Quote, error) {
+	return DefaultClient.GetMultiQuotesWithContext(ctx, symbols, req)
+}
+
 // GetBars returns the bars for the given symbol.
 func GetBars(symbol string, req GetBarsRequest) ([]Bar, error) {
 	return DefaultClient.GetBars(symbol, req)
 }
 
+// GetBarsWithContext returns the bars for the given symbol.
+func GetBarsWithContext(ctx context.Context, symbol string, req GetBarsRequest) ([]Bar, error) {
+	return DefaultClient.GetBarsWithContext(ctx, symbol, req)
+}
+
 // GetMultiBars returns the bars for the given symbols.
 func GetMultiBars(symbols []string, req GetBarsRequest) (map[string][]Bar, error) {
 	return DefaultClient.GetMultiBars(symbols, req)
 }
 
+// GetMultiBarsWithContext returns the bars for the given symbols.
+func GetMultiBarsWithContext(ctx context.Context, symbols []string, req GetBarsRequest) (map[string][]Bar, error) {
+	return DefaultClient.GetMultiBarsWithContext(ctx, symbols, req)
+}
+
 // GetAuctions returns the auctions for the given symbol.
 func GetAuctions(symbol string, req GetAuctionsRequest) ([]DailyAuctions, error) {
 	return DefaultClient.GetAuctions(symbol, req)
 }
 
+// GetAuctionsWithContext returns the auctions for the given symbol.
+func GetAuctionsWithContext(ctx context.Context, symbol string, req GetAuctionsRequest) ([]DailyAuctions, error) {
+	return DefaultClient.GetAuctionsWithContext(ctx, symbol, req)
+}
+
 // GetMultiAuctions returns the auctions for the given symbols.
 func GetMultiAuctions(symbols []string, req GetAuctionsRequest) (map[string][]DailyAuctions, error) {
 	return DefaultClient.GetMultiAuctions(symbols, req)
 }
 
+// GetMultiAuctionsWithContext returns the auctions for the given symbols.
+func GetMultiAuctionsWithContext(ctx context.Context, symbols []string, req GetAuctionsRequest) (map[string][]DailyAuctions, error) {
+	return DefaultClient.GetMultiAuctionsWithContext(ctx, symbols, req)
+}
+
 // GetLatestBar returns the latest minute bar for a given symbol.
 func GetLatestBar(symbol string, req GetLatestBarRequest) (*Bar, error) {
 	return DefaultClient.GetLatestBar(symbol, req)
 }
 
+// GetLatestBarWithContext returns the latest minute bar for a given symbol.
+func GetLatestBarWithContext(ctx context.Context, symbol string, req GetLatestBarRequest) (*Bar, error) {
+	return DefaultClient.GetLatestBarWithContext(ctx, symbol, req)
+}
+
 // GetLatestBars returns the latest minute bars for the given symbols.
 func GetLatestBars(symbols []string, req GetLatestBarRequest) (map[string]Bar, error) {
 	return DefaultClient.GetLatestBars(symbols, req)
 }
 
+// GetLatestBarsWithContext returns the latest minute bars for the given symbols.
+func GetLatestBarsWithContext(ctx context.Context, symbols []string, req GetLatestBarRequest) (map[string]Bar, error) {
+	return DefaultClient.GetLatestBarsWithContext(ctx, symbols, req)
+}
+
 // GetLatestTrade returns the latest trade for a given symbol.
 func GetLatestTrade(symbol string, req GetLatestTradeRequest) (*Trade, error) {
 	return DefaultClient.GetLatestTrade(symbol, req)
 }
 
+// GetLatestTradeWithContext returns the latest trade for a given symbol.
+func GetLatestTradeWithContext(ctx context.Context, symbol string, req GetLatestTradeRequest) (*Trade, error) {
+	return DefaultClient.GetLatestTradeWithContext(ctx, symbol, req)
+}
+
 // GetLatestTrades returns the latest trades for the given symbols.
 func GetLatestTrades(symbols []string, req GetLatestTradeRequest) (map[string]Trade, error) {
 	return DefaultClient.GetLatestTrades(symbols, req)
 }
 
+// GetLatestTradesWithContext returns the latest trades for the given symbols.
+func GetLatestTradesWithContext(ctx context.Context, symbols []string, req GetLatestTradeRequest) (map[string]Trade, error) {
+	return DefaultClient.GetLatestTradesWithContext(ctx, symbols, req)
+}
+
 // GetLatestQuote returns the latest quote for a given symbol.
 func GetLatestQuote(symbol string, req GetLatestQuoteRequest) (*Quote, error) {
 	return DefaultClient.GetLatestQuote(symbol, req)
 }
 
+// GetLatestQuoteWithContext returns the latest quote for a given symbol.
+func GetLatestQuoteWithContext(ctx context.Context, symbol string, req GetLatestQuoteRequest) (*Quote, error) {
+	return DefaultClient.GetLatestQuoteWithContext(ctx, symbol, req)
+}
+
 // GetLatestQuotes returns the latest quotes for the given symbols.
 func GetLatestQuotes(symbols []string, req GetLatestQuoteRequest) (map[string]Quote, error) {
 	return DefaultClient.GetLatestQuotes(symbols, req)
 }
 
+// GetLatestQuotesWithContext returns the latest quotes for the given symbols.
+func GetLatestQuotesWithContext(ctx context.Context, symbols []string, req GetLatestQuoteRequest) (map[string]Quote, error) {
+	return DefaultClient.GetLatestQuotesWithContext(ctx, symbols, req)
+}
+
 // GetSnapshot returns the snapshot for a given symbol
 func GetSnapshot(symbol string, req GetSnapshotRequest) (*Snapshot, error) {
 	return DefaultClient.GetSnapshot(symbol, req)
 }
 
+// GetSnapshotWithContext returns the snapshot for a given symbol
+func GetSnapshotWithContext(ctx context.Context, symbol string, req GetSnapshotRequest) (*Snapshot, error) {
+	return DefaultClient.GetSnapshotWithContext(ctx, symbol, req)
+}
+
 // GetSnapshots returns the snapshots for a multiple symbols
 func GetSnapshots(symbols []string, req GetSnapshotRequest) (map[string]*Snapshot, error) {
 	return DefaultClient.GetSnapshots(symbols, req)
 }
 
+// GetSnapshotsWithContext returns the snapshots for a multiple symbols
+func GetSnapshotsWithContext(ctx context.Context, symbols []string, req GetSnapshotRequest) (map[string]*Snapshot, error) {
+	return DefaultClient.GetSnapshotsWithContext(ctx, symbols, req)
+}
+
 // GetCryptoTrades returns the trades for the given crypto symbol.
 func GetCryptoTrades(symbol string, req GetCryptoTradesRequest) ([]CryptoTrade, error) {
 	return DefaultClient.GetCryptoTrades(symbol, req)
 }
 
+// GetCryptoTradesWithContext returns the trades for the given crypto symbol.
+func GetCryptoTradesWithContext(ctx context.Context, symbol string, req GetCryptoTradesRequest) ([]CryptoTrade, error) {
+	return DefaultClient.GetCryptoTradesWithContext(ctx, symbol, req)
+}
+
 // GetCryptoMultiTrades returns trades for the given crypto symbols.
 func GetCryptoMultiTrades(symbols []string, req GetCryptoTradesRequest) (map[string][]CryptoTrade, error) {
 	return DefaultClient.GetCryptoMultiTrades(symbols, req)
 }
 
+// GetCryptoMultiTradesWithContext returns trades for the given crypto symbols.
+func GetCryptoMultiTradesWithContext(ctx context.Context, symbols []string, req GetCryptoTradesRequest) (map[string][]CryptoTrade, error) {
+	return DefaultClient.GetCryptoMultiTradesWithContext(ctx, symbols, req)
+}
+
 // GetCryptoQuotes returns the quotes for the given crypto symbol.
 func GetCryptoQuotes(symbol string, req GetCryptoQuotesRequest) ([]CryptoQuote, error) {
 	return DefaultClient.GetCryptoQuotes(symbol, req)
 }
 
+// GetCryptoQuotesWithContext returns the quotes for the given crypto symbol.
+func GetCryptoQuotesWithContext(ctx context.Context, symbol string, req GetCryptoQuotesRequest) ([]CryptoQuote, error) {
+	return DefaultClient.GetCryptoQuotesWithContext(ctx, symbol, req)
+}
+
 // GetCryptoMultiQuotes returns quotes for the given crypto symbols.
 func GetCryptoMultiQuotes(symbols []string, req GetCryptoQuotesRequest) (map[string][]CryptoQuote, error) {
 	return DefaultClient.GetCryptoMultiQuotes(symbols, req)
 }
 
+// GetCryptoMultiQuotesWithContext returns quotes for the given crypto symbols.
+func GetCryptoMultiQuotesWithContext(ctx context.Context, symbols []string, req GetCryptoQuotesRequest) (map[string][]CryptoQuote, error) {
+	return DefaultClient.GetCryptoMultiQuotesWithContext(ctx, symbols, req)
+}
+
 // GetCryptoBars returns the bars for the given crypto symbol.
 func GetCryptoBars(symbol string, req GetCryptoBarsRequest) ([]CryptoBar, error) {
 	return DefaultClient.GetCryptoBars(symbol, req)
 }
 
+// GetCryptoBarsWithContext returns the bars for the given crypto symbol.
+func GetCryptoBarsWithContext(ctx context.Context, symbol string, req GetCryptoBarsRequest) ([]CryptoBar, error) {
+	return DefaultClient.GetCryptoBarsWithContext(ctx, symbol, req)
+}
+
 // GetCryptoMultiBars returns the bars for the given crypto symbols.
 func GetCryptoMultiBars(symbols []string, req GetCryptoBarsRequest) (map[string][]CryptoBar, error) {
 	return DefaultClient.GetCryptoMultiBars(symbols, req)
 }
 
+// GetCryptoMultiBarsWithContext returns the bars for the given crypto symbols.
+func GetCryptoMultiBarsWithContext(ctx context.Context, symbols []string, req GetCryptoBarsRequest) (map[string][]CryptoBar, error) {
+	return DefaultClient.GetCryptoMultiBarsWithContext(ctx, symbols, req)
+}
+
 // GetLatestCryptoBar returns the latest bar for a given crypto symbol
 func GetLatestCryptoBar(symbol string, req GetLatestCryptoBarRequest) (*CryptoBar, error) {
 	return DefaultClient.GetLatestCryptoBar(symbol, req)
 }
 
+// GetLatestCryptoBarWithContext returns the latest bar for a given crypto symbol
+func GetLatestCryptoBarWithContext(ctx context.Context, symbol string, req GetLatestCryptoBarRequest) (*CryptoBar, error) {
+	return DefaultClient.GetLatestCryptoBarWithContext(ctx, symbol, req)
+}
+
 // GetLatestCryptoBars returns the latest bars for the given crypto symbols
 func GetLatestCryptoBars(symbols []string, req GetLatestCryptoBarRequest) (map[string]CryptoBar, error) {
 	return DefaultClient.GetLatestCryptoBars(symbols, req)
 }
 
+// GetLatestCryptoBarsWithContext returns the latest bars for the given crypto symbols
+func GetLatestCryptoBarsWithContext(ctx context.Context, symbols []string, req GetLatestCryptoBarRequest) (map[string]CryptoBar, error) {
+	return DefaultClient.GetLatestCryptoBarsWithContext(ctx, symbols, req)
+}
+
 // GetLatestCryptoTrade returns the latest trade for a given crypto symbol
 func GetLatestCryptoTrade(symbol string, req GetLatestCryptoTradeRequest) (*CryptoTrade, error) {
 	return DefaultClient.GetLatestCryptoTrade(symbol, req)
 }
 
+// GetLatestCryptoTradeWithContext returns the latest trade for a given crypto symbol
+func GetLatestCryptoTradeWithContext(ctx context.Context, symbol string, req GetLatestCryptoTradeRequest) (*CryptoTrade, error) {
+	return DefaultClient.GetLatestCryptoTradeWithContext(ctx, symbol, req)
+}
+
 // GetLatestCryptoTrades returns the latest trades for the given crypto symbols
 func GetLatestCryptoTrades(symbols []string, req GetLatestCryptoTradeRequest) (map[string]CryptoTrade, error) {
 	return DefaultClient.GetLatestCryptoTrades(symbols, req)
 }
 
+// GetLatestCryptoTradesWithContext returns the latest trades for the given crypto symbols
+func GetLatestCryptoTradesWithContext(ctx context.Context, symbols []string, req GetLatestCryptoTradeRequest) (map[string]CryptoTrade, error) {
+	return DefaultClient.GetLatestCryptoTradesWithContext(ctx, symbols, req)
+}
+
 // GetLatestCryptoQuote returns the latest quote for a given crypto symbol
 func GetLatestCryptoQuote(symbol string, req GetLatestCryptoQuoteRequest) (*CryptoQuote, error) {
 	return DefaultClient.GetLatestCryptoQuote(symbol, req)
 }
 
+// GetLatestCryptoQuoteWithContext returns the latest quote for a given crypto symbol
+func GetLatestCryptoQuoteWithContext(ctx context.Context, symbol string, req GetLatestCryptoQuoteRequest) (*CryptoQuote, error) {
+	return DefaultClient.GetLatestCryptoQuoteWithContext(ctx, symbol, req)
+}
+
 // GetLatestCryptoQuotes returns the latest quotes for the given crypto symbols
 func GetLatestCryptoQuotes(symbols []string, req GetLatestCryptoQuoteRequest) (map[string]CryptoQuote, error) {
 	return DefaultClient.GetLatestCryptoQuotes(symbols, req)
 }
 
+// GetLatestCryptoQuotesWithContext returns the latest quotes for the given crypto symbols
+func GetLatestCryptoQuotesWithContext(ctx context.Context, symbols []string, req GetLatestCryptoQuoteRequest) (map[string]CryptoQuote, error) {
+	return DefaultClient.GetLatestCryptoQuotesWithContext(ctx, symbols, req)
+}
+
 // GetCryptoSnapshot returns the snapshot for a given crypto symbol
 func GetCryptoSnapshot(symbol string, req GetCryptoSnapshotRequest) (*CryptoSnapshot, error) {
 	return DefaultClient.GetCryptoSnapshot(symbol, req)
 }
 
+// GetCryptoSnapshotWithContext returns the snapshot for a given crypto symbol
+func GetCryptoSnapshotWithContext(ctx context.Context, symbol string, req GetCryptoSnapshotRequest) (*CryptoSnapshot, error) {
+	return DefaultClient.GetCryptoSnapshotWithContext(ctx, symbol, req)
+}
+
 // GetCryptoSnapshots returns the snapshots for the given crypto symbols
 func GetCryptoSnapshots(symbols []string, req GetCryptoSnapshotRequest) (map[string]CryptoSnapshot, error) {
 	return DefaultClient.GetCryptoSnapshots(symbols, req)
 }
 
+// GetCryptoSnapshotsWithContext returns the snapshots for the given crypto symbols
+func GetCryptoSnapshotsWithContext(ctx context.Context, symbols []string, req GetCryptoSnapshotRequest) (map[string]CryptoSnapshot, error) {
+	return DefaultClient.GetCryptoSnapshotsWithContext(ctx, symbols, req)
+}
+
 // GetNews returns the news articles based on the given req.
 func GetNews(req GetNewsRequest) ([]News, error) {
 	return DefaultClient.GetNews(req)
 }
 
+// GetNewsWithContext returns the news articles based on the given req.
+func GetNewsWithContext(ctx context.Context, req GetNewsRequest) ([]News, error) {
+	return DefaultClient.GetNewsWithContext(ctx, req)
+}
+
 // GetCorporateActions returns the corporate actions based on the given req.
 func GetCorporateActions(req GetCorporateActionsRequest) (CorporateActions, error) {
 	return DefaultClient.GetCorporateActions(req)
 }
 
-func (c *Client) get(u *url.URL) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+// GetCorporateActionsWithContext returns the corporate actions based on the given req.
+func GetCorporateActionsWithContext(ctx context.Context, req GetCorporateActionsRequest) (CorporateActions, error) {
+	return DefaultClient.GetCorporateActionsWithContext(ctx, req)
+}
+
+func (c *Client) get(ctx context.Context, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1750,11 +2174,7 @@ func (c *Client) get(u *url.URL) (*http.Response, error) {
 }
 
 func unmarshal(resp *http.Response, v easyjson.Unmarshaler) error {
-	defer func() {
-		// The underlying TCP connection can not be reused if the body is not fully read
-		_, _ = io.Copy(io.Discard, resp.Body)
-		resp.Body.Close()
-	}()
+	defer httpbody.Close(resp)
 	var (
 		reader io.ReadCloser
 		err    error
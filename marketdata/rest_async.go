@@ -1,8 +1,16 @@
 package marketdata
 
+import "context"
+
 // GetTradesAsync returns the trades for the given symbol asynchronously, triggering the callback function on each received batch.
 // The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
 func (c *Client) GetTradesAsync(symbol string, req GetTradesPaginatedRequest, callback func(trades []Trade, err error) (keepGoing bool)) error {
+	return c.GetTradesAsyncWithContext(context.Background(), symbol, req, callback)
+}
+
+// GetTradesAsyncWithContext returns the trades for the given symbol asynchronously, triggering the callback function on each received batch.
+// The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
+func (c *Client) GetTradesAsyncWithContext(ctx context.Context, symbol string, req GetTradesPaginatedRequest, callback func(trades []Trade, err error) (keepGoing bool)) error {
 	if req.TotalLimit == 0 && req.PageLimit > 0 {
 		req.TotalLimit = req.PageLimit
 	}
@@ -11,7 +19,7 @@ func (c *Client) GetTradesAsync(symbol string, req GetTradesPaginatedRequest, ca
 	}
 
 	for {
-		resp, nextPageToken, err := c.GetMultiTradesPaginated([]string{symbol}, req)
+		resp, nextPageToken, err := c.GetMultiTradesPaginatedWithContext(ctx, []string{symbol}, req)
 		keepGoing := callback(resp[symbol], err)
 		req.PageToken = nextPageToken
 		if keepGoing && nextPageToken != "" {
@@ -29,6 +37,12 @@ func (c *Client) GetTradesAsync(symbol string, req GetTradesPaginatedRequest, ca
 // GetQuotesAsync returns quotes for the given symbol asynchronously, triggering the callback function on each received batch.
 // The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
 func (c *Client) GetQuotesAsync(symbol string, req GetQuotesPaginatedRequest, callback func(quotes []Quote, err error) (keepGoing bool)) error {
+	return c.GetQuotesAsyncWithContext(context.Background(), symbol, req, callback)
+}
+
+// GetQuotesAsyncWithContext returns quotes for the given symbol asynchronously, triggering the callback function on each received batch.
+// The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
+func (c *Client) GetQuotesAsyncWithContext(ctx context.Context, symbol string, req GetQuotesPaginatedRequest, callback func(quotes []Quote, err error) (keepGoing bool)) error {
 	if req.TotalLimit == 0 && req.PageLimit > 0 {
 		req.TotalLimit = req.PageLimit
 	}
@@ -37,7 +51,7 @@ func (c *Client) GetQuotesAsync(symbol string, req GetQuotesPaginatedRequest, ca
 	}
 
 	for {
-		resp, nextPageToken, err := c.GetMultiQuotesPaginated([]string{symbol}, req)
+		resp, nextPageToken, err := c.GetMultiQuotesPaginatedWithContext(ctx, []string{symbol}, req)
 		keepGoing := callback(resp[symbol], err)
 		req.PageToken = nextPageToken
 		if keepGoing && nextPageToken != "" {
@@ -55,6 +69,12 @@ func (c *Client) GetQuotesAsync(symbol string, req GetQuotesPaginatedRequest, ca
 // GetBarsAsync returns bars for the given symbol asynchronously, triggering the callback function on each received batch.
 // The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
 func (c *Client) GetBarsAsync(symbol string, req GetBarsPaginatedRequest, callback func(bars []Bar, err error) (keepGoing bool)) error {
+	return c.GetBarsAsyncWithContext(context.Background(), symbol, req, callback)
+}
+
+// GetBarsAsyncWithContext returns bars for the given symbol asynchronously, triggering the callback function on each received batch.
+// The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
+func (c *Client) GetBarsAsyncWithContext(ctx context.Context, symbol string, req GetBarsPaginatedRequest, callback func(bars []Bar, err error) (keepGoing bool)) error {
 	if req.TotalLimit == 0 && req.PageLimit > 0 {
 		req.TotalLimit = req.PageLimit
 	}
@@ -63,7 +83,7 @@ func (c *Client) GetBarsAsync(symbol string, req GetBarsPaginatedRequest, callba
 	}
 
 	for {
-		resp, nextPageToken, err := c.GetMultiBarsPaginated([]string{symbol}, req)
+		resp, nextPageToken, err := c.GetMultiBarsPaginatedWithContext(ctx, []string{symbol}, req)
 		keepGoing := callback(resp[symbol], err)
 		req.PageToken = nextPageToken
 		if keepGoing && nextPageToken != "" {
@@ -81,6 +101,12 @@ func (c *Client) GetBarsAsync(symbol string, req GetBarsPaginatedRequest, callba
 // GetAuctionsAsync returns auctions for the given symbol asynchronously, triggering the callback function on each received batch.
 // The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
 func (c *Client) GetAuctionsAsync(symbol string, req GetAuctionsPaginatedRequest, callback func(auctions []DailyAuctions, err error) (keepGoing bool)) error {
+	return c.GetAuctionsAsyncWithContext(context.Background(), symbol, req, callback)
+}
+
+// GetAuctionsAsyncWithContext returns auctions for the given symbol asynchronously, triggering the callback function on each received batch.
+// The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
+func (c *Client) GetAuctionsAsyncWithContext(ctx context.Context, symbol string, req GetAuctionsPaginatedRequest, callback func(auctions []DailyAuctions, err error) (keepGoing bool)) error {
 	if req.TotalLimit == 0 && req.PageLimit > 0 {
 		req.TotalLimit = req.PageLimit
 	}
@@ -89,7 +115,7 @@ func (c *Client) GetAuctionsAsync(symbol string, req GetAuctionsPaginatedRequest
 	}
 
 	for {
-		resp, nextPageToken, err := c.GetMultiAuctionsPaginated([]string{symbol}, req)
+		resp, nextPageToken, err := c.GetMultiAuctionsPaginatedWithContext(ctx, []string{symbol}, req)
 		keepGoing := callback(resp[symbol], err)
 		req.PageToken = nextPageToken
 		if keepGoing && nextPageToken != "" {
@@ -107,6 +133,12 @@ func (c *Client) GetAuctionsAsync(symbol string, req GetAuctionsPaginatedRequest
 // GetCryptoTradesAsync returns trades for the given crypto symbol asynchronously, triggering the callback function on each received batch.
 // The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
 func (c *Client) GetCryptoTradesAsync(symbol string, req GetCryptoTradesPaginatedRequest, callback func(trades []CryptoTrade, err error) (keepGoing bool)) error {
+	return c.GetCryptoTradesAsyncWithContext(context.Background(), symbol, req, callback)
+}
+
+// GetCryptoTradesAsyncWithContext returns trades for the given crypto symbol asynchronously, triggering the callback function on each received batch.
+// The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
+func (c *Client) GetCryptoTradesAsyncWithContext(ctx context.Context, symbol string, req GetCryptoTradesPaginatedRequest, callback func(trades []CryptoTrade, err error) (keepGoing bool)) error {
 	if req.TotalLimit == 0 && req.PageLimit > 0 {
 		req.TotalLimit = req.PageLimit
 	}
@@ -115,7 +147,7 @@ func (c *Client) GetCryptoTradesAsync(symbol string, req GetCryptoTradesPaginate
 	}
 
 	for {
-		resp, nextPageToken, err := c.GetCryptoMultiTradesPaginated([]string{symbol}, req)
+		resp, nextPageToken, err := c.GetCryptoMultiTradesPaginatedWithContext(ctx, []string{symbol}, req)
 		keepGoing := callback(resp[symbol], err)
 		req.PageToken = nextPageToken
 		if keepGoing && nextPageToken != "" {
@@ -133,6 +165,12 @@ func (c *Client) GetCryptoTradesAsync(symbol string, req GetCryptoTradesPaginate
 // GetCryptoBarsAsync returns bars for the given crypto symbol asynchronously, triggering the callback function on each received batch.
 // The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
 func (c *Client) GetCryptoBarsAsync(symbol string, req GetCryptoBarsPaginatedRequest, callback func(bars []CryptoBar, err error) (keepGoing bool)) error {
+	return c.GetCryptoBarsAsyncWithContext(context.Background(), symbol, req, callback)
+}
+
+// GetCryptoBarsAsyncWithContext returns bars for the given crypto symbol asynchronously, triggering the callback function on each received batch.
+// The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
+func (c *Client) GetCryptoBarsAsyncWithContext(ctx context.Context, symbol string, req GetCryptoBarsPaginatedRequest, callback func(bars []CryptoBar, err error) (keepGoing bool)) error {
 	if req.TotalLimit == 0 && req.PageLimit > 0 {
 		req.TotalLimit = req.PageLimit
 	}
@@ -141,7 +179,7 @@ func (c *Client) GetCryptoBarsAsync(symbol string, req GetCryptoBarsPaginatedReq
 	}
 
 	for {
-		resp, nextPageToken, err := c.GetCryptoMultiBarsPaginated([]string{symbol}, req)
+		resp, nextPageToken, err := c.GetCryptoMultiBarsPaginatedWithContext(ctx, []string{symbol}, req)
 		keepGoing := callback(resp[symbol], err)
 		req.PageToken = nextPageToken
 		if keepGoing && nextPageToken != "" {
@@ -159,6 +197,12 @@ func (c *Client) GetCryptoBarsAsync(symbol string, req GetCryptoBarsPaginatedReq
 // GetNewsAsync returns the news articles based on the given req asynchronously, triggering the callback function on each received batch.
 // The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
 func (c *Client) GetNewsAsync(req GetNewsPaginatedRequest, callback func(news []News, err error) (keepGoing bool)) error {
+	return c.GetNewsAsyncWithContext(context.Background(), req, callback)
+}
+
+// GetNewsAsyncWithContext returns the news articles based on the given req asynchronously, triggering the callback function on each received batch.
+// The callback receives the batch, and an error (if there was one). It can return a boolean to decide whether to continue streaming the data or not.
+func (c *Client) GetNewsAsyncWithContext(ctx context.Context, req GetNewsPaginatedRequest, callback func(news []News, err error) (keepGoing bool)) error {
 	if req.TotalLimit == 0 && req.PageLimit > 0 {
 		req.TotalLimit = req.PageLimit
 	}
@@ -167,7 +211,7 @@ func (c *Client) GetNewsAsync(req GetNewsPaginatedRequest, callback func(news []
 	}
 
 	for {
-		resp, nextPageToken, err := c.GetNewsPaginated(req)
+		resp, nextPageToken, err := c.GetNewsPaginatedWithContext(ctx, req)
 		keepGoing := callback(resp, err)
 		req.PageToken = nextPageToken
 		if keepGoing && nextPageToken != "" {
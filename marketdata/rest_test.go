@@ -2,6 +2,8 @@
 package marketdata
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +14,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/civil"
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -47,6 +50,24 @@ func TestDefaultDo_InternalServerError(t *testing.T) {
 	assert.Contains(t, err.Error(), "500")
 }
 
+func TestDefaultDo_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-1")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"code":40310000,"message":"subscription does not permit querying recent SIP data"}`)
+	}))
+	defer server.Close()
+	client := NewClient(ClientOpts{BaseURL: server.URL})
+	_, err := client.GetLatestBar("SPY", GetLatestBarRequest{})
+	var apiErr *alpaca.APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	assert.Equal(t, 40310000, apiErr.Code)
+	assert.Equal(t, "req-1", apiErr.RequestID)
+	assert.Equal(t, http.MethodGet, apiErr.Method)
+	assert.Equal(t, "/v2/stocks/bars/latest", apiErr.Path)
+}
+
 func TestDefaultDo_Retry(t *testing.T) {
 	tryCount := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -106,6 +127,24 @@ func TestDefaultDo_Timeout(t *testing.T) {
 	assert.Contains(t, err.Error(), "Timeout")
 }
 
+func TestDefaultDo_ContextCancelledDuringRetry(t *testing.T) {
+	called := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called++
+		http.Error(w, "too many requests", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	client := NewClient(ClientOpts{
+		BaseURL:    server.URL,
+		RetryDelay: time.Hour,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.GetLatestBarWithContext(ctx, "SPY", GetLatestBarRequest{})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, called)
+}
+
 func mockResp(resp string) func(c *Client, req *http.Request) (*http.Response, error) {
 	return func(c *Client, req *http.Request) (*http.Response, error) {
 		return &http.Response{
@@ -214,6 +253,46 @@ func TestGetTrades_Currency(t *testing.T) {
 	assert.Len(t, got, 0)
 }
 
+func TestGetMultiBars_ContextCancelledBetweenPages(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	called := 0
+	c.do = func(c *Client, req *http.Request) (*http.Response, error) {
+		called++
+		// cancel while the first page is being served
+		cancel()
+		return &http.Response{
+			Body: io.NopCloser(strings.NewReader(`{"bars":{"AAPL":[{"t":"2021-10-13T04:00:00Z","o":141.24,"h":141.4,"l":139.2,"c":140.91,"v":78762721,"n":565190,"vw":140.359}]},"next_page_token":"QUFQTHxEfDIwMjEtMTAtMTNUMDQ6MDA6MDAuMDAwMDAwMDAwWg=="}`)),
+		}, nil
+	}
+	_, err := c.GetMultiBarsWithContext(ctx, []string{"AAPL"}, GetBarsRequest{})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, called)
+}
+
+func TestGetBarsAsyncWithContext(t *testing.T) {
+	c := NewClient(ClientOpts{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.do = mockResp(`{"bars":{"AAPL":[{"t":"2021-10-13T04:00:00Z","o":141.24,"h":141.4,"l":139.2,"c":140.91,"v":78762721,"n":565190,"vw":140.359}]},"next_page_token":"QUFQTHxEfDIwMjEtMTAtMTNUMDQ6MDA6MDAuMDAwMDAwMDAwWg=="}`)
+	batches := 0
+	err := c.GetBarsAsyncWithContext(ctx, "AAPL", GetBarsPaginatedRequest{
+		GetBarsRequest: GetBarsRequest{PageLimit: 1},
+	}, func(bars []Bar, err error) bool {
+		if err != nil {
+			return false
+		}
+		batches++
+		if batches == 2 {
+			cancel()
+		}
+		return true
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, batches)
+}
+
 func TestGetTrades_InvalidURL(t *testing.T) {
 	c := NewClient(ClientOpts{
 		BaseURL: string([]byte{0, 1, 2, 3}),
@@ -41,6 +41,11 @@ type client struct {
 
 	handler msgHandler
 
+	// initializer and messageHandler replace the market data protocol when set,
+	// e.g. by the TradeUpdatesClient
+	initializer    func(ctx context.Context) error
+	messageHandler func(b []byte) error
+
 	pendingSubChangeMutex sync.Mutex
 	pendingSubChange      *subChangeRequest
 
@@ -438,7 +443,7 @@ func (c *client) maintainConnection(ctx context.Context, u url.URL, initialResul
 			c.conn = conn
 
 			c.logger.Infof("datav2stream: established connection")
-			if err := c.initialize(ctx); err != nil {
+			if err := c.initializeConn(ctx); err != nil {
 				connError = err
 				c.conn.close()
 				if isErrorIrrecoverableAtInit(err) {
@@ -491,6 +496,22 @@ func (c *client) maintainConnection(ctx context.Context, u url.URL, initialResul
 	}
 }
 
+// initializeConn runs the initial flow of the protocol of the client on a new connection
+func (c *client) initializeConn(ctx context.Context) error {
+	if c.initializer != nil {
+		return c.initializer(ctx)
+	}
+	return c.initialize(ctx)
+}
+
+// processMessage handles a message received by the client
+func (c *client) processMessage(b []byte) error {
+	if c.messageHandler != nil {
+		return c.messageHandler(b)
+	}
+	return c.handleMessage(b)
+}
+
 // waitTimeout waits for the WaitGroup for the specified max timeout.
 // Returns true if waiting timed out.
 func waitTimeout(wg *sync.WaitGroup, timeout time.Duration) bool {
@@ -669,7 +690,7 @@ func (c *client) messageProcessor(
 			if !ok {
 				return
 			}
-			err := c.handleMessage(msg)
+			err := c.processMessage(msg)
 			if err != nil {
 				c.logger.Errorf("datav2stream: could not handle message, error: %v", err)
 			}
@@ -19,10 +19,21 @@ type nhooyrWebsocketConn struct {
 
 // newNhooyrWebsocketConn creates a new nhooyr websocket connection
 func newNhooyrWebsocketConn(ctx context.Context, u url.URL) (conn, error) {
+	return dialNhooyrWebsocket(ctx, u, "application/msgpack", websocket.MessageBinary)
+}
+
+// newNhooyrJSONWebsocketConn creates a new nhooyr websocket connection that sends JSON text messages
+func newNhooyrJSONWebsocketConn(ctx context.Context, u url.URL) (conn, error) {
+	return dialNhooyrWebsocket(ctx, u, "application/json", websocket.MessageText)
+}
+
+func dialNhooyrWebsocket(
+	ctx context.Context, u url.URL, contentType string, msgType websocket.MessageType,
+) (conn, error) {
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 	reqHeader := http.Header{}
-	reqHeader.Set("Content-Type", "application/msgpack")
+	reqHeader.Set("Content-Type", contentType)
 	reqHeader.Set("User-Agent", alpaca.Version())
 	conn, _, err := websocket.Dial(ctxWithTimeout, u.String(), &websocket.DialOptions{
 		CompressionMode: websocket.CompressionContextTakeover,
@@ -37,7 +48,7 @@ func newNhooyrWebsocketConn(ctx context.Context, u url.URL) (conn, error) {
 
 	return &nhooyrWebsocketConn{
 		conn:    conn,
-		msgType: websocket.MessageBinary,
+		msgType: msgType,
 	}, nil
 }
 
@@ -5,6 +5,8 @@ import (
 	"net/url"
 	"os"
 	"time"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
 )
 
 // StockOption is a configuration option for the StockClient
@@ -25,12 +27,18 @@ type NewsOption interface {
 	applyNews(*newsOptions)
 }
 
-// Option is a configuration option that can be used for both StockClient and CryptoClient
+// TradeUpdatesOption is a configuration option for the TradeUpdatesClient
+type TradeUpdatesOption interface {
+	applyTradeUpdates(*tradeUpdatesOptions)
+}
+
+// Option is a configuration option that can be used for all clients
 type Option interface {
 	StockOption
 	CryptoOption
 	OptionOption
 	NewsOption
+	TradeUpdatesOption
 }
 
 type options struct {
@@ -71,6 +79,10 @@ func (fo *funcOption) applyNews(o *newsOptions) {
 	fo.f(&o.options)
 }
 
+func (fo *funcOption) applyTradeUpdates(o *tradeUpdatesOptions) {
+	fo.f(&o.options)
+}
+
 func newFuncOption(f func(*options)) *funcOption {
 	return &funcOption{
 		f: f,
@@ -563,3 +575,59 @@ func WithNews(handler func(News), symbols ...string) NewsOption {
 		o.newsHandler = handler
 	})
 }
+
+type tradeUpdatesOptions struct {
+	options
+	tradeUpdateHandler func(alpaca.TradeUpdate)
+}
+
+// defaultTradeUpdatesOptions are the default options for a client.
+// Don't change this in a backward incompatible way!
+func defaultTradeUpdatesOptions() *tradeUpdatesOptions {
+	baseURL := "https://api.alpaca.markets"
+	if s := os.Getenv("APCA_API_BASE_URL"); s != "" {
+		baseURL = s
+	}
+
+	return &tradeUpdatesOptions{
+		options: options{
+			logger:         DefaultLogger(),
+			baseURL:        baseURL,
+			key:            os.Getenv("APCA_API_KEY_ID"),
+			secret:         os.Getenv("APCA_API_SECRET_KEY"),
+			reconnectLimit: 20,
+			reconnectDelay: 150 * time.Millisecond,
+			processorCount: 1,
+			bufferSize:     1000,
+			connCreator:    newNhooyrJSONWebsocketConn,
+		},
+		tradeUpdateHandler: func(tu alpaca.TradeUpdate) {},
+	}
+}
+
+func (o *tradeUpdatesOptions) applyTradeUpdates(opts ...TradeUpdatesOption) {
+	for _, opt := range opts {
+		opt.applyTradeUpdates(o)
+	}
+}
+
+type funcTradeUpdatesOption struct {
+	f func(*tradeUpdatesOptions)
+}
+
+func (fo *funcTradeUpdatesOption) applyTradeUpdates(o *tradeUpdatesOptions) {
+	fo.f(o)
+}
+
+func newFuncTradeUpdatesOption(f func(*tradeUpdatesOptions)) *funcTradeUpdatesOption {
+	return &funcTradeUpdatesOption{
+		f: f,
+	}
+}
+
+// WithTradeUpdates configures the trade updates handler
+func WithTradeUpdates(handler func(alpaca.TradeUpdate)) TradeUpdatesOption {
+	return newFuncTradeUpdatesOption(func(o *tradeUpdatesOptions) {
+		o.tradeUpdateHandler = handler
+	})
+}
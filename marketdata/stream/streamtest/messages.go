@@ -0,0 +1,154 @@
+package streamtest
+
+import (
+	"github.com/alpacahq/alpaca-trade-api-go/v3/marketdata/stream"
+)
+
+// SendTrade sends a stock trade to the clients subscribed to the trades of its symbol.
+func (s *Server) SendTrade(t stream.Trade) {
+	s.publish("trades", message{
+		{"T", "t"}, {"i", t.ID}, {"S", t.Symbol}, {"x", t.Exchange}, {"p", t.Price}, {"s", t.Size},
+		{"t", t.Timestamp}, {"c", t.Conditions}, {"z", t.Tape},
+	}, t.Symbol)
+}
+
+// SendQuote sends a stock quote to the clients subscribed to the quotes of its symbol.
+func (s *Server) SendQuote(q stream.Quote) {
+	s.publish("quotes", message{
+		{"T", "q"}, {"S", q.Symbol}, {"bx", q.BidExchange}, {"bp", q.BidPrice}, {"bs", q.BidSize},
+		{"ax", q.AskExchange}, {"ap", q.AskPrice}, {"as", q.AskSize}, {"t", q.Timestamp}, {"c", q.Conditions}, {"z", q.Tape},
+	}, q.Symbol)
+}
+
+// SendBar sends a minute bar to the clients subscribed to the bars of its symbol.
+func (s *Server) SendBar(b stream.Bar) {
+	s.publish("bars", stockBarMessage("b", b), b.Symbol)
+}
+
+// SendUpdatedBar sends an updated bar to the clients subscribed to the updated bars of its symbol.
+func (s *Server) SendUpdatedBar(b stream.Bar) {
+	s.publish("updatedBars", stockBarMessage("u", b), b.Symbol)
+}
+
+// SendDailyBar sends a daily bar to the clients subscribed to the daily bars of its symbol.
+func (s *Server) SendDailyBar(b stream.Bar) {
+	s.publish("dailyBars", stockBarMessage("d", b), b.Symbol)
+}
+
+func stockBarMessage(typ string, b stream.Bar) message {
+	return message{
+		{"T", typ}, {"S", b.Symbol}, {"o", b.Open}, {"h", b.High}, {"l", b.Low}, {"c", b.Close}, {"v", b.Volume},
+		{"t", b.Timestamp}, {"n", b.TradeCount}, {"vw", b.VWAP},
+	}
+}
+
+// SendTradingStatus sends a trading status to the clients subscribed to the statuses of its symbol.
+func (s *Server) SendTradingStatus(ts stream.TradingStatus) {
+	s.publish("statuses", message{
+		{"T", "s"}, {"S", ts.Symbol}, {"sc", ts.StatusCode}, {"sm", ts.StatusMsg}, {"rc", ts.ReasonCode},
+		{"rm", ts.ReasonMsg}, {"t", ts.Timestamp}, {"z", ts.Tape},
+	}, ts.Symbol)
+}
+
+// SendLULD sends a LULD to the clients subscribed to the LULDs of its symbol.
+func (s *Server) SendLULD(l stream.LULD) {
+	s.publish("lulds", message{
+		{"T", "l"}, {"S", l.Symbol}, {"u", l.LimitUpPrice}, {"d", l.LimitDownPrice}, {"i", l.Indicator},
+		{"t", l.Timestamp}, {"z", l.Tape},
+	}, l.Symbol)
+}
+
+// SendCancelError sends a trade cancel or error to the clients subscribed to the trades of its symbol.
+func (s *Server) SendCancelError(tce stream.TradeCancelError) {
+	s.publish("trades", message{
+		{"T", "x"}, {"S", tce.Symbol}, {"i", tce.ID}, {"x", tce.Exchange}, {"p", tce.Price}, {"s", tce.Size},
+		{"a", tce.CancelErrorAction}, {"z", tce.Tape}, {"t", tce.Timestamp},
+	}, tce.Symbol)
+}
+
+// SendCorrection sends a trade correction to the clients subscribed to the trades of its symbol.
+func (s *Server) SendCorrection(tc stream.TradeCorrection) {
+	s.publish("trades", message{
+		{"T", "c"}, {"S", tc.Symbol}, {"x", tc.Exchange},
+		{"oi", tc.OriginalID}, {"op", tc.OriginalPrice}, {"os", tc.OriginalSize}, {"oc", tc.OriginalConditions},
+		{"ci", tc.CorrectedID}, {"cp", tc.CorrectedPrice}, {"cs", tc.CorrectedSize}, {"cc", tc.CorrectedConditions},
+		{"z", tc.Tape}, {"t", tc.Timestamp},
+	}, tc.Symbol)
+}
+
+// SendCryptoTrade sends a crypto trade to the clients subscribed to the trades of its symbol.
+func (s *Server) SendCryptoTrade(t stream.CryptoTrade) {
+	s.publish("trades", message{
+		{"T", "t"}, {"S", t.Symbol}, {"x", t.Exchange}, {"p", t.Price}, {"s", t.Size}, {"t", t.Timestamp},
+		{"i", t.ID}, {"tks", t.TakerSide},
+	}, t.Symbol)
+}
+
+// SendCryptoQuote sends a crypto quote to the clients subscribed to the quotes of its symbol.
+func (s *Server) SendCryptoQuote(q stream.CryptoQuote) {
+	s.publish("quotes", message{
+		{"T", "q"}, {"S", q.Symbol}, {"x", q.Exchange}, {"bp", q.BidPrice}, {"bs", q.BidSize},
+		{"ap", q.AskPrice}, {"as", q.AskSize}, {"t", q.Timestamp},
+	}, q.Symbol)
+}
+
+// SendCryptoBar sends a crypto minute bar to the clients subscribed to the bars of its symbol.
+func (s *Server) SendCryptoBar(b stream.CryptoBar) {
+	s.publish("bars", cryptoBarMessage("b", b), b.Symbol)
+}
+
+// SendCryptoUpdatedBar sends an updated crypto bar to the clients subscribed to the updated bars of its symbol.
+func (s *Server) SendCryptoUpdatedBar(b stream.CryptoBar) {
+	s.publish("updatedBars", cryptoBarMessage("u", b), b.Symbol)
+}
+
+// SendCryptoDailyBar sends a daily crypto bar to the clients subscribed to the daily bars of its symbol.
+func (s *Server) SendCryptoDailyBar(b stream.CryptoBar) {
+	s.publish("dailyBars", cryptoBarMessage("d", b), b.Symbol)
+}
+
+func cryptoBarMessage(typ string, b stream.CryptoBar) message {
+	return message{
+		{"T", typ}, {"S", b.Symbol}, {"x", b.Exchange}, {"o", b.Open}, {"h", b.High}, {"l", b.Low}, {"c", b.Close},
+		{"v", b.Volume}, {"t", b.Timestamp}, {"n", b.TradeCount}, {"vw", b.VWAP},
+	}
+}
+
+// SendCryptoOrderbook sends an orderbook update to the clients subscribed to the orderbooks of its symbol.
+func (s *Server) SendCryptoOrderbook(ob stream.CryptoOrderbook) {
+	entries := func(entries []stream.CryptoOrderbookEntry) []message {
+		m := make([]message, 0, len(entries))
+		for _, e := range entries {
+			m = append(m, message{{"p", e.Price}, {"s", e.Size}})
+		}
+		return m
+	}
+	s.publish("orderbooks", message{
+		{"T", "o"}, {"S", ob.Symbol}, {"x", ob.Exchange}, {"t", ob.Timestamp},
+		{"b", entries(ob.Bids)}, {"a", entries(ob.Asks)}, {"r", ob.Reset},
+	}, ob.Symbol)
+}
+
+// SendOptionTrade sends an option trade to the clients subscribed to the trades of its symbol.
+func (s *Server) SendOptionTrade(t stream.OptionTrade) {
+	s.publish("trades", message{
+		{"T", "t"}, {"S", t.Symbol}, {"x", t.Exchange}, {"p", t.Price}, {"s", t.Size}, {"t", t.Timestamp}, {"c", t.Condition},
+	}, t.Symbol)
+}
+
+// SendOptionQuote sends an option quote to the clients subscribed to the quotes of its symbol.
+func (s *Server) SendOptionQuote(q stream.OptionQuote) {
+	s.publish("quotes", message{
+		{"T", "q"}, {"S", q.Symbol}, {"bx", q.BidExchange}, {"bp", q.BidPrice}, {"bs", q.BidSize},
+		{"ax", q.AskExchange}, {"ap", q.AskPrice}, {"as", q.AskSize}, {"t", q.Timestamp}, {"c", q.Condition},
+	}, q.Symbol)
+}
+
+// SendNews sends a news article to the clients subscribed to the news of any of its symbols.
+func (s *Server) SendNews(n stream.News) {
+	s.publish("news", message{
+		{"T", "n"}, {"id", n.ID}, {"headline", n.Headline}, {"summary", n.Summary}, {"author", n.Author},
+		{"content", n.Content}, {"url", n.URL}, {"created_at", n.CreatedAt}, {"updated_at", n.UpdatedAt},
+		{"symbols", n.Symbols},
+	}, n.Symbols...)
+}
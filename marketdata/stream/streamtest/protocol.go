@@ -0,0 +1,151 @@
+package streamtest
+
+import (
+	"context"
+	"sort"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// channels are the subscribable channels, in the order they appear in the requests.
+var channels = []string{
+	"trades", "quotes", "bars", "updatedBars", "dailyBars", "statuses", "lulds", "orderbooks", "news",
+}
+
+type request struct {
+	Action string `msgpack:"action"`
+	Key    string `msgpack:"key"`
+	Secret string `msgpack:"secret"`
+
+	Trades      []string `msgpack:"trades"`
+	Quotes      []string `msgpack:"quotes"`
+	Bars        []string `msgpack:"bars"`
+	UpdatedBars []string `msgpack:"updatedBars"`
+	DailyBars   []string `msgpack:"dailyBars"`
+	Statuses    []string `msgpack:"statuses"`
+	LULDs       []string `msgpack:"lulds"`
+	Orderbooks  []string `msgpack:"orderbooks"`
+	News        []string `msgpack:"news"`
+}
+
+func (r request) symbols() map[string][]string {
+	return map[string][]string{
+		"trades":      r.Trades,
+		"quotes":      r.Quotes,
+		"bars":        r.Bars,
+		"updatedBars": r.UpdatedBars,
+		"dailyBars":   r.DailyBars,
+		"statuses":    r.Statuses,
+		"lulds":       r.LULDs,
+		"orderbooks":  r.Orderbooks,
+		"news":        r.News,
+	}
+}
+
+// handle processes a message of the client. It only returns an error if the connection failed.
+func (s *Server) handle(ctx context.Context, sess *session, data []byte) error {
+	var req request
+	if err := msgpack.Unmarshal(data, &req); err != nil {
+		return sess.write(ctx, errorMessage(ErrInvalidSyntax))
+	}
+	switch req.Action {
+	case "auth":
+		return sess.write(ctx, s.authenticate(sess, req))
+	case "subscribe", "unsubscribe":
+		return sess.write(ctx, s.subscribe(sess, req))
+	}
+	return sess.write(ctx, errorMessage(ErrInvalidSyntax))
+}
+
+// authenticate returns the response to the auth request of sess.
+func (s *Server) authenticate(sess *session, req request) message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sess.authenticated {
+		return errorMessage(ErrAlreadyAuthenticated)
+	}
+	if len(s.authErrors) > 0 {
+		err := s.authErrors[0]
+		s.authErrors = s.authErrors[1:]
+		return errorMessage(err)
+	}
+	if (s.opts.Key != "" || s.opts.Secret != "") && (req.Key != s.opts.Key || req.Secret != s.opts.Secret) {
+		return errorMessage(ErrAuthFailed)
+	}
+	if s.opts.MaxConnections > 0 {
+		n := 0
+		for other := range s.sessions {
+			if other.authenticated {
+				n++
+			}
+		}
+		if n >= s.opts.MaxConnections {
+			return errorMessage(ErrConnectionLimitExceeded)
+		}
+	}
+	sess.authenticated = true
+	return controlMessage("success", "authenticated")
+}
+
+// subscribe applies the subscription change of sess and returns the response.
+func (s *Server) subscribe(sess *session, req request) message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !sess.authenticated {
+		return errorMessage(ErrNotAuthenticated)
+	}
+	if len(s.subErrors) > 0 {
+		err := s.subErrors[0]
+		s.subErrors = s.subErrors[1:]
+		return errorMessage(err)
+	}
+
+	for channel, symbols := range req.symbols() {
+		if sess.sub[channel] == nil {
+			sess.sub[channel] = make(map[string]bool)
+		}
+		for _, symbol := range symbols {
+			if req.Action == "subscribe" {
+				sess.sub[channel][symbol] = true
+			} else {
+				delete(sess.sub[channel], symbol)
+			}
+		}
+	}
+
+	resp := message{{"T", "subscription"}}
+	for _, channel := range channels {
+		symbols := make([]string, 0, len(sess.sub[channel]))
+		for symbol := range sess.sub[channel] {
+			symbols = append(symbols, symbol)
+		}
+		sort.Strings(symbols)
+		resp = append(resp, field{channel, symbols})
+		if channel == "trades" {
+			// cancel errors and corrections are subscribed together with the trades
+			resp = append(resp, field{"cancelErrors", symbols}, field{"corrections", symbols})
+		}
+	}
+	return resp
+}
+
+// broadcast sends msg to the authenticated sessions accepted by filter.
+func (s *Server) broadcast(filter func(*session) bool, msg message) {
+	s.mu.Lock()
+	var targets []*session
+	for sess := range s.sessions {
+		if sess.authenticated && filter(sess) {
+			targets = append(targets, sess)
+		}
+	}
+	s.mu.Unlock()
+	for _, sess := range targets {
+		// a failed write means that the client is gone, which is not the concern of the sender
+		_ = sess.write(s.ctx, msg)
+	}
+}
+
+// publish sends msg to the sessions subscribed to any of symbols on channel.
+func (s *Server) publish(channel string, msg message, symbols ...string) {
+	s.broadcast(func(sess *session) bool { return sess.subscribed(channel, symbols...) }, msg)
+}
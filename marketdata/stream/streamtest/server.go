@@ -0,0 +1,256 @@
+// Package streamtest provides a fake Alpaca market data stream server for tests.
+//
+// The server runs on a local port and speaks the same msgpack protocol as the real
+// stream: it welcomes the clients, authenticates them, keeps track of their
+// subscriptions and delivers the messages pushed by the test to the subscribers:
+//
+//	srv := streamtest.NewServer(streamtest.ServerOpts{})
+//	defer srv.Close()
+//	c := stream.NewStocksClient(marketdata.IEX, stream.WithBaseURL(srv.URL),
+//		stream.WithTrades(handler, "AAPL"))
+//	err := c.Connect(ctx)
+//	srv.SendTrade(stream.Trade{Symbol: "AAPL", Price: 180})
+//
+// Every connection is accepted regardless of its path, so the same server can be
+// used by stock, crypto, option and news clients.
+package streamtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"nhooyr.io/websocket"
+)
+
+// Error is an error message sent by the server.
+type Error struct {
+	Code int
+	Msg  string
+}
+
+// The errors sent by the real server.
+var (
+	ErrNotAuthenticated              = Error{Code: 401, Msg: "not authenticated"}
+	ErrAuthFailed                    = Error{Code: 402, Msg: "auth failed"}
+	ErrAlreadyAuthenticated          = Error{Code: 403, Msg: "already authenticated"}
+	ErrSymbolLimitExceeded           = Error{Code: 405, Msg: "symbol limit exceeded"}
+	ErrConnectionLimitExceeded       = Error{Code: 406, Msg: "connection limit exceeded"}
+	ErrSlowClient                    = Error{Code: 407, Msg: "slow client"}
+	ErrInsufficientSubscription      = Error{Code: 409, Msg: "insufficient subscription"}
+	ErrInvalidSubscribeActionForFeed = Error{Code: 410, Msg: "invalid subscribe action for this feed"}
+	ErrInsufficientScope             = Error{Code: 411, Msg: "insufficient scope"}
+	ErrInvalidSyntax                 = Error{Code: 400, Msg: "invalid syntax"}
+)
+
+type ServerOpts struct {
+	// Key and Secret are the credentials accepted by the server.
+	// If both are empty, any credentials are accepted.
+	Key    string
+	Secret string
+	// MaxConnections is the number of authenticated connections allowed at the same time.
+	// Further clients get ErrConnectionLimitExceeded when they authenticate. 0 means no limit.
+	MaxConnections int
+}
+
+// Server is a fake market data stream server. All methods are safe for concurrent use.
+type Server struct {
+	// URL is the base URL of the server, to be used with stream.WithBaseURL.
+	URL string
+
+	opts   ServerOpts
+	srv    *httptest.Server
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu         sync.Mutex
+	sessions   map[*session]struct{}
+	authErrors []Error
+	subErrors  []Error
+}
+
+// NewServer starts a new fake server. Close must be called when the server is not needed anymore.
+func NewServer(opts ServerOpts) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Server{
+		opts:     opts,
+		ctx:      ctx,
+		cancel:   cancel,
+		sessions: make(map[*session]struct{}),
+	}
+	s.srv = httptest.NewServer(s)
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close disconnects the clients and stops the server.
+func (s *Server) Close() {
+	s.cancel()
+	s.CloseConnections()
+	s.srv.Close()
+}
+
+// CloseConnections drops every open connection. The clients are expected to reconnect.
+func (s *Server) CloseConnections() {
+	s.mu.Lock()
+	sessions := make([]*session, 0, len(s.sessions))
+	for sess := range s.sessions {
+		sessions = append(sessions, sess)
+	}
+	s.mu.Unlock()
+	for _, sess := range sessions {
+		sess.conn.Close(websocket.StatusGoingAway, "")
+	}
+}
+
+// Connections returns the number of authenticated connections.
+func (s *Server) Connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for sess := range s.sessions {
+		if sess.authenticated {
+			n++
+		}
+	}
+	return n
+}
+
+// FailNextAuth makes the next authentication attempt fail with err.
+// Multiple calls queue multiple failures.
+func (s *Server) FailNextAuth(err Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authErrors = append(s.authErrors, err)
+}
+
+// FailNextSubscription makes the next subscribe or unsubscribe request fail with err.
+// Multiple calls queue multiple failures.
+func (s *Server) FailNextSubscription(err Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subErrors = append(s.subErrors, err)
+}
+
+// SendError sends err to every authenticated connection, e.g. ErrSlowClient.
+func (s *Server) SendError(err Error) {
+	s.broadcast(func(*session) bool { return true }, errorMessage(err))
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{
+		CompressionMode: websocket.CompressionContextTakeover,
+	})
+	if err != nil {
+		return
+	}
+	conn.SetReadLimit(-1)
+	sess := &session{conn: conn, sub: make(map[string]map[string]bool)}
+
+	s.mu.Lock()
+	s.sessions[sess] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.sessions, sess)
+		s.mu.Unlock()
+		conn.Close(websocket.StatusNormalClosure, "")
+	}()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	if err := sess.write(ctx, controlMessage("success", "connected")); err != nil {
+		return
+	}
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+		if err := s.handle(ctx, sess, data); err != nil {
+			return
+		}
+	}
+}
+
+// session is a single client connection.
+type session struct {
+	conn *websocket.Conn
+	// writeMu serializes the writes of conn
+	writeMu sync.Mutex
+
+	// the fields below are guarded by Server.mu
+	authenticated bool
+	// sub maps the channels (trades, quotes, etc.) to the subscribed symbols
+	sub map[string]map[string]bool
+}
+
+func (sess *session) write(ctx context.Context, msgs ...message) error {
+	b, err := msgpack.Marshal(msgs)
+	if err != nil {
+		return err
+	}
+	sess.writeMu.Lock()
+	defer sess.writeMu.Unlock()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return sess.conn.Write(ctx, websocket.MessageBinary, b)
+}
+
+// subscribed returns whether the session is subscribed to any of symbols on channel.
+func (sess *session) subscribed(channel string, symbols ...string) bool {
+	subscribed := sess.sub[channel]
+	if subscribed["*"] {
+		return true
+	}
+	for _, symbol := range symbols {
+		if subscribed[symbol] {
+			return true
+		}
+	}
+	return false
+}
+
+// message is a msgpack map that keeps the order of its fields: the clients expect "T" to come first.
+type message []field
+
+type field struct {
+	key   string
+	value interface{}
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+func (m message) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if err := enc.EncodeMapLen(len(m)); err != nil {
+		return err
+	}
+	for _, f := range m {
+		if err := enc.EncodeString(f.key); err != nil {
+			return err
+		}
+		if err := enc.Encode(f.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func controlMessage(typ, msg string) message {
+	return message{{"T", typ}, {"msg", msg}}
+}
+
+func errorMessage(err Error) message {
+	return message{{"T", "error"}, {"code", err.Code}, {"msg", err.Msg}}
+}
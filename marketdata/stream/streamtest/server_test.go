@@ -0,0 +1,296 @@
+package streamtest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/marketdata"
+	"github.com/alpacahq/alpaca-trade-api-go/v3/marketdata/stream"
+)
+
+const timeout = 3 * time.Second
+
+func receive[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(timeout):
+		t.Fatal("message not received")
+	}
+	var zero T
+	return zero
+}
+
+func TestStocks(t *testing.T) {
+	srv := NewServer(ServerOpts{Key: "key", Secret: "secret"})
+	defer srv.Close()
+
+	trades := make(chan stream.Trade, 1)
+	quotes := make(chan stream.Quote, 1)
+	bars := make(chan stream.Bar, 1)
+	dailyBars := make(chan stream.Bar, 1)
+	statuses := make(chan stream.TradingStatus, 1)
+	lulds := make(chan stream.LULD, 1)
+	cancelErrors := make(chan stream.TradeCancelError, 1)
+	corrections := make(chan stream.TradeCorrection, 1)
+	c := stream.NewStocksClient(marketdata.IEX,
+		stream.WithBaseURL(srv.URL),
+		stream.WithCredentials("key", "secret"),
+		stream.WithTrades(func(t stream.Trade) { trades <- t }, "AAPL"),
+		stream.WithQuotes(func(q stream.Quote) { quotes <- q }, "AAPL"),
+		stream.WithBars(func(b stream.Bar) { bars <- b }, "*"),
+		stream.WithDailyBars(func(b stream.Bar) { dailyBars <- b }, "AAPL"),
+		stream.WithStatuses(func(ts stream.TradingStatus) { statuses <- ts }, "AAPL"),
+		stream.WithLULDs(func(l stream.LULD) { lulds <- l }, "AAPL"),
+		stream.WithCancelErrors(func(tce stream.TradeCancelError) { cancelErrors <- tce }),
+		stream.WithCorrections(func(tc stream.TradeCorrection) { corrections <- tc }),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, c.Connect(ctx))
+	assert.Equal(t, 1, srv.Connections())
+
+	ts := time.Date(2024, 3, 1, 15, 30, 0, 0, time.UTC)
+	// MSFT is not subscribed to, so the first trade must not be delivered
+	srv.SendTrade(stream.Trade{Symbol: "MSFT", Price: 400})
+	srv.SendTrade(stream.Trade{ID: 1, Symbol: "AAPL", Exchange: "V", Price: 180.5, Size: 10,
+		Timestamp: ts, Conditions: []string{"@"}, Tape: "C"})
+	trade := receive(t, trades)
+	assert.EqualValues(t, 1, trade.ID)
+	assert.Equal(t, "AAPL", trade.Symbol)
+	assert.Equal(t, "V", trade.Exchange)
+	assert.Equal(t, 180.5, trade.Price)
+	assert.EqualValues(t, 10, trade.Size)
+	assert.True(t, ts.Equal(trade.Timestamp))
+	assert.Equal(t, []string{"@"}, trade.Conditions)
+	assert.Equal(t, "C", trade.Tape)
+
+	srv.SendQuote(stream.Quote{Symbol: "AAPL", BidPrice: 180.1, AskPrice: 180.2, BidSize: 1, AskSize: 2})
+	quote := receive(t, quotes)
+	assert.Equal(t, 180.1, quote.BidPrice)
+	assert.Equal(t, 180.2, quote.AskPrice)
+	assert.EqualValues(t, 2, quote.AskSize)
+
+	srv.SendBar(stream.Bar{Symbol: "TSLA", Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 100, TradeCount: 3, VWAP: 1.2})
+	bar := receive(t, bars)
+	assert.Equal(t, "TSLA", bar.Symbol)
+	assert.Equal(t, 1.5, bar.Close)
+	assert.EqualValues(t, 100, bar.Volume)
+	assert.EqualValues(t, 3, bar.TradeCount)
+
+	srv.SendDailyBar(stream.Bar{Symbol: "AAPL", Close: 181})
+	assert.Equal(t, 181.0, receive(t, dailyBars).Close)
+
+	srv.SendTradingStatus(stream.TradingStatus{Symbol: "AAPL", StatusCode: "H", StatusMsg: "Trading Halt"})
+	assert.Equal(t, "H", receive(t, statuses).StatusCode)
+
+	srv.SendLULD(stream.LULD{Symbol: "AAPL", LimitUpPrice: 190, LimitDownPrice: 170})
+	luld := receive(t, lulds)
+	assert.Equal(t, 190.0, luld.LimitUpPrice)
+	assert.Equal(t, 170.0, luld.LimitDownPrice)
+
+	srv.SendCancelError(stream.TradeCancelError{Symbol: "AAPL", ID: 1, CancelErrorAction: "C"})
+	tce := receive(t, cancelErrors)
+	assert.EqualValues(t, 1, tce.ID)
+	assert.Equal(t, "C", tce.CancelErrorAction)
+
+	srv.SendCorrection(stream.TradeCorrection{Symbol: "AAPL", OriginalID: 1, CorrectedID: 2, CorrectedPrice: 180.4})
+	tc := receive(t, corrections)
+	assert.EqualValues(t, 2, tc.CorrectedID)
+	assert.Equal(t, 180.4, tc.CorrectedPrice)
+}
+
+func TestSubscriptionChanges(t *testing.T) {
+	srv := NewServer(ServerOpts{})
+	defer srv.Close()
+
+	trades := make(chan stream.Trade, 10)
+	c := stream.NewStocksClient(marketdata.SIP, stream.WithBaseURL(srv.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, c.Connect(ctx))
+
+	require.NoError(t, c.SubscribeToTrades(func(t stream.Trade) { trades <- t }, "AAPL", "MSFT"))
+	require.NoError(t, c.UnsubscribeFromTrades("AAPL"))
+	srv.SendTrade(stream.Trade{Symbol: "AAPL", Price: 1})
+	srv.SendTrade(stream.Trade{Symbol: "MSFT", Price: 2})
+	assert.Equal(t, "MSFT", receive(t, trades).Symbol)
+
+	srv.FailNextSubscription(ErrInsufficientSubscription)
+	err := c.SubscribeToTrades(func(t stream.Trade) { trades <- t }, "TSLA")
+	assert.ErrorIs(t, err, stream.ErrInsufficientSubscription)
+}
+
+func TestCryptoAndOptions(t *testing.T) {
+	srv := NewServer(ServerOpts{})
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cryptoTrades := make(chan stream.CryptoTrade, 1)
+	orderbooks := make(chan stream.CryptoOrderbook, 1)
+	cc := stream.NewCryptoClient(marketdata.US,
+		stream.WithBaseURL(srv.URL),
+		stream.WithCryptoTrades(func(t stream.CryptoTrade) { cryptoTrades <- t }, "BTC/USD"),
+		stream.WithCryptoOrderbooks(func(ob stream.CryptoOrderbook) { orderbooks <- ob }, "BTC/USD"),
+	)
+	require.NoError(t, cc.Connect(ctx))
+
+	optionTrades := make(chan stream.OptionTrade, 1)
+	optionQuotes := make(chan stream.OptionQuote, 1)
+	oc := stream.NewOptionClient(marketdata.Indicative,
+		stream.WithBaseURL(srv.URL),
+		stream.WithOptionTrades(func(t stream.OptionTrade) { optionTrades <- t }, "AAPL240315C00180000"),
+		stream.WithOptionQuotes(func(q stream.OptionQuote) { optionQuotes <- q }, "AAPL240315C00180000"),
+	)
+	require.NoError(t, oc.Connect(ctx))
+	assert.Equal(t, 2, srv.Connections())
+
+	srv.SendCryptoTrade(stream.CryptoTrade{Symbol: "BTC/USD", Price: 65000, Size: 0.1, ID: 7, TakerSide: marketdata.TakerSideBuy})
+	ct := receive(t, cryptoTrades)
+	assert.Equal(t, 65000.0, ct.Price)
+	assert.Equal(t, 0.1, ct.Size)
+	assert.EqualValues(t, 7, ct.ID)
+	assert.Equal(t, marketdata.TakerSideBuy, ct.TakerSide)
+
+	srv.SendCryptoOrderbook(stream.CryptoOrderbook{
+		Symbol: "BTC/USD",
+		Bids:   []stream.CryptoOrderbookEntry{{Price: 64999, Size: 1}},
+		Asks:   []stream.CryptoOrderbookEntry{{Price: 65001, Size: 2}},
+		Reset:  true,
+	})
+	ob := receive(t, orderbooks)
+	assert.Equal(t, []stream.CryptoOrderbookEntry{{Price: 64999, Size: 1}}, ob.Bids)
+	assert.Equal(t, []stream.CryptoOrderbookEntry{{Price: 65001, Size: 2}}, ob.Asks)
+	assert.True(t, ob.Reset)
+
+	srv.SendOptionTrade(stream.OptionTrade{Symbol: "AAPL240315C00180000", Price: 2.5, Size: 3, Condition: "I"})
+	ot := receive(t, optionTrades)
+	assert.Equal(t, 2.5, ot.Price)
+	assert.Equal(t, "I", ot.Condition)
+
+	srv.SendOptionQuote(stream.OptionQuote{Symbol: "AAPL240315C00180000", BidPrice: 2.4, AskPrice: 2.6})
+	oq := receive(t, optionQuotes)
+	assert.Equal(t, 2.4, oq.BidPrice)
+	assert.Equal(t, 2.6, oq.AskPrice)
+}
+
+func TestNews(t *testing.T) {
+	srv := NewServer(ServerOpts{})
+	defer srv.Close()
+
+	news := make(chan stream.News, 1)
+	c := stream.NewNewsClient(
+		stream.WithBaseURL(srv.URL),
+		stream.WithNews(func(n stream.News) { news <- n }, "TSLA"),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, c.Connect(ctx))
+
+	createdAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	srv.SendNews(stream.News{ID: 1, Headline: "not relevant", Symbols: []string{"AAPL"}})
+	srv.SendNews(stream.News{ID: 2, Headline: "Tesla news", Author: "someone", CreatedAt: createdAt,
+		Symbols: []string{"AAPL", "TSLA"}})
+	n := receive(t, news)
+	assert.Equal(t, 2, n.ID)
+	assert.Equal(t, "Tesla news", n.Headline)
+	assert.Equal(t, "someone", n.Author)
+	assert.True(t, createdAt.Equal(n.CreatedAt))
+	assert.Equal(t, []string{"AAPL", "TSLA"}, n.Symbols)
+}
+
+func TestAuthErrors(t *testing.T) {
+	srv := NewServer(ServerOpts{Key: "key", Secret: "secret"})
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := stream.NewStocksClient(marketdata.IEX,
+		stream.WithBaseURL(srv.URL), stream.WithCredentials("key", "wrong"))
+	err := c.Connect(ctx)
+	assert.ErrorIs(t, err, stream.ErrInvalidCredentials)
+
+	// the connection limit is retried by the client
+	srv.FailNextAuth(ErrConnectionLimitExceeded)
+	c = stream.NewStocksClient(marketdata.IEX,
+		stream.WithBaseURL(srv.URL), stream.WithCredentials("key", "secret"))
+	require.NoError(t, c.Connect(ctx))
+	assert.Equal(t, 1, srv.Connections())
+}
+
+func TestMaxConnections(t *testing.T) {
+	srv := NewServer(ServerOpts{MaxConnections: 1})
+	defer srv.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, stream.NewStocksClient(marketdata.IEX, stream.WithBaseURL(srv.URL)).Connect(ctx))
+	srv.FailNextAuth(ErrInsufficientScope)
+	err := stream.NewStocksClient(marketdata.IEX, stream.WithBaseURL(srv.URL)).Connect(ctx)
+	assert.ErrorIs(t, err, stream.ErrInsufficientScope)
+	assert.Equal(t, 1, srv.Connections())
+}
+
+type recordingLogger struct {
+	mu   sync.Mutex
+	logs []string
+}
+
+func (l *recordingLogger) Infof(format string, v ...interface{}) {}
+
+func (l *recordingLogger) Warnf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logs = append(l.logs, fmt.Sprintf(format, v...))
+}
+
+func (l *recordingLogger) Errorf(format string, v ...interface{}) { l.Warnf(format, v...) }
+
+func (l *recordingLogger) contains(s string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, log := range l.logs {
+		if strings.Contains(log, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestErrorsAndReconnect(t *testing.T) {
+	srv := NewServer(ServerOpts{})
+	defer srv.Close()
+
+	logger := &recordingLogger{}
+	trades := make(chan stream.Trade, 1)
+	connected := make(chan struct{}, 2)
+	c := stream.NewStocksClient(marketdata.IEX,
+		stream.WithBaseURL(srv.URL),
+		stream.WithLogger(logger),
+		stream.WithReconnectSettings(5, 10*time.Millisecond),
+		stream.WithConnectCallback(func() { connected <- struct{}{} }),
+		stream.WithTrades(func(t stream.Trade) { trades <- t }, "AAPL"),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, c.Connect(ctx))
+	receive(t, connected)
+
+	srv.SendError(ErrSlowClient)
+	assert.Eventually(t, func() bool { return logger.contains("slow client") }, timeout, 10*time.Millisecond)
+
+	srv.CloseConnections()
+	receive(t, connected)
+	// the client resubscribes after reconnecting
+	srv.SendTrade(stream.Trade{Symbol: "AAPL", Price: 1})
+	assert.Equal(t, "AAPL", receive(t, trades).Symbol)
+}
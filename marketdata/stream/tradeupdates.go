@@ -0,0 +1,166 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+)
+
+const (
+	tradeUpdatesStream  = "trade_updates"
+	authorizationStream = "authorization"
+	listeningStream     = "listening"
+)
+
+// TradeUpdatesClient is a client that connects to the trading websocket stream
+// of Alpaca and receives the updates of the orders of the account.
+// It is an alternative to alpaca.StreamTradeUpdates that uses a websocket
+// instead of server-sent events.
+//
+// After constructing, Connect() must be called. Connect keeps the connection alive
+// and reestablishes it until a configured number of retries has not been exceeded.
+//
+// Terminated() returns a channel that the client sends an error to when it has terminated.
+// A client can not be reused once it has terminated!
+type TradeUpdatesClient struct {
+	*client
+
+	tradeUpdateHandler func(alpaca.TradeUpdate)
+}
+
+// NewTradeUpdatesClient returns a new TradeUpdatesClient whose default
+// configurations are modified by opts.
+func NewTradeUpdatesClient(opts ...TradeUpdatesOption) *TradeUpdatesClient {
+	tc := TradeUpdatesClient{
+		client: newClient(),
+	}
+	tc.client.initializer = tc.initialize
+	tc.client.messageHandler = tc.handleMessage
+	o := defaultTradeUpdatesOptions()
+	o.applyTradeUpdates(opts...)
+	tc.configure(*o)
+	return &tc
+}
+
+func (tc *TradeUpdatesClient) configure(o tradeUpdatesOptions) {
+	tc.client.configure(o.options)
+	tc.tradeUpdateHandler = o.tradeUpdateHandler
+}
+
+// Connect establishes a connection and **reestablishes it when errors occur**
+// as long as the configured number of retries has not been exceeded.
+//
+// It blocks until the connection has been established for the first time (or it failed to do so).
+//
+// **Should only be called once!**
+func (tc *TradeUpdatesClient) Connect(ctx context.Context) error {
+	u, err := tc.constructURL()
+	if err != nil {
+		return err
+	}
+	return tc.connect(ctx, u)
+}
+
+func (tc *TradeUpdatesClient) constructURL() (url.URL, error) {
+	return constructURL(strings.TrimSuffix(tc.baseURL, "/"), "stream")
+}
+
+type tradeUpdatesMessage struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// initialize authenticates and starts listening to the trade updates.
+// Unlike the market data streams the trading stream does not send a welcome message.
+func (tc *TradeUpdatesClient) initialize(ctx context.Context) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, initializeTimeout)
+	defer cancel()
+
+	if err := tc.writeJSON(ctxWithTimeout, map[string]interface{}{
+		"action": "auth",
+		"key":    tc.key,
+		"secret": tc.secret,
+	}); err != nil {
+		return fmt.Errorf("failed to write auth: %w", err)
+	}
+	var auth struct {
+		Status string `json:"status"`
+	}
+	if err := tc.readControl(ctxWithTimeout, authorizationStream, &auth); err != nil {
+		return fmt.Errorf("failed to read auth response: %w", err)
+	}
+	switch auth.Status {
+	case "authorized":
+	case "unauthorized":
+		return ErrInvalidCredentials
+	default:
+		return ErrBadAuthResponse
+	}
+
+	if err := tc.writeJSON(ctxWithTimeout, map[string]interface{}{
+		"action": "listen",
+		"data": map[string]interface{}{
+			"streams": []string{tradeUpdatesStream},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to write listen: %w", err)
+	}
+	var listening struct {
+		Streams []string `json:"streams"`
+	}
+	if err := tc.readControl(ctxWithTimeout, listeningStream, &listening); err != nil {
+		return fmt.Errorf("failed to read listen response: %w", err)
+	}
+	for _, s := range listening.Streams {
+		if s == tradeUpdatesStream {
+			return nil
+		}
+	}
+	return ErrSubResponse
+}
+
+func (tc *TradeUpdatesClient) writeJSON(ctx context.Context, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return tc.conn.writeMessage(ctx, b)
+}
+
+// readControl reads the next message and decodes its data into v. It fails if the
+// message was sent on a different stream than expected.
+func (tc *TradeUpdatesClient) readControl(ctx context.Context, expected string, v interface{}) error {
+	b, err := tc.conn.readMessage(ctx)
+	if err != nil {
+		return err
+	}
+	var msg tradeUpdatesMessage
+	if err := json.Unmarshal(b, &msg); err != nil {
+		return err
+	}
+	if msg.Stream != expected {
+		return fmt.Errorf("unexpected stream %q, expected %q", msg.Stream, expected)
+	}
+	return json.Unmarshal(msg.Data, v)
+}
+
+func (tc *TradeUpdatesClient) handleMessage(b []byte) error {
+	var msg tradeUpdatesMessage
+	if err := json.Unmarshal(b, &msg); err != nil {
+		return err
+	}
+	if msg.Stream != tradeUpdatesStream {
+		tc.logger.Warnf("datav2stream: unexpected message on stream %q", msg.Stream)
+		return nil
+	}
+	var tu alpaca.TradeUpdate
+	if err := json.Unmarshal(msg.Data, &tu); err != nil {
+		return err
+	}
+	tc.tradeUpdateHandler(tu)
+	return nil
+}
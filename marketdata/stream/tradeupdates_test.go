@@ -0,0 +1,178 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alpacahq/alpaca-trade-api-go/v3/alpaca"
+)
+
+func serializeToJSON(t *testing.T, v interface{}) []byte {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
+func writeTradeUpdatesInitialFlowToConn(t *testing.T, connection *mockConn) {
+	connection.readCh <- serializeToJSON(t, map[string]interface{}{
+		"stream": "authorization",
+		"data":   map[string]interface{}{"action": "authenticate", "status": "authorized"},
+	})
+	connection.readCh <- serializeToJSON(t, map[string]interface{}{
+		"stream": "listening",
+		"data":   map[string]interface{}{"streams": []string{"trade_updates"}},
+	})
+}
+
+func expectJSONWrite(t *testing.T, connection *mockConn) map[string]interface{} {
+	select {
+	case b := <-connection.writeCh:
+		var msg map[string]interface{}
+		require.NoError(t, json.Unmarshal(b, &msg))
+		return msg
+	case <-time.After(time.Second):
+		require.Fail(t, "no message written in time")
+	}
+	return nil
+}
+
+func TestTradeUpdatesClientConstructURL(t *testing.T) {
+	for _, tt := range []struct {
+		baseURL  string
+		expected string
+	}{
+		{baseURL: "https://paper-api.alpaca.markets", expected: "wss://paper-api.alpaca.markets/stream"},
+		{baseURL: "https://api.alpaca.markets/", expected: "wss://api.alpaca.markets/stream"},
+		{baseURL: "http://localhost:8080", expected: "ws://localhost:8080/stream"},
+	} {
+		c := NewTradeUpdatesClient(WithBaseURL(tt.baseURL))
+		u, err := c.constructURL()
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, u.String())
+	}
+}
+
+func TestTradeUpdatesClientCoreFunctionality(t *testing.T) {
+	connection := newMockConn()
+	defer connection.close()
+	connCreator := func(ctx context.Context, u url.URL) (conn, error) {
+		return connection, nil
+	}
+	writeTradeUpdatesInitialFlowToConn(t, connection)
+
+	updates := make(chan alpaca.TradeUpdate, 10)
+	c := NewTradeUpdatesClient(
+		WithCredentials("key", "secret"),
+		WithTradeUpdates(func(tu alpaca.TradeUpdate) { updates <- tu }),
+		withConnCreator(connCreator))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, c.Connect(ctx))
+
+	auth := expectJSONWrite(t, connection)
+	assert.Equal(t, "auth", auth["action"])
+	assert.Equal(t, "key", auth["key"])
+	assert.Equal(t, "secret", auth["secret"])
+	listen := expectJSONWrite(t, connection)
+	assert.Equal(t, "listen", listen["action"])
+	assert.Equal(t, map[string]interface{}{"streams": []interface{}{"trade_updates"}}, listen["data"])
+
+	connection.readCh <- []byte(`{
+		"stream": "trade_updates",
+		"data": {
+			"event": "fill",
+			"event_id": "01HWK1D0K0W2F3C2H8G6X6C3YH",
+			"execution_id": "exec",
+			"price": "179.08",
+			"qty": "1",
+			"position_qty": "1",
+			"timestamp": "2024-04-29T14:05:23.123Z",
+			"order": {
+				"id": "61e69015-8549-4bfd-b9c3-01e75843f47d",
+				"symbol": "AAPL",
+				"side": "buy",
+				"status": "filled",
+				"filled_qty": "1"
+			}
+		}
+	}`)
+
+	select {
+	case tu := <-updates:
+		assert.Equal(t, alpaca.TradeEventFill, tu.Event)
+		assert.Equal(t, "01HWK1D0K0W2F3C2H8G6X6C3YH", tu.EventID)
+		assert.Equal(t, "AAPL", tu.Order.Symbol)
+		assert.Equal(t, alpaca.OrderStatusFilled, tu.Order.Status)
+		require.NotNil(t, tu.Price)
+		assert.Equal(t, "179.08", tu.Price.String())
+	case <-time.After(time.Second):
+		require.Fail(t, "no trade update received in time")
+	}
+}
+
+func TestTradeUpdatesClientInvalidCredentials(t *testing.T) {
+	connection := newMockConn()
+	defer connection.close()
+	connCreator := func(ctx context.Context, u url.URL) (conn, error) {
+		return connection, nil
+	}
+	connection.readCh <- serializeToJSON(t, map[string]interface{}{
+		"stream": "authorization",
+		"data":   map[string]interface{}{"action": "authenticate", "status": "unauthorized"},
+	})
+
+	// invalid credentials are irrecoverable, so the retries must not be used up
+	c := NewTradeUpdatesClient(WithReconnectSettings(20, time.Second), withConnCreator(connCreator))
+	err := c.Connect(context.Background())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrInvalidCredentials))
+}
+
+func TestTradeUpdatesClientReconnects(t *testing.T) {
+	connections := make(chan *mockConn, 2)
+	for i := 0; i < 2; i++ {
+		connection := newMockConn()
+		defer connection.close()
+		writeTradeUpdatesInitialFlowToConn(t, connection)
+		connections <- connection
+	}
+	var current *mockConn
+	connCreator := func(ctx context.Context, u url.URL) (conn, error) {
+		current = <-connections
+		return current, nil
+	}
+
+	connects := make(chan struct{}, 2)
+	disconnects := make(chan struct{}, 2)
+	c := NewTradeUpdatesClient(
+		WithReconnectSettings(1, 0),
+		WithConnectCallback(func() { connects <- struct{}{} }),
+		WithDisconnectCallback(func() { disconnects <- struct{}{} }),
+		withConnCreator(connCreator))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, c.Connect(ctx))
+
+	waitFor := func(ch <-chan struct{}, name string) {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			require.Fail(t, name+" callback was not called in time")
+		}
+	}
+	waitFor(connects, "connect")
+
+	// the server drops the connection
+	current.close()
+	waitFor(disconnects, "disconnect")
+	waitFor(connects, "connect")
+}